@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/df-mc/dragonfly/server"
 	"github.com/df-mc/dragonfly/server/player/chat"
@@ -12,13 +13,23 @@ import (
 func main() {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	chat.Global.Subscribe(chat.StdoutSubscriber{})
-	conf, err := readConfig(slog.Default())
+	uc, err := readConfig()
+	if err != nil {
+		panic(err)
+	}
+	conf, err := uc.Config(slog.Default())
 	if err != nil {
 		panic(err)
 	}
 
 	srv := conf.New()
 	srv.CloseOnProgramEnd()
+	go srv.WatchConfig("config.toml", uc).Run(context.Background())
+	go func() {
+		if err := srv.Console().Run(os.Stdin); err != nil {
+			slog.Error(err.Error())
+		}
+	}()
 
 	srv.Listen()
 	for p := range srv.Accept() {
@@ -28,25 +39,24 @@ func main() {
 
 // readConfig reads the configuration from the config.toml file, or creates the
 // file if it does not yet exist.
-func readConfig(log *slog.Logger) (server.Config, error) {
+func readConfig() (server.UserConfig, error) {
 	c := server.DefaultConfig()
-	var zero server.Config
 	if _, err := os.Stat("config.toml"); os.IsNotExist(err) {
 		data, err := toml.Marshal(c)
 		if err != nil {
-			return zero, fmt.Errorf("encode default config: %v", err)
+			return c, fmt.Errorf("encode default config: %v", err)
 		}
 		if err := os.WriteFile("config.toml", data, 0644); err != nil {
-			return zero, fmt.Errorf("create default config: %v", err)
+			return c, fmt.Errorf("create default config: %v", err)
 		}
-		return c.Config(log)
+		return c, nil
 	}
 	data, err := os.ReadFile("config.toml")
 	if err != nil {
-		return zero, fmt.Errorf("read config: %v", err)
+		return c, fmt.Errorf("read config: %v", err)
 	}
 	if err := toml.Unmarshal(data, &c); err != nil {
-		return zero, fmt.Errorf("decode config: %v", err)
+		return c, fmt.Errorf("decode config: %v", err)
 	}
-	return c.Config(log)
+	return c, nil
 }
@@ -3,6 +3,7 @@ package server
 import (
 	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
 	"net"
+	"slices"
 )
 
 // Allower may be implemented to specifically allow or disallow players from
@@ -25,3 +26,24 @@ type allower struct{}
 func (allower) Allow(net.Addr, login.IdentityData, login.ClientData) (string, bool) {
 	return "", true
 }
+
+// Allowers combines the Allowers passed into a single Allower, for example to
+// use both a BanList and a Whitelist. A connection is allowed only if all
+// Allowers allow it. The Allowers are called in the order passed and the
+// message of the first Allower to disallow the connection is used.
+func Allowers(a ...Allower) Allower {
+	return allowers(slices.Clone(a))
+}
+
+// allowers is an Allower that combines multiple Allowers.
+type allowers []Allower
+
+// Allow returns false if any of the Allowers returns false.
+func (a allowers) Allow(addr net.Addr, d login.IdentityData, c login.ClientData) (string, bool) {
+	for _, allower := range a {
+		if msg, ok := allower.Allow(addr, d, c); !ok {
+			return msg, false
+		}
+	}
+	return "", true
+}
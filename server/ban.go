@@ -0,0 +1,349 @@
+package server
+
+import (
+	"fmt"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BanEntry is an entry of a BanList, holding a player that is banned from a
+// Server.
+type BanEntry struct {
+	// Name is the name of the banned player. Names are case-insensitive. The
+	// name is only used to match the player until its XUID is known. After
+	// that, it is kept only to display the entry.
+	Name string `json:"name"`
+	// XUID is the XUID of the banned player. If empty, it is set to the XUID
+	// of the first player with the Name that tries to join, so that the player
+	// remains banned after changing their name.
+	XUID string `json:"xuid,omitempty"`
+	// Reason is the reason for the ban, which is shown to the player when
+	// they try to join.
+	Reason string `json:"reason,omitempty"`
+	// Source is the name of whoever created the ban.
+	Source string `json:"source,omitempty"`
+	// Created is the time at which the ban was created. It is set to the
+	// current time by BanList.Ban if zero.
+	Created time.Time `json:"created"`
+	// Expires is the time at which the ban expires. If zero, the ban never
+	// expires.
+	Expires time.Time `json:"expires,omitzero"`
+}
+
+// Expired checks if the BanEntry has expired.
+func (e BanEntry) Expired() bool {
+	return expired(e.Expires)
+}
+
+// BanList is a list of players that are banned from a Server. Players are
+// matched by their XUID or, for entries without an XUID, by their name.
+// BanList implements Allower
+// and may be set as Config.Allower, optionally combined with other Allowers
+// using Allowers. If the BanList was loaded from a file, every change to the
+// list is written back to that file and changes made to the file by others
+// are picked up automatically. BanList is safe for concurrent use.
+type BanList struct {
+	mu      sync.Mutex
+	f       listFile[[]BanEntry]
+	entries []BanEntry
+}
+
+// NewBanList returns a BanList holding the entries passed. Changes to the
+// BanList returned are not persisted.
+func NewBanList(entries ...BanEntry) *BanList {
+	return &BanList{entries: slices.Clone(entries)}
+}
+
+// LoadBanList loads a BanList from the JSON file passed. The file holds an
+// array of BanEntry objects and is created if it does not yet exist.
+func LoadBanList(file string) (*BanList, error) {
+	l := &BanList{f: listFile[[]BanEntry]{path: file}}
+	if _, err := l.f.load(&l.entries); err != nil {
+		return nil, fmt.Errorf("load ban list: %w", err)
+	}
+	return l, nil
+}
+
+// Ban adds the BanEntry passed to the BanList, replacing any existing entry
+// for the same player. The XUID of the entry may be empty if it is not known.
+// An error is returned if the list could not be written
+// to its file.
+func (l *BanList) Ban(e BanEntry) error {
+	if e.Created.IsZero() {
+		e.Created = time.Now()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.f.load(&l.entries); err != nil {
+		return fmt.Errorf("ban: %w", err)
+	}
+	l.entries = slices.DeleteFunc(l.entries, func(other BanEntry) bool {
+		return other.Expired() || matchesPlayer(other.Name, other.XUID, e.Name, e.XUID)
+	})
+	l.entries = append(l.entries, e)
+	return l.f.save(l.entries)
+}
+
+// Unban removes the entries with the name or XUID passed from the BanList.
+// False is returned if no such entry existed. An error is returned if the
+// list could not be written to its file.
+func (l *BanList) Unban(nameOrXUID string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.f.load(&l.entries); err != nil {
+		return false, fmt.Errorf("unban: %w", err)
+	}
+	n := len(l.entries)
+	l.entries = slices.DeleteFunc(l.entries, func(e BanEntry) bool {
+		return strings.EqualFold(e.Name, nameOrXUID) || (e.XUID != "" && e.XUID == nameOrXUID)
+	})
+	if len(l.entries) == n {
+		return false, nil
+	}
+	return true, l.f.save(l.entries)
+}
+
+// Banned looks up the BanEntry of a player with the name and XUID passed.
+// The XUID may be empty. False is returned if the player is not banned or if
+// their ban expired.
+func (l *BanList) Banned(name, xuid string) (BanEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.f.load(&l.entries)
+	if i := l.index(name, xuid); i != -1 {
+		return l.entries[i], true
+	}
+	return BanEntry{}, false
+}
+
+// Entries returns all entries of the BanList that have not expired.
+func (l *BanList) Entries() []BanEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.f.load(&l.entries)
+	return slices.DeleteFunc(slices.Clone(l.entries), BanEntry.Expired)
+}
+
+// Allow disallows players that are in the BanList. If the player matched an
+// entry by its name only, the XUID of the player is stored in the entry.
+func (l *BanList) Allow(_ net.Addr, d login.IdentityData, _ login.ClientData) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.f.load(&l.entries)
+	i := l.index(d.DisplayName, d.XUID)
+	if i == -1 {
+		return "", true
+	}
+	e := &l.entries[i]
+	if e.XUID == "" && d.XUID != "" {
+		e.XUID, e.Name = d.XUID, d.DisplayName
+		_ = l.f.save(l.entries)
+	}
+	return banMessage(e.Reason, e.Expires), false
+}
+
+// index returns the index of the entry that bans the player with the name and
+// XUID passed, or -1 if the player is not banned or if their ban expired.
+// index must only be called while l.mu is locked.
+func (l *BanList) index(name, xuid string) int {
+	return slices.IndexFunc(l.entries, func(e BanEntry) bool {
+		return !e.Expired() && matchesPlayer(e.Name, e.XUID, name, xuid)
+	})
+}
+
+// IPBanEntry is an entry of an IPBanList, holding an IP address or network
+// that is banned from a Server.
+type IPBanEntry struct {
+	// Address is either a single IP address, such as "192.168.1.10", or a
+	// network in CIDR notation, such as "192.168.1.0/24".
+	Address string `json:"address"`
+	// Reason is the reason for the ban, which is shown to players when they
+	// try to join.
+	Reason string `json:"reason,omitempty"`
+	// Source is the name of whoever created the ban.
+	Source string `json:"source,omitempty"`
+	// Created is the time at which the ban was created. It is set to the
+	// current time by IPBanList.Ban if zero.
+	Created time.Time `json:"created"`
+	// Expires is the time at which the ban expires. If zero, the ban never
+	// expires.
+	Expires time.Time `json:"expires,omitzero"`
+}
+
+// Expired checks if the IPBanEntry has expired.
+func (e IPBanEntry) Expired() bool {
+	return expired(e.Expires)
+}
+
+// prefix parses the Address of the IPBanEntry. A single IP address is
+// returned as a prefix holding only that address.
+func (e IPBanEntry) prefix() (netip.Prefix, error) {
+	if strings.Contains(e.Address, "/") {
+		p, err := netip.ParsePrefix(e.Address)
+		return p.Masked(), err
+	}
+	ip, err := netip.ParseAddr(e.Address)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	ip = ip.Unmap()
+	return netip.PrefixFrom(ip, ip.BitLen()), nil
+}
+
+// IPBanList is a list of IP addresses and networks that are banned from a
+// Server. IPBanList implements Allower and may be set as Config.Allower,
+// optionally combined with other Allowers using Allowers. If the IPBanList
+// was loaded from a file, every change to the list is written back to that
+// file and changes made to the file by others are picked up automatically.
+// IPBanList is safe for concurrent use.
+type IPBanList struct {
+	mu      sync.Mutex
+	f       listFile[[]IPBanEntry]
+	entries []IPBanEntry
+}
+
+// NewIPBanList returns an IPBanList holding the entries passed. Changes to
+// the IPBanList returned are not persisted. Entries with an invalid Address
+// are ignored.
+func NewIPBanList(entries ...IPBanEntry) *IPBanList {
+	return &IPBanList{entries: slices.Clone(entries)}
+}
+
+// LoadIPBanList loads an IPBanList from the JSON file passed. The file holds
+// an array of IPBanEntry objects and is created if it does not yet exist.
+func LoadIPBanList(file string) (*IPBanList, error) {
+	l := &IPBanList{f: listFile[[]IPBanEntry]{path: file}}
+	if _, err := l.f.load(&l.entries); err != nil {
+		return nil, fmt.Errorf("load ip ban list: %w", err)
+	}
+	return l, nil
+}
+
+// Ban adds the IPBanEntry passed to the IPBanList, replacing any existing
+// entry with the same address. An error is returned if the Address of the
+// entry is not a valid IP address or network, or if the list could not be
+// written to its file.
+func (l *IPBanList) Ban(e IPBanEntry) error {
+	p, err := e.prefix()
+	if err != nil {
+		return fmt.Errorf("ban ip: %w", err)
+	}
+	if e.Created.IsZero() {
+		e.Created = time.Now()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.f.load(&l.entries); err != nil {
+		return fmt.Errorf("ban ip: %w", err)
+	}
+	l.entries = slices.DeleteFunc(l.entries, func(other IPBanEntry) bool {
+		op, err := other.prefix()
+		return other.Expired() || (err == nil && op == p)
+	})
+	l.entries = append(l.entries, e)
+	return l.f.save(l.entries)
+}
+
+// Unban removes the entry with the IP address or network passed from the
+// IPBanList. False is returned if no such entry existed. An error is returned
+// if the list could not be written to its file.
+func (l *IPBanList) Unban(address string) (bool, error) {
+	p, err := IPBanEntry{Address: address}.prefix()
+	if err != nil {
+		return false, fmt.Errorf("unban ip: %w", err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.f.load(&l.entries); err != nil {
+		return false, fmt.Errorf("unban ip: %w", err)
+	}
+	n := len(l.entries)
+	l.entries = slices.DeleteFunc(l.entries, func(e IPBanEntry) bool {
+		op, err := e.prefix()
+		return err == nil && op == p
+	})
+	if len(l.entries) == n {
+		return false, nil
+	}
+	return true, l.f.save(l.entries)
+}
+
+// Banned looks up the IPBanEntry that matches the IP address passed. False is
+// returned if the address is not banned or if its ban expired.
+func (l *IPBanList) Banned(ip netip.Addr) (IPBanEntry, bool) {
+	ip = ip.Unmap()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.f.load(&l.entries)
+	for _, e := range l.entries {
+		if p, err := e.prefix(); err == nil && !e.Expired() && p.Contains(ip) {
+			return e, true
+		}
+	}
+	return IPBanEntry{}, false
+}
+
+// Entries returns all entries of the IPBanList that have not expired.
+func (l *IPBanList) Entries() []IPBanEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.f.load(&l.entries)
+	return slices.DeleteFunc(slices.Clone(l.entries), IPBanEntry.Expired)
+}
+
+// Allow disallows connections from IP addresses that are in the IPBanList.
+func (l *IPBanList) Allow(addr net.Addr, _ login.IdentityData, _ login.ClientData) (string, bool) {
+	ip, ok := addrIP(addr)
+	if !ok {
+		return "", true
+	}
+	if e, ok := l.Banned(ip); ok {
+		return banMessage(e.Reason, e.Expires), false
+	}
+	return "", true
+}
+
+// addrIP returns the IP address of the net.Addr passed. False is returned if
+// the net.Addr does not have an IP address.
+func addrIP(addr net.Addr) (netip.Addr, bool) {
+	switch addr := addr.(type) {
+	case *net.UDPAddr:
+		ip, ok := netip.AddrFromSlice(addr.IP)
+		return ip.Unmap(), ok
+	case *net.TCPAddr:
+		ip, ok := netip.AddrFromSlice(addr.IP)
+		return ip.Unmap(), ok
+	}
+	if addr == nil {
+		return netip.Addr{}, false
+	}
+	if ap, err := netip.ParseAddrPort(addr.String()); err == nil {
+		return ap.Addr().Unmap(), true
+	}
+	ip, err := netip.ParseAddr(addr.String())
+	return ip.Unmap(), err == nil
+}
+
+// expired checks if a ban with the expiry time passed has expired.
+func expired(expires time.Time) bool {
+	return !expires.IsZero() && time.Now().After(expires)
+}
+
+// banMessage returns the disconnect message shown to players that are banned
+// with the reason and expiry time passed.
+func banMessage(reason string, expires time.Time) string {
+	msg := "You are banned from this server."
+	if reason != "" {
+		msg += "\nReason: " + reason
+	}
+	if !expires.IsZero() {
+		msg += "\nYour ban expires on " + expires.Format(time.DateTime) + "."
+	}
+	return msg
+}
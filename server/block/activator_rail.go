@@ -0,0 +1,92 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// ActivatorRail is a rail that makes entities riding a minecart dismount when
+// the minecart moves over it while it is powered by redstone. Activator rails
+// cannot curve.
+type ActivatorRail struct {
+	empty
+	transparent
+
+	// Shape is the shape of the rail, which specifies the directions that it
+	// connects to. Activator rails only have straight shapes.
+	Shape RailShape
+	// Powered specifies if the rail is powered. An activator rail passes its
+	// power on to up to eight activator rails connected to it.
+	Powered bool
+}
+
+// BreakInfo ...
+func (r ActivatorRail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(ActivatorRail{}))
+}
+
+// UseOnBlock ...
+func (r ActivatorRail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, r)
+	if !used || !railSupported(pos, tx) {
+		return false
+	}
+	r.Shape = placementRailShape(pos, user, r, tx)
+	r.Powered = railPowered(pos, r, tx)
+
+	place(tx, pos, r, user, ctx)
+	if placed(ctx) {
+		connectRail(pos, r, tx)
+	}
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r ActivatorRail) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !railSupported(pos, tx) {
+		breakBlock(r, pos, tx)
+		return
+	}
+	if powered := railPowered(pos, r, tx); powered != r.Powered {
+		r.Powered = powered
+		tx.SetBlock(pos, r, nil)
+		updateAroundRedstone(pos, tx)
+	}
+}
+
+// Ascending returns true if the rail slopes upwards.
+func (r ActivatorRail) Ascending() bool {
+	_, ok := r.Shape.Ascending()
+	return ok
+}
+
+// EncodeItem ...
+func (ActivatorRail) EncodeItem() (name string, meta int16) {
+	return "minecraft:activator_rail", 0
+}
+
+// EncodeBlock ...
+func (r ActivatorRail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:activator_rail", map[string]any{"rail_direction": int32(r.Shape.Uint8()), "rail_data_bit": boolByte(r.Powered)}
+}
+
+// railShape ...
+func (r ActivatorRail) railShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r ActivatorRail) withRailShape(s RailShape) world.Block {
+	r.Shape = s
+	return r
+}
+
+// allActivatorRails ...
+func allActivatorRails() (rails []world.Block) {
+	for _, s := range RailShapes()[:6] {
+		rails = append(rails, ActivatorRail{Shape: s}, ActivatorRail{Shape: s, Powered: true})
+	}
+	return
+}
@@ -0,0 +1,252 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"math"
+)
+
+// Bed is a block that allows players to sleep through the night and to set their spawn point. A bed consists of
+// two blocks: A foot and a head. Beds explode when used outside the overworld.
+type Bed struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Colour is the colour of the bed.
+	Colour item.Colour
+	// Facing is the direction that the bed is facing. The head of the bed is on this side of the foot.
+	Facing cube.Direction
+	// Head is true if the block is the head of the bed, and false if it is the foot.
+	Head bool
+	// Occupied is true if a player is currently sleeping in the bed.
+	Occupied bool
+}
+
+// BedMessage is a message sent to a user of a Bed, such as the reason that it
+// was not able to sleep in the bed.
+type BedMessage int
+
+const (
+	// BedRespawnSet is sent when the spawn point of the user was set to the bed.
+	BedRespawnSet BedMessage = iota
+	// BedNoSleep is sent when the user attempts to sleep during the day.
+	BedNoSleep
+	// BedOccupied is sent when another player is already sleeping in the bed.
+	BedOccupied
+	// BedTooFar is sent when the user is too far away from the bed to use it.
+	BedTooFar
+	// BedNotSafe is sent when hostile mobs are near the bed.
+	BedNotSafe
+)
+
+// sleeper represents an item.User that is able to sleep in a Bed.
+type sleeper interface {
+	world.Sleeper
+	UUID() uuid.UUID
+	// SetBedSpawn sets the spawn point of the sleeper to the bed with its head
+	// at the position passed.
+	SetBedSpawn(head cube.Pos)
+	// SendBedMessage sends a BedMessage to the sleeper.
+	SendBedMessage(m BedMessage)
+}
+
+// MaxCount always returns 1.
+func (Bed) MaxCount() int {
+	return 1
+}
+
+// Model ...
+func (Bed) Model() world.BlockModel {
+	return model.Bed{}
+}
+
+// SideClosed ...
+func (Bed) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// BreakInfo ...
+func (b Bed) BreakInfo() BreakInfo {
+	return newBreakInfo(0.2, alwaysHarvestable, nothingEffective, simpleDrops(item.NewStack(Bed{Colour: b.Colour}, 1)))
+}
+
+// Pick ...
+func (b Bed) Pick() item.Stack {
+	return item.NewStack(Bed{Colour: b.Colour}, 1)
+}
+
+// UseOnBlock places the foot of the bed at the position clicked and the head of the bed in the direction that the
+// user is facing.
+func (b Bed) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, b)
+	if !used {
+		return false
+	}
+	b.Facing = user.Rotation().Direction()
+	head := pos.Side(b.Facing.Face())
+	if !replaceableWith(tx, head, b) {
+		return false
+	}
+	if !tx.Block(pos.Side(cube.FaceDown)).Model().FaceSolid(pos.Side(cube.FaceDown), cube.FaceUp, tx) ||
+		!tx.Block(head.Side(cube.FaceDown)).Model().FaceSolid(head.Side(cube.FaceDown), cube.FaceUp, tx) {
+		return false
+	}
+
+	ctx.IgnoreBBox = true
+	place(tx, pos, b, user, ctx)
+	place(tx, head, Bed{Colour: b.Colour, Facing: b.Facing, Head: true}, user, ctx)
+	ctx.CountSub = 1
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick removes the bed if the other half of the bed was removed.
+func (b Bed) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if _, ok := b.otherHalf(pos, tx); !ok {
+		breakBlockNoDrops(b, pos, tx)
+	}
+}
+
+// Activate makes the user sleep in the bed and sets its spawn point. Outside the overworld, the bed explodes
+// instead.
+func (b Bed) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
+	s, ok := u.(sleeper)
+	if !ok {
+		return false
+	}
+	other, ok := b.otherHalf(pos, tx)
+	if !ok {
+		return false
+	}
+	head := pos
+	if !b.Head {
+		head = other
+	}
+	if tx.World().Dimension() != world.Overworld {
+		tx.SetBlock(pos, nil, nil)
+		tx.SetBlock(other, nil, nil)
+		ExplosionConfig{Size: 5, SpawnFire: true}.Explode(tx, head.Vec3Centre())
+		return true
+	}
+	if !withinBedReach(s.Position(), pos) && !withinBedReach(s.Position(), other) {
+		s.SendBedMessage(BedTooFar)
+		return true
+	}
+	if sleepPos, sleeping := s.Sleeping(); sleeping && sleepPos == head {
+		return true
+	}
+	if bedOccupied(head, tx) {
+		s.SendBedMessage(BedOccupied)
+		return true
+	}
+	if tx.World().PlayerSpawn(s.UUID()) != head {
+		s.SetBedSpawn(head)
+		s.SendBedMessage(BedRespawnSet)
+	}
+	if t := tx.World().Time() % 24000; (t < 12542 || t > 23459) && !tx.World().Thundering() {
+		s.SendBedMessage(BedNoSleep)
+		return true
+	}
+	if monstersNearby(head, tx) {
+		s.SendBedMessage(BedNotSafe)
+		return true
+	}
+	s.Sleep(head)
+	return true
+}
+
+// SetBedOccupied marks the bed with its head at the position passed as
+// occupied or unoccupied, updating both halves of the bed. Nothing happens if
+// no bed is at the position.
+func SetBedOccupied(head cube.Pos, occupied bool, tx *world.Tx) {
+	b, ok := tx.Block(head).(Bed)
+	if !ok || !b.Head || b.Occupied == occupied {
+		return
+	}
+	other, ok := b.otherHalf(head, tx)
+	b.Occupied = occupied
+	tx.SetBlock(head, b, nil)
+	if ok {
+		tx.SetBlock(other, Bed{Colour: b.Colour, Facing: b.Facing, Occupied: occupied}, nil)
+	}
+}
+
+// otherHalf returns the position of the other half of the bed and true if the block at that position is still
+// a matching half of the bed.
+func (b Bed) otherHalf(pos cube.Pos, tx *world.Tx) (cube.Pos, bool) {
+	face := b.Facing.Face()
+	if b.Head {
+		face = face.Opposite()
+	}
+	other := pos.Side(face)
+	o, ok := tx.Block(other).(Bed)
+	return other, ok && o.Head != b.Head && o.Facing == b.Facing
+}
+
+// withinBedReach checks if an entity at the position passed is close enough to the bed half at pos to use it.
+func withinBedReach(entityPos mgl64.Vec3, pos cube.Pos) bool {
+	centre := pos.Vec3Centre()
+	return math.Abs(entityPos[0]-centre[0]) <= 3 && math.Abs(entityPos[1]-centre[1]) <= 2 && math.Abs(entityPos[2]-centre[2]) <= 3
+}
+
+// bedOccupied checks if any player in the world is currently sleeping in the bed with its head at the position
+// passed.
+func bedOccupied(head cube.Pos, tx *world.Tx) bool {
+	for e := range tx.Players() {
+		if s, ok := e.(world.Sleeper); ok {
+			if pos, sleeping := s.Sleeping(); sleeping && pos == head {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// monstersNearby checks if any hostile mobs are within 8 blocks horizontally and 5 blocks vertically of the bed
+// with its head at the position passed.
+func monstersNearby(head cube.Pos, tx *world.Tx) bool {
+	reg := tx.World().EntityRegistry()
+	box := cube.Box(-8, -5, -8, 9, 6, 9).Translate(head.Vec3())
+	for e := range tx.EntitiesWithin(box) {
+		if c, ok := reg.SpawnCategory(e.H().Type()); ok && c.Hostile() {
+			return true
+		}
+	}
+	return false
+}
+
+// EncodeItem ...
+func (b Bed) EncodeItem() (name string, meta int16) {
+	return "minecraft:bed", int16(b.Colour.Uint8())
+}
+
+// EncodeBlock ...
+func (b Bed) EncodeBlock() (name string, properties map[string]any) {
+	return "minecraft:bed", map[string]any{"direction": int32(horizontalDirection(b.Facing)), "head_piece_bit": b.Head, "occupied_bit": b.Occupied}
+}
+
+// EncodeNBT ...
+func (b Bed) EncodeNBT() map[string]any {
+	return map[string]any{"id": "Bed", "color": b.Colour.Uint8()}
+}
+
+// DecodeNBT ...
+func (b Bed) DecodeNBT(m map[string]any) any {
+	b.Colour = item.Colours()[nbtconv.Uint8(m, "color")&0xf]
+	return b
+}
+
+// allBeds returns all possible bed states.
+func allBeds() (beds []world.Block) {
+	for _, d := range cube.Directions() {
+		for _, occupied := range []bool{false, true} {
+			beds = append(beds, Bed{Facing: d, Occupied: occupied})
+			beds = append(beds, Bed{Facing: d, Head: true, Occupied: occupied})
+		}
+	}
+	return
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
+	"image/color"
 	"math/rand/v2"
 	"time"
 )
@@ -79,6 +80,13 @@ type ProjectileHitter interface {
 	ProjectileHit(pos cube.Pos, tx *world.Tx, e world.Entity, face cube.Face)
 }
 
+// MapColoured represents a block that is shown on maps. Blocks that do not implement this interface, or that return
+// a fully transparent colour, are not shown on maps, so that the block below them is shown instead.
+type MapColoured interface {
+	// MapColour returns the colour of the block shown on maps, before it is shaded to show differences in height.
+	MapColour() color.RGBA
+}
+
 // Frictional represents a block that may have a custom friction value. Friction is used for entity drag when the
 // entity is on ground. If a block does not implement this interface, it should be assumed that its friction is 0.6.
 type Frictional interface {
@@ -86,6 +94,28 @@ type Frictional interface {
 	Friction() float64
 }
 
+// PistonImmovable represents a block that cannot be pushed or pulled by a piston. Blocks that cannot be broken
+// and blocks that carry additional data, such as chests, are never moved by pistons, regardless of whether they
+// implement this interface.
+type PistonImmovable interface {
+	// PistonImmovable returns whether the block is immovable by pistons.
+	PistonImmovable() bool
+}
+
+// PistonBreakable represents a block that is broken when a piston attempts to push it, rather than being moved.
+// Blocks without a collision box, such as torches and flowers, are always broken by pistons.
+type PistonBreakable interface {
+	// PistonBreakable returns whether the block is broken when pushed by a piston.
+	PistonBreakable() bool
+}
+
+// PistonSticky represents a block that is sticky when moved by a piston, such as a slime block. Blocks next to a
+// sticky block are moved along with it, unless they cannot be moved.
+type PistonSticky interface {
+	// PistonSticksTo checks if the block sticks to the block passed when moved by a piston.
+	PistonSticksTo(b world.Block) bool
+}
+
 // Permutable represents a custom block that can have more permutations than its default state.
 type Permutable interface {
 	// States returns a map of all the different properties for the block. The key is the property name, and the value
@@ -0,0 +1,138 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// Button is a non-solid block that provides redstone power for a short time after being pressed.
+type Button struct {
+	empty
+	transparent
+
+	// Type is the type of the button.
+	Type ButtonType
+	// Facing is the face of the block that the button is attached to.
+	Facing cube.Face
+	// Pressed is whether the button is currently pressed.
+	Pressed bool
+}
+
+// BreakInfo ...
+func (b Button) BreakInfo() BreakInfo {
+	effectiveTool := axeEffective
+	if !b.Type.Wooden() {
+		effectiveTool = pickaxeEffective
+	}
+	return newBreakInfo(0.5, alwaysHarvestable, effectiveTool, oneOf(Button{Type: b.Type})).withBreakHandler(redstoneBreakHandler)
+}
+
+// FuelInfo ...
+func (b Button) FuelInfo() item.FuelInfo {
+	if !b.Type.Wooden() {
+		return item.FuelInfo{}
+	}
+	return newFuelInfo(time.Second * 5)
+}
+
+// UseOnBlock ...
+func (b Button) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(tx, pos, face, b)
+	if !used {
+		return false
+	}
+	if !tx.Block(pos.Side(face.Opposite())).Model().FaceSolid(pos.Side(face.Opposite()), face, tx) {
+		return false
+	}
+	b.Facing = face
+	b.Pressed = false
+
+	place(tx, pos, b, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (b Button) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !tx.Block(pos.Side(b.Facing.Opposite())).Model().FaceSolid(pos.Side(b.Facing.Opposite()), b.Facing, tx) {
+		breakBlock(b, pos, tx)
+	}
+}
+
+// Activate ...
+func (b Button) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
+	if b.Pressed {
+		return true
+	}
+	b.Pressed = true
+	tx.SetBlock(pos, b, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.PowerOn{})
+	updateAroundRedstone(pos, tx)
+	tx.ScheduleBlockUpdate(pos, b, b.pressDuration())
+	return true
+}
+
+// ScheduledTick ...
+func (b Button) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if !b.Pressed {
+		return
+	}
+	b.Pressed = false
+	tx.SetBlock(pos, b, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.PowerOff{})
+	updateAroundRedstone(pos, tx)
+}
+
+// pressDuration returns the duration that the button stays pressed for after being pressed.
+func (b Button) pressDuration() time.Duration {
+	if b.Type.Wooden() {
+		return time.Millisecond * 1500
+	}
+	return time.Second
+}
+
+// RedstoneSource ...
+func (Button) RedstoneSource() bool {
+	return true
+}
+
+// WeakPower ...
+func (b Button) WeakPower(cube.Pos, cube.Face, *world.Tx, bool) int {
+	if b.Pressed {
+		return 15
+	}
+	return 0
+}
+
+// StrongPower ...
+func (b Button) StrongPower(_ cube.Pos, face cube.Face, _ *world.Tx, _ bool) int {
+	if b.Pressed && face == b.Facing.Opposite() {
+		return 15
+	}
+	return 0
+}
+
+// EncodeItem ...
+func (b Button) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + b.Type.String() + "_button", 0
+}
+
+// EncodeBlock ...
+func (b Button) EncodeBlock() (string, map[string]any) {
+	return "minecraft:" + b.Type.String() + "_button", map[string]any{"facing_direction": int32(b.Facing), "button_pressed_bit": b.Pressed}
+}
+
+// allButtons ...
+func allButtons() (buttons []world.Block) {
+	for _, t := range ButtonTypes() {
+		for _, f := range cube.Faces() {
+			buttons = append(buttons, Button{Type: t, Facing: f})
+			buttons = append(buttons, Button{Type: t, Facing: f, Pressed: true})
+		}
+	}
+	return
+}
@@ -0,0 +1,76 @@
+package block
+
+import "strings"
+
+// ButtonType represents a type of button.
+type ButtonType struct {
+	button
+
+	// Wood is the type of wood of the button. This field is only used for wooden buttons.
+	Wood WoodType
+}
+
+type button uint8
+
+// StoneButton is a button made of stone.
+func StoneButton() ButtonType {
+	return ButtonType{button: 0}
+}
+
+// PolishedBlackstoneButton is a button made of polished blackstone.
+func PolishedBlackstoneButton() ButtonType {
+	return ButtonType{button: 1}
+}
+
+// WoodenButton returns a wooden button made of the wood type passed.
+func WoodenButton(w WoodType) ButtonType {
+	return ButtonType{button: 2, Wood: w}
+}
+
+// Uint8 returns the button type as a uint8.
+func (b ButtonType) Uint8() uint8 {
+	return b.Wood.Uint8()<<2 | uint8(b.button)
+}
+
+// Name ...
+func (b ButtonType) Name() string {
+	switch b.button {
+	case 0:
+		return "Stone Button"
+	case 1:
+		return "Polished Blackstone Button"
+	case 2:
+		return strings.TrimSuffix(b.Wood.Name(), " Wood") + " Button"
+	}
+	panic("unknown button type")
+}
+
+// String ...
+func (b ButtonType) String() string {
+	switch b.button {
+	case 0:
+		return "stone"
+	case 1:
+		return "polished_blackstone"
+	case 2:
+		if b.Wood == OakWood() {
+			return "wooden"
+		}
+		return b.Wood.String()
+	}
+	panic("unknown button type")
+}
+
+// Wooden checks if the button is made of wood.
+func (b ButtonType) Wooden() bool {
+	return b.button == 2
+}
+
+// ButtonTypes returns all button types.
+func ButtonTypes() []ButtonType {
+	types := []ButtonType{StoneButton(), PolishedBlackstoneButton()}
+	for _, w := range WoodTypes() {
+		types = append(types, WoodenButton(w))
+	}
+	return types
+}
@@ -76,22 +76,33 @@ func (d CopperDoor) NeighbourUpdateTick(pos, changedNeighbour cube.Pos, tx *worl
 	if d.Top {
 		if b, ok := tx.Block(pos.Side(cube.FaceDown)).(CopperDoor); !ok {
 			breakBlockNoDrops(d, pos, tx)
+			return
 		} else if d.Oxidation != b.Oxidation || d.Waxed != b.Waxed {
 			d.Oxidation = b.Oxidation
 			d.Waxed = b.Waxed
 			tx.SetBlock(pos, d, nil)
+			return
 		}
 	} else if solid := tx.Block(pos.Side(cube.FaceDown)).Model().FaceSolid(pos.Side(cube.FaceDown), cube.FaceUp, tx); !solid {
 		// CopperDoor is pickaxeHarvestable, so don't use breakBlock() here.
 		breakBlockNoDrops(d, pos, tx)
 		dropItem(tx, item.NewStack(d, 1), pos.Vec3Centre())
+		return
 	} else if b, ok := tx.Block(pos.Side(cube.FaceUp)).(CopperDoor); !ok {
 		breakBlockNoDrops(d, pos, tx)
+		return
 	} else if d.Oxidation != b.Oxidation || d.Waxed != b.Waxed {
 		d.Oxidation = b.Oxidation
 		d.Waxed = b.Waxed
 		tx.SetBlock(pos, d, nil)
+		return
 	}
+	d.updatePower(pos, changedNeighbour, tx)
+}
+
+// PistonBreakable ...
+func (CopperDoor) PistonBreakable() bool {
+	return true
 }
 
 // UseOnBlock handles the directional placing of doors
@@ -131,7 +142,13 @@ func (d CopperDoor) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *w
 }
 
 func (d CopperDoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
-	d.Open = !d.Open
+	d.setOpen(pos, tx, !d.Open)
+	return true
+}
+
+// setOpen opens or closes both halves of the door and plays the corresponding sound.
+func (d CopperDoor) setOpen(pos cube.Pos, tx *world.Tx, open bool) {
+	d.Open = open
 	tx.SetBlock(pos, d, nil)
 
 	otherPos := pos.Side(cube.Face(boolByte(!d.Top)))
@@ -142,10 +159,22 @@ func (d CopperDoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.Use
 	}
 	if d.Open {
 		tx.PlaySound(pos.Vec3Centre(), sound.DoorOpen{Block: d})
-		return true
+		return
 	}
 	tx.PlaySound(pos.Vec3Centre(), sound.DoorClose{Block: d})
-	return true
+}
+
+// updatePower opens the door if either of its halves is powered by redstone and closes it if neither is after
+// the redstone around it changed. Updates caused by the door itself are ignored, so that a door opened or closed
+// by hand stays that way until the power around it changes.
+func (d CopperDoor) updatePower(pos, changedNeighbour cube.Pos, tx *world.Tx) {
+	otherPos := pos.Side(cube.Face(boolByte(!d.Top)))
+	if changedNeighbour == pos || changedNeighbour == otherPos {
+		return
+	}
+	if powered := receivesPower(pos, tx) || receivesPower(otherPos, tx); toggledByPower(d.Open, powered, changedNeighbour, tx) {
+		d.setOpen(pos, tx, powered)
+	}
 }
 
 func (d CopperDoor) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
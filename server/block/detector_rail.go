@@ -0,0 +1,152 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// DetectorRail is a rail that provides redstone power while a minecart is on
+// top of it. Detector rails cannot curve.
+type DetectorRail struct {
+	empty
+	transparent
+
+	// Shape is the shape of the rail, which specifies the directions that it
+	// connects to. Detector rails only have straight shapes.
+	Shape RailShape
+	// Powered specifies if the rail is powered because a minecart is on top
+	// of it.
+	Powered bool
+}
+
+// BreakInfo ...
+func (r DetectorRail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(DetectorRail{})).withBreakHandler(redstoneBreakHandler)
+}
+
+// UseOnBlock ...
+func (r DetectorRail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, r)
+	if !used || !railSupported(pos, tx) {
+		return false
+	}
+	r.Shape = placementRailShape(pos, user, r, tx)
+	r.Powered = false
+
+	place(tx, pos, r, user, ctx)
+	if placed(ctx) {
+		connectRail(pos, r, tx)
+	}
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r DetectorRail) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !railSupported(pos, tx) {
+		breakBlock(r, pos, tx)
+	}
+}
+
+// EntityInside ...
+func (r DetectorRail) EntityInside(pos cube.Pos, tx *world.Tx, e world.Entity) {
+	if !r.Powered && isMinecart(e) {
+		// Once powered, the rail checks for minecarts on top of it using
+		// scheduled ticks.
+		r.update(pos, tx)
+	}
+}
+
+// ScheduledTick ...
+func (r DetectorRail) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if r.Powered {
+		r.update(pos, tx)
+	}
+}
+
+// update checks if a minecart is on top of the rail and changes the power of
+// the rail accordingly. As long as the rail is powered, update schedules a
+// block update to check again.
+func (r DetectorRail) update(pos cube.Pos, tx *world.Tx) {
+	box := cube.Box(0.125, 0, 0.125, 0.875, 0.875, 0.875).Translate(pos.Vec3())
+
+	powered := false
+	for e := range tx.EntitiesWithin(box.Grow(2)) {
+		if isMinecart(e) && e.H().Type().BBox(e).Translate(e.Position()).IntersectsWith(box) {
+			powered = true
+			break
+		}
+	}
+	if powered != r.Powered {
+		r.Powered = powered
+		tx.SetBlock(pos, r, nil)
+		updateAroundRedstone(pos, tx)
+	}
+	if powered {
+		tx.ScheduleBlockUpdate(pos, r, time.Second)
+	}
+}
+
+// isMinecart checks if the entity passed is a minecart.
+func isMinecart(e world.Entity) bool {
+	return e.H().Type().EncodeEntity() == "minecraft:minecart"
+}
+
+// RedstoneSource ...
+func (DetectorRail) RedstoneSource() bool {
+	return true
+}
+
+// WeakPower ...
+func (r DetectorRail) WeakPower(cube.Pos, cube.Face, *world.Tx, bool) int {
+	if r.Powered {
+		return 15
+	}
+	return 0
+}
+
+// StrongPower ...
+func (r DetectorRail) StrongPower(_ cube.Pos, face cube.Face, _ *world.Tx, _ bool) int {
+	if r.Powered && face == cube.FaceDown {
+		return 15
+	}
+	return 0
+}
+
+// Ascending returns true if the rail slopes upwards.
+func (r DetectorRail) Ascending() bool {
+	_, ok := r.Shape.Ascending()
+	return ok
+}
+
+// EncodeItem ...
+func (DetectorRail) EncodeItem() (name string, meta int16) {
+	return "minecraft:detector_rail", 0
+}
+
+// EncodeBlock ...
+func (r DetectorRail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:detector_rail", map[string]any{"rail_direction": int32(r.Shape.Uint8()), "rail_data_bit": boolByte(r.Powered)}
+}
+
+// railShape ...
+func (r DetectorRail) railShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r DetectorRail) withRailShape(s RailShape) world.Block {
+	r.Shape = s
+	return r
+}
+
+// allDetectorRails ...
+func allDetectorRails() (rails []world.Block) {
+	for _, s := range RailShapes()[:6] {
+		rails = append(rails, DetectorRail{Shape: s}, DetectorRail{Shape: s, Powered: true})
+	}
+	return
+}
@@ -0,0 +1,46 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// EndPortal is the block that fills a complete ring of end portal frames with eyes of ender. Entities that enter an
+// end portal are transported to the end, or back to the overworld if they are already in the end.
+type EndPortal struct {
+	transparent
+	empty
+}
+
+// LightEmissionLevel ...
+func (EndPortal) LightEmissionLevel() uint8 {
+	return 15
+}
+
+// EntityInside ...
+func (EndPortal) EntityInside(pos cube.Pos, _ *world.Tx, e world.Entity) {
+	if t, ok := e.(PortalTraveller); ok {
+		t.EnterPortal(world.End, pos)
+	}
+}
+
+// EncodeBlock ...
+func (EndPortal) EncodeBlock() (string, map[string]any) {
+	return "minecraft:end_portal", nil
+}
+
+// EndPortalExit returns the position at which an entity travelling through an end portal arrives in the end World of
+// tx. An obsidian platform is created at the arrival position, clearing any blocks above it.
+func EndPortalExit(tx *world.Tx) mgl64.Vec3 {
+	const platformY = 48
+	for x := 98; x <= 102; x++ {
+		for z := -2; z <= 2; z++ {
+			tx.SetBlock(cube.Pos{x, platformY, z}, Obsidian{}, nil)
+			for y := platformY + 1; y <= platformY+3; y++ {
+				tx.SetBlock(cube.Pos{x, y, z}, nil, nil)
+			}
+		}
+	}
+	return mgl64.Vec3{100.5, platformY + 1, 0.5}
+}
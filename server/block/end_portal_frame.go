@@ -0,0 +1,110 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// EndPortalFrame is a block that forms the ring around an end portal. Once all twelve frames of a ring are filled
+// with eyes of ender, the end portal inside of it is activated.
+type EndPortalFrame struct {
+	solid
+
+	// Facing is the direction that the end portal frame is facing. The frames of a ring must all face towards the
+	// centre of the ring to form an end portal.
+	Facing cube.Direction
+	// Eye is true if an eye of ender has been placed in the end portal frame.
+	Eye bool
+}
+
+// Model ...
+func (f EndPortalFrame) Model() world.BlockModel {
+	return model.EndPortalFrame{Eye: f.Eye}
+}
+
+// LightEmissionLevel ...
+func (EndPortalFrame) LightEmissionLevel() uint8 {
+	return 1
+}
+
+// UseOnBlock ...
+func (f EndPortalFrame) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) (used bool) {
+	pos, _, used = firstReplaceable(tx, pos, face, f)
+	if !used {
+		return
+	}
+	f.Facing = user.Rotation().Direction().Opposite()
+	place(tx, pos, f, user, ctx)
+	return placed(ctx)
+}
+
+// Activate places an eye of ender in the end portal frame if the user is holding one. If this completes a ring of
+// end portal frames, the end portal inside of it is activated.
+func (f EndPortalFrame) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, ctx *item.UseContext) bool {
+	if held, _ := u.HeldItems(); f.Eye || held.Empty() {
+		return false
+	} else if _, ok := held.Item().(item.EnderEye); !ok {
+		return false
+	}
+	f.Eye = true
+	tx.SetBlock(pos, f, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.EnderEyePlace{})
+	ctx.SubtractFromCount(1)
+
+	// The frame may be in any of the three positions on its side of the ring, so check all possible centres.
+	inwards := pos.Side(f.Facing.Face()).Side(f.Facing.Face())
+	for _, centre := range endPortalFrameSide(inwards, f.Facing) {
+		if endPortalFrameRing(centre, tx) {
+			for x := -1; x <= 1; x++ {
+				for z := -1; z <= 1; z++ {
+					tx.SetBlock(centre.Add(cube.Pos{x, 0, z}), EndPortal{}, nil)
+				}
+			}
+			tx.PlaySound(centre.Vec3Centre(), sound.EndPortalOpen{})
+			break
+		}
+	}
+	return true
+}
+
+// endPortalFrameRing checks if the 3x3 area around centre is surrounded by a complete ring of end portal frames that
+// all face towards the centre and all have an eye of ender placed in them.
+func endPortalFrameRing(centre cube.Pos, tx *world.Tx) bool {
+	for _, d := range cube.Directions() {
+		for _, pos := range endPortalFrameSide(centre.Side(d.Face()).Side(d.Face()), d) {
+			frame, ok := tx.Block(pos).(EndPortalFrame)
+			if !ok || !frame.Eye || frame.Facing != d.Opposite() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// endPortalFrameSide returns pos and the two positions next to it, perpendicular to the direction passed.
+func endPortalFrameSide(pos cube.Pos, d cube.Direction) [3]cube.Pos {
+	return [3]cube.Pos{pos.Side(d.RotateLeft().Face()), pos, pos.Side(d.RotateRight().Face())}
+}
+
+// EncodeItem ...
+func (EndPortalFrame) EncodeItem() (name string, meta int16) {
+	return "minecraft:end_portal_frame", 0
+}
+
+// EncodeBlock ...
+func (f EndPortalFrame) EncodeBlock() (string, map[string]any) {
+	return "minecraft:end_portal_frame", map[string]any{"minecraft:cardinal_direction": f.Facing.String(), "end_portal_eye_bit": f.Eye}
+}
+
+// allEndPortalFrames returns all possible end portal frame states.
+func allEndPortalFrames() (frames []world.Block) {
+	for _, d := range cube.Directions() {
+		frames = append(frames, EndPortalFrame{Facing: d})
+		frames = append(frames, EndPortalFrame{Facing: d, Eye: true})
+	}
+	return
+}
@@ -189,6 +189,12 @@ func (f Fire) spread(from, to cube.Pos, tx *world.Tx, r *rand.Rand) {
 	tx.ScheduleBlockUpdate(to, spread, time.Duration(30+r.IntN(10))*time.Second/20)
 }
 
+// Ignite lights a nether portal in place of the fire if the position passed is inside an empty obsidian frame. True
+// is returned if a portal was lit, in which case the fire should not be placed.
+func (f Fire) Ignite(pos cube.Pos, tx *world.Tx, _ world.Entity) bool {
+	return lightNetherPortal(pos, tx)
+}
+
 // EntityInside ...
 func (f Fire) EntityInside(_ cube.Pos, _ *world.Tx, e world.Entity) {
 	if flammable, ok := e.(flammableEntity); ok {
@@ -5,7 +5,8 @@ package block
 import "github.com/df-mc/dragonfly/server/world"
 
 const (
-	hashAir = iota
+	hashActivatorRail = iota
+	hashAir
 	hashAmethyst
 	hashAncientDebris
 	hashAndesite
@@ -15,6 +16,7 @@ const (
 	hashBarrier
 	hashBasalt
 	hashBeacon
+	hashBed
 	hashBedrock
 	hashBeetrootSeeds
 	hashBlackstone
@@ -24,6 +26,7 @@ const (
 	hashBookshelf
 	hashBrewingStand
 	hashBricks
+	hashButton
 	hashCactus
 	hashCake
 	hashCalcite
@@ -54,6 +57,7 @@ const (
 	hashDeepslate
 	hashDeepslateBricks
 	hashDeepslateTiles
+	hashDetectorRail
 	hashDiamond
 	hashDiamondOre
 	hashDiorite
@@ -68,6 +72,8 @@ const (
 	hashEmeraldOre
 	hashEnchantingTable
 	hashEndBricks
+	hashEndPortal
+	hashEndPortalFrame
 	hashEndRod
 	hashEndStone
 	hashEnderChest
@@ -89,6 +95,7 @@ const (
 	hashGravel
 	hashGrindstone
 	hashHayBale
+	hashHoney
 	hashHoneycomb
 	hashHopper
 	hashInvisibleBedrock
@@ -105,6 +112,7 @@ const (
 	hashLava
 	hashLeaves
 	hashLectern
+	hashLever
 	hashLight
 	hashLitPumpkin
 	hashLog
@@ -112,12 +120,14 @@ const (
 	hashMelon
 	hashMelonSeeds
 	hashMossCarpet
+	hashMovingBlock
 	hashMud
 	hashMudBricks
 	hashMuddyMangroveRoots
 	hashNetherBrickFence
 	hashNetherBricks
 	hashNetherGoldOre
+	hashNetherPortal
 	hashNetherQuartzOre
 	hashNetherSprouts
 	hashNetherWart
@@ -125,15 +135,20 @@ const (
 	hashNetherite
 	hashNetherrack
 	hashNote
+	hashNylium
 	hashObsidian
 	hashPackedIce
 	hashPackedMud
 	hashPinkPetals
+	hashPiston
+	hashPistonArmCollision
 	hashPlanks
 	hashPodzol
 	hashPolishedBlackstoneBrick
 	hashPolishedTuff
 	hashPotato
+	hashPoweredRail
+	hashPressurePlate
 	hashPrismarine
 	hashPumpkin
 	hashPumpkinSeeds
@@ -142,9 +157,15 @@ const (
 	hashQuartz
 	hashQuartzBricks
 	hashQuartzPillar
+	hashRail
 	hashRawCopper
 	hashRawGold
 	hashRawIron
+	hashRedstoneBlock
+	hashRedstoneLamp
+	hashRedstoneRepeater
+	hashRedstoneTorch
+	hashRedstoneWire
 	hashReinforcedDeepslate
 	hashResin
 	hashResinBricks
@@ -157,6 +178,7 @@ const (
 	hashSign
 	hashSkull
 	hashSlab
+	hashSlime
 	hashSmithingTable
 	hashSmoker
 	hashSnow
@@ -199,6 +221,10 @@ func NextHash() uint64 {
 	return customBlockBase
 }
 
+func (a ActivatorRail) Hash() (uint64, uint64) {
+	return hashActivatorRail, uint64(a.Shape.Uint8()) | uint64(boolByte(a.Powered))<<4
+}
+
 func (Air) Hash() (uint64, uint64) {
 	return hashAir, 0
 }
@@ -239,6 +265,10 @@ func (Beacon) Hash() (uint64, uint64) {
 	return hashBeacon, 0
 }
 
+func (b Bed) Hash() (uint64, uint64) {
+	return hashBed, uint64(b.Facing) | uint64(boolByte(b.Head))<<2 | uint64(boolByte(b.Occupied))<<3
+}
+
 func (b Bedrock) Hash() (uint64, uint64) {
 	return hashBedrock, uint64(boolByte(b.InfiniteBurning))
 }
@@ -275,6 +305,10 @@ func (Bricks) Hash() (uint64, uint64) {
 	return hashBricks, 0
 }
 
+func (b Button) Hash() (uint64, uint64) {
+	return hashButton, uint64(b.Type.Uint8()) | uint64(b.Facing)<<8 | uint64(boolByte(b.Pressed))<<11
+}
+
 func (c Cactus) Hash() (uint64, uint64) {
 	return hashCactus, uint64(c.Age)
 }
@@ -395,6 +429,10 @@ func (d DeepslateTiles) Hash() (uint64, uint64) {
 	return hashDeepslateTiles, uint64(boolByte(d.Cracked))
 }
 
+func (d DetectorRail) Hash() (uint64, uint64) {
+	return hashDetectorRail, uint64(d.Shape.Uint8()) | uint64(boolByte(d.Powered))<<4
+}
+
 func (Diamond) Hash() (uint64, uint64) {
 	return hashDiamond, 0
 }
@@ -451,6 +489,14 @@ func (EndBricks) Hash() (uint64, uint64) {
 	return hashEndBricks, 0
 }
 
+func (EndPortal) Hash() (uint64, uint64) {
+	return hashEndPortal, 0
+}
+
+func (e EndPortalFrame) Hash() (uint64, uint64) {
+	return hashEndPortalFrame, uint64(e.Facing) | uint64(boolByte(e.Eye))<<2
+}
+
 func (e EndRod) Hash() (uint64, uint64) {
 	return hashEndRod, uint64(e.Facing)
 }
@@ -535,6 +581,10 @@ func (h HayBale) Hash() (uint64, uint64) {
 	return hashHayBale, uint64(h.Axis)
 }
 
+func (Honey) Hash() (uint64, uint64) {
+	return hashHoney, 0
+}
+
 func (Honeycomb) Hash() (uint64, uint64) {
 	return hashHoneycomb, 0
 }
@@ -599,6 +649,10 @@ func (l Lectern) Hash() (uint64, uint64) {
 	return hashLectern, uint64(l.Facing)
 }
 
+func (l Lever) Hash() (uint64, uint64) {
+	return hashLever, uint64(boolByte(l.Powered)) | uint64(l.Facing)<<1 | uint64(l.Direction)<<4
+}
+
 func (l Light) Hash() (uint64, uint64) {
 	return hashLight, uint64(l.Level)
 }
@@ -627,6 +681,10 @@ func (MossCarpet) Hash() (uint64, uint64) {
 	return hashMossCarpet, 0
 }
 
+func (MovingBlock) Hash() (uint64, uint64) {
+	return hashMovingBlock, 0
+}
+
 func (Mud) Hash() (uint64, uint64) {
 	return hashMud, 0
 }
@@ -651,6 +709,10 @@ func (NetherGoldOre) Hash() (uint64, uint64) {
 	return hashNetherGoldOre, 0
 }
 
+func (n NetherPortal) Hash() (uint64, uint64) {
+	return hashNetherPortal, uint64(n.Axis)
+}
+
 func (NetherQuartzOre) Hash() (uint64, uint64) {
 	return hashNetherQuartzOre, 0
 }
@@ -679,6 +741,10 @@ func (Note) Hash() (uint64, uint64) {
 	return hashNote, 0
 }
 
+func (n Nylium) Hash() (uint64, uint64) {
+	return hashNylium, uint64(boolByte(n.Warped))
+}
+
 func (o Obsidian) Hash() (uint64, uint64) {
 	return hashObsidian, uint64(boolByte(o.Crying))
 }
@@ -695,6 +761,14 @@ func (p PinkPetals) Hash() (uint64, uint64) {
 	return hashPinkPetals, uint64(p.AdditionalCount) | uint64(p.Facing)<<8
 }
 
+func (p Piston) Hash() (uint64, uint64) {
+	return hashPiston, uint64(p.Facing) | uint64(boolByte(p.Sticky))<<3
+}
+
+func (p PistonArmCollision) Hash() (uint64, uint64) {
+	return hashPistonArmCollision, uint64(p.Facing) | uint64(boolByte(p.Sticky))<<3
+}
+
 func (p Planks) Hash() (uint64, uint64) {
 	return hashPlanks, uint64(p.Wood.Uint8())
 }
@@ -715,6 +789,14 @@ func (p Potato) Hash() (uint64, uint64) {
 	return hashPotato, uint64(p.Growth)
 }
 
+func (p PoweredRail) Hash() (uint64, uint64) {
+	return hashPoweredRail, uint64(p.Shape.Uint8()) | uint64(boolByte(p.Powered))<<4
+}
+
+func (p PressurePlate) Hash() (uint64, uint64) {
+	return hashPressurePlate, uint64(p.Type.Uint8()) | uint64(p.Power)<<8
+}
+
 func (p Prismarine) Hash() (uint64, uint64) {
 	return hashPrismarine, uint64(p.Type.Uint8())
 }
@@ -747,6 +829,10 @@ func (q QuartzPillar) Hash() (uint64, uint64) {
 	return hashQuartzPillar, uint64(q.Axis)
 }
 
+func (r Rail) Hash() (uint64, uint64) {
+	return hashRail, uint64(r.Shape.Uint8())
+}
+
 func (RawCopper) Hash() (uint64, uint64) {
 	return hashRawCopper, 0
 }
@@ -759,6 +845,26 @@ func (RawIron) Hash() (uint64, uint64) {
 	return hashRawIron, 0
 }
 
+func (RedstoneBlock) Hash() (uint64, uint64) {
+	return hashRedstoneBlock, 0
+}
+
+func (l RedstoneLamp) Hash() (uint64, uint64) {
+	return hashRedstoneLamp, uint64(boolByte(l.Lit))
+}
+
+func (r RedstoneRepeater) Hash() (uint64, uint64) {
+	return hashRedstoneRepeater, uint64(r.Facing) | uint64(r.Delay)<<2 | uint64(boolByte(r.Powered))<<10
+}
+
+func (t RedstoneTorch) Hash() (uint64, uint64) {
+	return hashRedstoneTorch, uint64(t.Facing) | uint64(boolByte(t.Lit))<<3
+}
+
+func (r RedstoneWire) Hash() (uint64, uint64) {
+	return hashRedstoneWire, uint64(r.Power)
+}
+
 func (ReinforcedDeepslate) Hash() (uint64, uint64) {
 	return hashReinforcedDeepslate, 0
 }
@@ -807,6 +913,10 @@ func (s Slab) Hash() (uint64, uint64) {
 	return hashSlab, world.BlockHash(s.Block) | uint64(boolByte(s.Top))<<32 | uint64(boolByte(s.Double))<<33
 }
 
+func (Slime) Hash() (uint64, uint64) {
+	return hashSlime, 0
+}
+
 func (SmithingTable) Hash() (uint64, uint64) {
 	return hashSmithingTable, 0
 }
@@ -0,0 +1,41 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Honey is a translucent block that is sticky when moved by pistons, moving the blocks next to it along with it.
+// Honey does not stick to slime blocks. Entities landing on a honey block take less fall damage.
+type Honey struct {
+	solid
+	transparent
+}
+
+// EntityLand ...
+func (Honey) EntityLand(_ cube.Pos, _ *world.Tx, e world.Entity, distance *float64) {
+	if _, ok := e.(fallDistanceEntity); ok {
+		*distance *= 0.2
+	}
+}
+
+// PistonSticksTo ...
+func (Honey) PistonSticksTo(b world.Block) bool {
+	_, slime := b.(Slime)
+	return !slime
+}
+
+// BreakInfo ...
+func (h Honey) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(h))
+}
+
+// EncodeItem ...
+func (Honey) EncodeItem() (name string, meta int16) {
+	return "minecraft:honey_block", 0
+}
+
+// EncodeBlock ...
+func (Honey) EncodeBlock() (string, map[string]any) {
+	return "minecraft:honey_block", nil
+}
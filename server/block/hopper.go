@@ -145,6 +145,14 @@ func (h Hopper) Tick(currentTick int64, pos cube.Pos, tx *world.Tx) {
 	tx.SetBlock(pos, h, nil)
 }
 
+// NeighbourUpdateTick ...
+func (h Hopper) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if powered := receivesPower(pos, tx); powered != h.Powered {
+		h.Powered = powered
+		tx.SetBlock(pos, h, nil)
+	}
+}
+
 // HopperInsertable represents a block that can have its contents inserted into by a hopper.
 type HopperInsertable interface {
 	// InsertItem handles the insert logic for that block.
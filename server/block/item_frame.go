@@ -33,12 +33,18 @@ type ItemFrame struct {
 // Activate ...
 func (i ItemFrame) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, ctx *item.UseContext) bool {
 	if !i.Item.Empty() {
-		// TODO: Item frames with maps can only be rotated four times.
-		i.Rotations = (i.Rotations + 1) % 8
+		rotations := 8
+		if _, ok := i.Item.Item().(item.FilledMap); ok {
+			// Item frames with maps can only be rotated four times.
+			rotations = 4
+		}
+		i.Rotations = (i.Rotations + 1) % rotations
 		tx.PlaySound(pos.Vec3Centre(), sound.ItemFrameRotate{})
 	} else if held, _ := u.HeldItems(); !held.Empty() {
 		i.Item = held.Grow(-held.Count() + 1)
-		// TODO: When maps are implemented, check the item is a map, and if so, display the large version of the frame.
+		if m, ok := i.frameMap(tx); ok {
+			m.AddFrame(pos, i.Facing.Opposite())
+		}
 		ctx.SubtractFromCount(1)
 		tx.PlaySound(pos.Vec3Centre(), sound.ItemAdd{})
 	} else {
@@ -62,6 +68,9 @@ func (i ItemFrame) Punch(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User) {
 			dropItem(tx, i.Item, pos.Vec3Centre())
 		}
 	}
+	if m, ok := i.frameMap(tx); ok {
+		m.RemoveFrame(pos)
+	}
 	i.Item, i.Rotations = item.Stack{}, 0
 	tx.PlaySound(pos.Vec3Centre(), sound.ItemFrameRemove{})
 	tx.SetBlock(pos, i, nil)
@@ -87,12 +96,23 @@ func (i ItemFrame) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *wo
 // BreakInfo ...
 func (i ItemFrame) BreakInfo() BreakInfo {
 	return newBreakInfo(0.25, alwaysHarvestable, nothingEffective, oneOf(ItemFrame{Glowing: i.Glowing})).withBreakHandler(func(pos cube.Pos, tx *world.Tx, _ item.User) {
+		if m, ok := i.frameMap(tx); ok {
+			m.RemoveFrame(pos)
+		}
 		if !i.Item.Empty() {
 			dropItem(tx, i.Item, pos.Vec3Centre())
 		}
 	})
 }
 
+// frameMap returns the world.Map shown by the item in the frame, if the item is a filled map.
+func (i ItemFrame) frameMap(tx *world.Tx) (*world.Map, bool) {
+	if filled, ok := i.Item.Item().(item.FilledMap); ok {
+		return filled.Map(tx)
+	}
+	return nil, false
+}
+
 // EncodeItem ...
 func (i ItemFrame) EncodeItem() (name string, meta int16) {
 	if i.Glowing {
@@ -109,7 +129,7 @@ func (i ItemFrame) EncodeBlock() (name string, properties map[string]any) {
 	}
 	return name, map[string]any{
 		"facing_direction":     int32(i.Facing.Opposite()),
-		"item_frame_map_bit":   uint8(0), // TODO: When maps are added, set this to true if the item is a map.
+		"item_frame_map_bit":   uint8(0), // TODO: Set this to true if the item is a map. This requires the hash of the block to account for the item.
 		"item_frame_photo_bit": uint8(0), // Only implemented in Education Edition.
 	}
 }
@@ -0,0 +1,121 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Lever is a non-solid block that can be switched on and off to provide redstone power.
+type Lever struct {
+	empty
+	transparent
+
+	// Powered is whether the lever is switched on.
+	Powered bool
+	// Facing is the face of the block that the lever is attached to.
+	Facing cube.Face
+	// Direction is the direction the lever is pointing. It is only used for levers that are attached to the up or
+	// down face of a block, in which case only the axis of the direction is relevant.
+	Direction cube.Direction
+}
+
+// BreakInfo ...
+func (l Lever) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, alwaysHarvestable, nothingEffective, oneOf(Lever{})).withBreakHandler(redstoneBreakHandler)
+}
+
+// UseOnBlock ...
+func (l Lever) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(tx, pos, face, l)
+	if !used {
+		return false
+	}
+	if !tx.Block(pos.Side(face.Opposite())).Model().FaceSolid(pos.Side(face.Opposite()), face, tx) {
+		return false
+	}
+	l.Facing = face
+	l.Direction = cube.North
+	if d := user.Rotation().Direction(); d == cube.East || d == cube.West {
+		l.Direction = cube.East
+	}
+
+	place(tx, pos, l, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (l Lever) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !tx.Block(pos.Side(l.Facing.Opposite())).Model().FaceSolid(pos.Side(l.Facing.Opposite()), l.Facing, tx) {
+		breakBlock(l, pos, tx)
+	}
+}
+
+// Activate ...
+func (l Lever) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
+	l.Powered = !l.Powered
+	tx.SetBlock(pos, l, nil)
+	updateAroundRedstone(pos, tx)
+	if l.Powered {
+		tx.PlaySound(pos.Vec3Centre(), sound.PowerOn{})
+		return true
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.PowerOff{})
+	return true
+}
+
+// RedstoneSource ...
+func (Lever) RedstoneSource() bool {
+	return true
+}
+
+// WeakPower ...
+func (l Lever) WeakPower(cube.Pos, cube.Face, *world.Tx, bool) int {
+	if l.Powered {
+		return 15
+	}
+	return 0
+}
+
+// StrongPower ...
+func (l Lever) StrongPower(_ cube.Pos, face cube.Face, _ *world.Tx, _ bool) int {
+	if l.Powered && face == l.Facing.Opposite() {
+		return 15
+	}
+	return 0
+}
+
+// EncodeItem ...
+func (l Lever) EncodeItem() (name string, meta int16) {
+	return "minecraft:lever", 0
+}
+
+// EncodeBlock ...
+func (l Lever) EncodeBlock() (string, map[string]any) {
+	direction := l.Facing.String()
+	if l.Facing == cube.FaceDown || l.Facing == cube.FaceUp {
+		axis := "north_south"
+		if l.Direction == cube.East || l.Direction == cube.West {
+			axis = "east_west"
+		}
+		direction += "_" + axis
+	}
+	return "minecraft:lever", map[string]any{"open_bit": l.Powered, "lever_direction": direction}
+}
+
+// allLevers ...
+func allLevers() (levers []world.Block) {
+	for _, f := range cube.Faces() {
+		directions := []cube.Direction{cube.North}
+		if f == cube.FaceDown || f == cube.FaceUp {
+			directions = append(directions, cube.East)
+		}
+		for _, d := range directions {
+			levers = append(levers, Lever{Facing: f, Direction: d})
+			levers = append(levers, Lever{Facing: f, Direction: d, Powered: true})
+		}
+	}
+	return
+}
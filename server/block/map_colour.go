@@ -0,0 +1,810 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"image/color"
+)
+
+// The base colours of blocks shown on maps. These match the colours used by
+// vanilla, before they are shaded to show differences in height.
+var (
+	mapColourGrass               = mapRGB(0x7fb238)
+	mapColourSand                = mapRGB(0xf7e9a3)
+	mapColourWool                = mapRGB(0xc7c7c7)
+	mapColourFire                = mapRGB(0xff0000)
+	mapColourIce                 = mapRGB(0xa0a0ff)
+	mapColourMetal               = mapRGB(0xa7a7a7)
+	mapColourPlant               = mapRGB(0x007c00)
+	mapColourSnow                = mapRGB(0xffffff)
+	mapColourClay                = mapRGB(0xa4a8b8)
+	mapColourDirt                = mapRGB(0x976d4d)
+	mapColourStone               = mapRGB(0x707070)
+	mapColourWater               = mapRGB(0x4040ff)
+	mapColourWood                = mapRGB(0x8f7748)
+	mapColourQuartz              = mapRGB(0xfffcf5)
+	mapColourGold                = mapRGB(0xfaee4d)
+	mapColourDiamond             = mapRGB(0x5cdbd5)
+	mapColourLapis               = mapRGB(0x4a80ff)
+	mapColourEmerald             = mapRGB(0x00d93a)
+	mapColourPodzol              = mapRGB(0x815631)
+	mapColourNether              = mapRGB(0x700200)
+	mapColourCrimsonNylium       = mapRGB(0xbd3031)
+	mapColourCrimsonStem         = mapRGB(0x943f61)
+	mapColourCrimsonHyphae       = mapRGB(0x5c191d)
+	mapColourWarpedNylium        = mapRGB(0x167e86)
+	mapColourWarpedStem          = mapRGB(0x3a8e8c)
+	mapColourWarpedHyphae        = mapRGB(0x562c3e)
+	mapColourWarpedWartBlock     = mapRGB(0x14b485)
+	mapColourDeepslate           = mapRGB(0x646464)
+	mapColourRawIron             = mapRGB(0xd8af93)
+	mapColourGlowLichen          = mapRGB(0x7fa796)
+	mapColourTerracottaWhite     = mapRGB(0xd1b1a1)
+	mapColourTerracottaOrange    = mapRGB(0x9f5224)
+	mapColourTerracottaLightGrey = mapRGB(0x876b62)
+	mapColourTerracottaCyan      = mapRGB(0x575c5c)
+	mapColourTerracottaGrey      = mapRGB(0x392923)
+	mapColourTerracottaBrown     = mapRGB(0x4c3223)
+	mapColourTerracottaRed       = mapRGB(0x8e3c2e)
+	mapColourOrange              = mapRGB(0xd87f33)
+	mapColourMagenta             = mapRGB(0xb24cd8)
+	mapColourLightBlue           = mapRGB(0x6699d8)
+	mapColourYellow              = mapRGB(0xe5e533)
+	mapColourLime                = mapRGB(0x7fcc19)
+	mapColourPink                = mapRGB(0xf27fa5)
+	mapColourGrey                = mapRGB(0x4c4c4c)
+	mapColourLightGrey           = mapRGB(0x999999)
+	mapColourCyan                = mapRGB(0x4c7f99)
+	mapColourPurple              = mapRGB(0x7f3fb2)
+	mapColourBlue                = mapRGB(0x334cb2)
+	mapColourBrown               = mapRGB(0x664c33)
+	mapColourGreen               = mapRGB(0x667f33)
+	mapColourRed                 = mapRGB(0x993333)
+	mapColourBlack               = mapRGB(0x191919)
+	mapColourTerracottaMagenta   = mapRGB(0x95576c)
+	mapColourTerracottaLightBlue = mapRGB(0x706c8a)
+	mapColourTerracottaYellow    = mapRGB(0xba8524)
+	mapColourTerracottaLime      = mapRGB(0x677535)
+	mapColourTerracottaPink      = mapRGB(0xa04d4e)
+	mapColourTerracottaPurple    = mapRGB(0x7a4958)
+	mapColourTerracottaBlue      = mapRGB(0x4c3e5c)
+	mapColourTerracottaGreen     = mapRGB(0x4c522a)
+	mapColourTerracottaBlack     = mapRGB(0x251610)
+)
+
+// mapRGB returns an opaque colour from a hexadecimal RGB value.
+func mapRGB(v uint32) color.RGBA {
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
+
+// dyeMapColours and terracottaMapColours hold the map colours of dyed blocks
+// and stained terracotta respectively, indexed by the item.Colour of the
+// block.
+var (
+	dyeMapColours = [...]color.RGBA{
+		mapColourSnow, mapColourOrange, mapColourMagenta, mapColourLightBlue, mapColourYellow, mapColourLime,
+		mapColourPink, mapColourGrey, mapColourLightGrey, mapColourCyan, mapColourPurple, mapColourBlue,
+		mapColourBrown, mapColourGreen, mapColourRed, mapColourBlack,
+	}
+	terracottaMapColours = [...]color.RGBA{
+		mapColourTerracottaWhite, mapColourTerracottaOrange, mapColourTerracottaMagenta, mapColourTerracottaLightBlue,
+		mapColourTerracottaYellow, mapColourTerracottaLime, mapColourTerracottaPink, mapColourTerracottaGrey,
+		mapColourTerracottaLightGrey, mapColourTerracottaCyan, mapColourTerracottaPurple, mapColourTerracottaBlue,
+		mapColourTerracottaBrown, mapColourTerracottaGreen, mapColourTerracottaRed, mapColourTerracottaBlack,
+	}
+)
+
+// dyeMapColour returns the map colour of a block dyed with the colour passed.
+func dyeMapColour(c item.Colour) color.RGBA {
+	return dyeMapColours[c.Uint8()]
+}
+
+// planksMapColour returns the map colour of planks and other blocks made out
+// of the wood type passed, such as doors and fences.
+func planksMapColour(w WoodType) color.RGBA {
+	switch w {
+	case SpruceWood():
+		return mapColourPodzol
+	case BirchWood():
+		return mapColourSand
+	case JungleWood():
+		return mapColourDirt
+	case AcaciaWood():
+		return mapColourOrange
+	case DarkOakWood():
+		return mapColourBrown
+	case CrimsonWood():
+		return mapColourCrimsonStem
+	case WarpedWood():
+		return mapColourWarpedStem
+	case MangroveWood():
+		return mapColourRed
+	case CherryWood():
+		return mapColourTerracottaWhite
+	case PaleOakWood():
+		return mapColourQuartz
+	}
+	return mapColourWood
+}
+
+// barkMapColour returns the map colour of the bark of logs of the wood type
+// passed.
+func barkMapColour(w WoodType) color.RGBA {
+	switch w {
+	case SpruceWood(), DarkOakWood():
+		return mapColourBrown
+	case BirchWood():
+		return mapColourQuartz
+	case AcaciaWood():
+		return mapColourGrey
+	case CrimsonWood():
+		return mapColourCrimsonHyphae
+	case WarpedWood():
+		return mapColourWarpedHyphae
+	case CherryWood():
+		return mapColourTerracottaGrey
+	case PaleOakWood():
+		return mapColourStone
+	}
+	return mapColourPodzol
+}
+
+// oreMapColour returns the map colour of an ore of the type passed.
+func oreMapColour(t OreType) color.RGBA {
+	if t == DeepslateOre() {
+		return mapColourDeepslate
+	}
+	return mapColourStone
+}
+
+// copperMapColour returns the map colour of copper blocks with the oxidation
+// passed.
+func copperMapColour(o OxidationType) color.RGBA {
+	switch o {
+	case ExposedOxidation():
+		return mapColourTerracottaLightGrey
+	case WeatheredOxidation():
+		return mapColourWarpedStem
+	case OxidisedOxidation():
+		return mapColourWarpedNylium
+	}
+	return mapColourOrange
+}
+
+// coralMapColour returns the map colour of coral of the type passed.
+func coralMapColour(t CoralType, dead bool) color.RGBA {
+	if dead {
+		return mapColourGrey
+	}
+	switch t {
+	case BrainCoral():
+		return mapColourPink
+	case BubbleCoral():
+		return mapColourPurple
+	case FireCoral():
+		return mapColourRed
+	case HornCoral():
+		return mapColourYellow
+	}
+	return mapColourBlue
+}
+
+// materialMapColour returns the map colour of a block made of the block
+// passed, such as a slab or stairs. A fully transparent colour is returned if
+// the block passed has no map colour.
+func materialMapColour(b world.Block) color.RGBA {
+	if c, ok := b.(MapColoured); ok {
+		return c.MapColour()
+	}
+	return color.RGBA{}
+}
+
+// MapColour ...
+func (Amethyst) MapColour() color.RGBA { return mapColourPurple }
+
+// MapColour ...
+func (AncientDebris) MapColour() color.RGBA { return mapColourBlack }
+
+// MapColour ...
+func (Andesite) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (Anvil) MapColour() color.RGBA { return mapColourMetal }
+
+// MapColour ...
+func (Banner) MapColour() color.RGBA { return mapColourWood }
+
+// MapColour ...
+func (Barrel) MapColour() color.RGBA { return mapColourWood }
+
+// MapColour ...
+func (Basalt) MapColour() color.RGBA { return mapColourBlack }
+
+// MapColour ...
+func (Beacon) MapColour() color.RGBA { return mapColourDiamond }
+
+// MapColour ...
+func (b Bed) MapColour() color.RGBA {
+	if b.Head {
+		return dyeMapColour(b.Colour)
+	}
+	return mapColourWool
+}
+
+// MapColour ...
+func (Bedrock) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (BeetrootSeeds) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (Blackstone) MapColour() color.RGBA { return mapColourBlack }
+
+// MapColour ...
+func (BlastFurnace) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (BlueIce) MapColour() color.RGBA { return mapColourIce }
+
+// MapColour ...
+func (Bone) MapColour() color.RGBA { return mapColourSand }
+
+// MapColour ...
+func (Bookshelf) MapColour() color.RGBA { return mapColourWood }
+
+// MapColour ...
+func (BrewingStand) MapColour() color.RGBA { return mapColourMetal }
+
+// MapColour ...
+func (Bricks) MapColour() color.RGBA { return mapColourRed }
+
+// MapColour ...
+func (Cactus) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (Calcite) MapColour() color.RGBA { return mapColourTerracottaWhite }
+
+// MapColour ...
+func (Campfire) MapColour() color.RGBA { return mapColourPodzol }
+
+// MapColour ...
+func (c Carpet) MapColour() color.RGBA { return dyeMapColour(c.Colour) }
+
+// MapColour ...
+func (Carrot) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (Chain) MapColour() color.RGBA { return mapColourMetal }
+
+// MapColour ...
+func (Chest) MapColour() color.RGBA { return mapColourWood }
+
+// MapColour ...
+func (ChiseledQuartz) MapColour() color.RGBA { return mapColourQuartz }
+
+// MapColour ...
+func (Clay) MapColour() color.RGBA { return mapColourClay }
+
+// MapColour ...
+func (Coal) MapColour() color.RGBA { return mapColourBlack }
+
+// MapColour ...
+func (c CoalOre) MapColour() color.RGBA { return oreMapColour(c.Type) }
+
+// MapColour ...
+func (Cobblestone) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (CocoaBean) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (Composter) MapColour() color.RGBA { return mapColourWood }
+
+// MapColour ...
+func (c Concrete) MapColour() color.RGBA { return dyeMapColour(c.Colour) }
+
+// MapColour ...
+func (c ConcretePowder) MapColour() color.RGBA { return dyeMapColour(c.Colour) }
+
+// MapColour ...
+func (c Copper) MapColour() color.RGBA { return copperMapColour(c.Oxidation) }
+
+// MapColour ...
+func (c CopperDoor) MapColour() color.RGBA { return copperMapColour(c.Oxidation) }
+
+// MapColour ...
+func (c CopperGrate) MapColour() color.RGBA { return copperMapColour(c.Oxidation) }
+
+// MapColour ...
+func (c CopperOre) MapColour() color.RGBA { return oreMapColour(c.Type) }
+
+// MapColour ...
+func (c CopperTrapdoor) MapColour() color.RGBA { return copperMapColour(c.Oxidation) }
+
+// MapColour ...
+func (c Coral) MapColour() color.RGBA { return coralMapColour(c.Type, c.Dead) }
+
+// MapColour ...
+func (c CoralBlock) MapColour() color.RGBA { return coralMapColour(c.Type, c.Dead) }
+
+// MapColour ...
+func (CraftingTable) MapColour() color.RGBA { return mapColourWood }
+
+// MapColour ...
+func (DeadBush) MapColour() color.RGBA { return mapColourWood }
+
+// MapColour ...
+func (DecoratedPot) MapColour() color.RGBA { return mapColourTerracottaRed }
+
+// MapColour ...
+func (Deepslate) MapColour() color.RGBA { return mapColourDeepslate }
+
+// MapColour ...
+func (DeepslateBricks) MapColour() color.RGBA { return mapColourDeepslate }
+
+// MapColour ...
+func (DeepslateTiles) MapColour() color.RGBA { return mapColourDeepslate }
+
+// MapColour ...
+func (Diamond) MapColour() color.RGBA { return mapColourDiamond }
+
+// MapColour ...
+func (d DiamondOre) MapColour() color.RGBA { return oreMapColour(d.Type) }
+
+// MapColour ...
+func (Diorite) MapColour() color.RGBA { return mapColourQuartz }
+
+// MapColour ...
+func (Dirt) MapColour() color.RGBA { return mapColourDirt }
+
+// MapColour ...
+func (DirtPath) MapColour() color.RGBA { return mapColourDirt }
+
+// MapColour ...
+func (DoubleFlower) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (DoubleTallGrass) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (DragonEgg) MapColour() color.RGBA { return mapColourBlack }
+
+// MapColour ...
+func (DriedKelp) MapColour() color.RGBA { return mapColourGreen }
+
+// MapColour ...
+func (Dripstone) MapColour() color.RGBA { return mapColourTerracottaBrown }
+
+// MapColour ...
+func (Emerald) MapColour() color.RGBA { return mapColourEmerald }
+
+// MapColour ...
+func (e EmeraldOre) MapColour() color.RGBA { return oreMapColour(e.Type) }
+
+// MapColour ...
+func (EnchantingTable) MapColour() color.RGBA { return mapColourRed }
+
+// MapColour ...
+func (EndBricks) MapColour() color.RGBA { return mapColourSand }
+
+// MapColour ...
+func (EndPortal) MapColour() color.RGBA { return mapColourBlack }
+
+// MapColour ...
+func (EndPortalFrame) MapColour() color.RGBA { return mapColourGreen }
+
+// MapColour ...
+func (EndStone) MapColour() color.RGBA { return mapColourSand }
+
+// MapColour ...
+func (EnderChest) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (Farmland) MapColour() color.RGBA { return mapColourDirt }
+
+// MapColour ...
+func (Fern) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (f Fire) MapColour() color.RGBA {
+	if f.Type == SoulFire() {
+		return mapColourLightBlue
+	}
+	return mapColourFire
+}
+
+// MapColour ...
+func (FletchingTable) MapColour() color.RGBA { return mapColourWood }
+
+// MapColour ...
+func (Flower) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (f Froglight) MapColour() color.RGBA {
+	switch f.Type {
+	case Pearlescent():
+		return mapColourPink
+	case Verdant():
+		return mapColourGlowLichen
+	}
+	return mapColourSand
+}
+
+// MapColour ...
+func (Furnace) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (g GlazedTerracotta) MapColour() color.RGBA { return dyeMapColour(g.Colour) }
+
+// MapColour ...
+func (Glowstone) MapColour() color.RGBA { return mapColourSand }
+
+// MapColour ...
+func (Gold) MapColour() color.RGBA { return mapColourGold }
+
+// MapColour ...
+func (g GoldOre) MapColour() color.RGBA { return oreMapColour(g.Type) }
+
+// MapColour ...
+func (Granite) MapColour() color.RGBA { return mapColourDirt }
+
+// MapColour ...
+func (Grass) MapColour() color.RGBA { return mapColourGrass }
+
+// MapColour ...
+func (Gravel) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (Grindstone) MapColour() color.RGBA { return mapColourMetal }
+
+// MapColour ...
+func (HayBale) MapColour() color.RGBA { return mapColourYellow }
+
+// MapColour ...
+func (Honey) MapColour() color.RGBA { return mapColourOrange }
+
+// MapColour ...
+func (Honeycomb) MapColour() color.RGBA { return mapColourOrange }
+
+// MapColour ...
+func (Hopper) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (Iron) MapColour() color.RGBA { return mapColourMetal }
+
+// MapColour ...
+func (i IronOre) MapColour() color.RGBA { return oreMapColour(i.Type) }
+
+// MapColour ...
+func (Jukebox) MapColour() color.RGBA { return mapColourDirt }
+
+// MapColour ...
+func (Kelp) MapColour() color.RGBA { return mapColourWater }
+
+// MapColour ...
+func (Lantern) MapColour() color.RGBA { return mapColourMetal }
+
+// MapColour ...
+func (Lapis) MapColour() color.RGBA { return mapColourLapis }
+
+// MapColour ...
+func (l LapisOre) MapColour() color.RGBA { return oreMapColour(l.Type) }
+
+// MapColour ...
+func (Lava) MapColour() color.RGBA { return mapColourFire }
+
+// MapColour ...
+func (l Leaves) MapColour() color.RGBA {
+	if l.Wood == CherryWood() {
+		return mapColourPink
+	}
+	return mapColourPlant
+}
+
+// MapColour ...
+func (Lectern) MapColour() color.RGBA { return mapColourWood }
+
+// MapColour ...
+func (LitPumpkin) MapColour() color.RGBA { return mapColourOrange }
+
+// MapColour ...
+func (l Log) MapColour() color.RGBA {
+	if l.Stripped || l.Axis == cube.Y {
+		// Upright logs show the top of the log on maps, which has the colour
+		// of the planks of the wood.
+		return planksMapColour(l.Wood)
+	}
+	return barkMapColour(l.Wood)
+}
+
+// MapColour ...
+func (Loom) MapColour() color.RGBA { return mapColourWood }
+
+// MapColour ...
+func (Melon) MapColour() color.RGBA { return mapColourLime }
+
+// MapColour ...
+func (MelonSeeds) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (MossCarpet) MapColour() color.RGBA { return mapColourGreen }
+
+// MapColour ...
+func (Mud) MapColour() color.RGBA { return mapColourTerracottaCyan }
+
+// MapColour ...
+func (MudBricks) MapColour() color.RGBA { return mapColourTerracottaLightGrey }
+
+// MapColour ...
+func (MuddyMangroveRoots) MapColour() color.RGBA { return mapColourPodzol }
+
+// MapColour ...
+func (NetherBrickFence) MapColour() color.RGBA { return mapColourNether }
+
+// MapColour ...
+func (NetherBricks) MapColour() color.RGBA { return mapColourNether }
+
+// MapColour ...
+func (NetherGoldOre) MapColour() color.RGBA { return mapColourNether }
+
+// MapColour ...
+func (NetherQuartzOre) MapColour() color.RGBA { return mapColourNether }
+
+// MapColour ...
+func (NetherSprouts) MapColour() color.RGBA { return mapColourCyan }
+
+// MapColour ...
+func (NetherWart) MapColour() color.RGBA { return mapColourRed }
+
+// MapColour ...
+func (n NetherWartBlock) MapColour() color.RGBA {
+	if n.Warped {
+		return mapColourWarpedWartBlock
+	}
+	return mapColourRed
+}
+
+// MapColour ...
+func (Netherite) MapColour() color.RGBA { return mapColourBlack }
+
+// MapColour ...
+func (Netherrack) MapColour() color.RGBA { return mapColourNether }
+
+// MapColour ...
+func (Note) MapColour() color.RGBA { return mapColourWood }
+
+// MapColour ...
+func (n Nylium) MapColour() color.RGBA {
+	if n.Warped {
+		return mapColourWarpedNylium
+	}
+	return mapColourCrimsonNylium
+}
+
+// MapColour ...
+func (Obsidian) MapColour() color.RGBA { return mapColourBlack }
+
+// MapColour ...
+func (PackedIce) MapColour() color.RGBA { return mapColourIce }
+
+// MapColour ...
+func (PackedMud) MapColour() color.RGBA { return mapColourDirt }
+
+// MapColour ...
+func (PinkPetals) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (Piston) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (PistonArmCollision) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (p Planks) MapColour() color.RGBA { return planksMapColour(p.Wood) }
+
+// MapColour ...
+func (Podzol) MapColour() color.RGBA { return mapColourPodzol }
+
+// MapColour ...
+func (PolishedBlackstoneBrick) MapColour() color.RGBA { return mapColourBlack }
+
+// MapColour ...
+func (PolishedTuff) MapColour() color.RGBA { return mapColourTerracottaGrey }
+
+// MapColour ...
+func (Potato) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (p PressurePlate) MapColour() color.RGBA {
+	switch p.Type {
+	case StonePressurePlate():
+		return mapColourStone
+	case PolishedBlackstonePressurePlate():
+		return mapColourBlack
+	case LightWeightedPressurePlate():
+		return mapColourGold
+	case HeavyWeightedPressurePlate():
+		return mapColourMetal
+	}
+	return planksMapColour(p.Type.Wood)
+}
+
+// MapColour ...
+func (p Prismarine) MapColour() color.RGBA {
+	if p.Type == NormalPrismarine() {
+		return mapColourCyan
+	}
+	return mapColourDiamond
+}
+
+// MapColour ...
+func (Pumpkin) MapColour() color.RGBA { return mapColourOrange }
+
+// MapColour ...
+func (PumpkinSeeds) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (Purpur) MapColour() color.RGBA { return mapColourMagenta }
+
+// MapColour ...
+func (PurpurPillar) MapColour() color.RGBA { return mapColourMagenta }
+
+// MapColour ...
+func (Quartz) MapColour() color.RGBA { return mapColourQuartz }
+
+// MapColour ...
+func (QuartzBricks) MapColour() color.RGBA { return mapColourQuartz }
+
+// MapColour ...
+func (QuartzPillar) MapColour() color.RGBA { return mapColourQuartz }
+
+// MapColour ...
+func (RawCopper) MapColour() color.RGBA { return mapColourOrange }
+
+// MapColour ...
+func (RawGold) MapColour() color.RGBA { return mapColourGold }
+
+// MapColour ...
+func (RawIron) MapColour() color.RGBA { return mapColourRawIron }
+
+// MapColour ...
+func (RedstoneBlock) MapColour() color.RGBA { return mapColourFire }
+
+// MapColour ...
+func (ReinforcedDeepslate) MapColour() color.RGBA { return mapColourDeepslate }
+
+// MapColour ...
+func (Resin) MapColour() color.RGBA { return mapColourTerracottaOrange }
+
+// MapColour ...
+func (ResinBricks) MapColour() color.RGBA { return mapColourTerracottaOrange }
+
+// MapColour ...
+func (s Sand) MapColour() color.RGBA {
+	if s.Red {
+		return mapColourOrange
+	}
+	return mapColourSand
+}
+
+// MapColour ...
+func (s Sandstone) MapColour() color.RGBA {
+	if s.Red {
+		return mapColourOrange
+	}
+	return mapColourSand
+}
+
+// MapColour ...
+func (SeaLantern) MapColour() color.RGBA { return mapColourQuartz }
+
+// MapColour ...
+func (SeaPickle) MapColour() color.RGBA { return mapColourGreen }
+
+// MapColour ...
+func (ShortGrass) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (Shroomlight) MapColour() color.RGBA { return mapColourRed }
+
+// MapColour ...
+func (s Sign) MapColour() color.RGBA { return planksMapColour(s.Wood) }
+
+// MapColour ...
+func (s Slab) MapColour() color.RGBA { return materialMapColour(s.Block) }
+
+// MapColour ...
+func (Slime) MapColour() color.RGBA { return mapColourGrass }
+
+// MapColour ...
+func (SmithingTable) MapColour() color.RGBA { return mapColourWood }
+
+// MapColour ...
+func (Smoker) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (Snow) MapColour() color.RGBA { return mapColourSnow }
+
+// MapColour ...
+func (SoulSand) MapColour() color.RGBA { return mapColourBrown }
+
+// MapColour ...
+func (SoulSoil) MapColour() color.RGBA { return mapColourBrown }
+
+// MapColour ...
+func (Sponge) MapColour() color.RGBA { return mapColourYellow }
+
+// MapColour ...
+func (SporeBlossom) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (s StainedGlass) MapColour() color.RGBA { return dyeMapColour(s.Colour) }
+
+// MapColour ...
+func (s StainedGlassPane) MapColour() color.RGBA { return dyeMapColour(s.Colour) }
+
+// MapColour ...
+func (s StainedTerracotta) MapColour() color.RGBA { return terracottaMapColours[s.Colour.Uint8()] }
+
+// MapColour ...
+func (s Stairs) MapColour() color.RGBA { return materialMapColour(s.Block) }
+
+// MapColour ...
+func (Stone) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (StoneBricks) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (Stonecutter) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (SugarCane) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (TNT) MapColour() color.RGBA { return mapColourFire }
+
+// MapColour ...
+func (Terracotta) MapColour() color.RGBA { return mapColourOrange }
+
+// MapColour ...
+func (Tuff) MapColour() color.RGBA { return mapColourTerracottaGrey }
+
+// MapColour ...
+func (TuffBricks) MapColour() color.RGBA { return mapColourTerracottaGrey }
+
+// MapColour ...
+func (Vines) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (w Wall) MapColour() color.RGBA { return materialMapColour(w.Block) }
+
+// MapColour ...
+func (Water) MapColour() color.RGBA { return mapColourWater }
+
+// MapColour ...
+func (WheatSeeds) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (w Wood) MapColour() color.RGBA {
+	if w.Stripped {
+		return planksMapColour(w.Wood)
+	}
+	return barkMapColour(w.Wood)
+}
+
+// MapColour ...
+func (w WoodDoor) MapColour() color.RGBA { return planksMapColour(w.Wood) }
+
+// MapColour ...
+func (w WoodFence) MapColour() color.RGBA { return planksMapColour(w.Wood) }
+
+// MapColour ...
+func (w WoodFenceGate) MapColour() color.RGBA { return planksMapColour(w.Wood) }
+
+// MapColour ...
+func (w WoodTrapdoor) MapColour() color.RGBA { return planksMapColour(w.Wood) }
+
+// MapColour ...
+func (w Wool) MapColour() color.RGBA { return dyeMapColour(w.Colour) }
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Piston is the model of a piston. A retracted piston occupies a full block, while an extended piston is
+// shortened by its arm on the side that it is facing.
+type Piston struct {
+	// Facing is the face that the piston is pushing towards.
+	Facing cube.Face
+	// Extended specifies if the arm of the piston is extended.
+	Extended bool
+}
+
+// BBox returns a BBox that spans a full block if the piston is retracted, or a shortened block if the piston
+// is extended.
+func (p Piston) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	if p.Extended {
+		return []cube.BBox{shrink(full, p.Facing, 0.25)}
+	}
+	return []cube.BBox{full}
+}
+
+// FaceSolid returns true for all faces of a retracted piston and for all faces other than the face of the arm for
+// an extended piston.
+func (p Piston) FaceSolid(_ cube.Pos, face cube.Face, _ world.BlockSource) bool {
+	return !p.Extended || face != p.Facing
+}
+
+// PistonArm is the model of the arm of an extended piston. It consists of the head of the piston and the rod that
+// connects it to the piston.
+type PistonArm struct {
+	// Facing is the face that the piston is pushing towards.
+	Facing cube.Face
+}
+
+// BBox returns the BBoxes of the head and the rod of the piston arm.
+func (p PistonArm) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	rod := shrink(full, p.Facing, 0.25)
+	for _, a := range cube.Axes() {
+		if a != p.Facing.Axis() {
+			rod = rod.Stretch(a, -0.375)
+		}
+	}
+	return []cube.BBox{shrink(full, p.Facing.Opposite(), 0.75), rod}
+}
+
+// FaceSolid only returns true for the face of the head of the piston arm.
+func (p PistonArm) FaceSolid(_ cube.Pos, face cube.Face, _ world.BlockSource) bool {
+	return face == p.Facing
+}
+
+// shrink moves the side of the BBox passed on the face passed inwards by x.
+func shrink(box cube.BBox, face cube.Face, x float64) cube.BBox {
+	if face.Axis() == cube.Y {
+		// ExtendTowards moves the side opposite to vertical faces.
+		return box.ExtendTowards(face.Opposite(), x)
+	}
+	return box.ExtendTowards(face, -x)
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Repeater is a model used by redstone repeaters. It is a thin slab with a height of 0.125.
+type Repeater struct{}
+
+// BBox returns a flat BBox with a height of 0.125.
+func (Repeater) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	return []cube.BBox{cube.Box(0, 0, 0, 1, 0.125, 1)}
+}
+
+// FaceSolid returns true if the face passed is the bottom face.
+func (Repeater) FaceSolid(_ cube.Pos, face cube.Face, _ world.BlockSource) bool {
+	return face == cube.FaceDown
+}
@@ -0,0 +1,47 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// MovingBlock is a block entity that represents a block while it is being moved by a piston. It occupies the
+// position that the block is moving to and is replaced by the moving block once the piston finishes moving.
+type MovingBlock struct {
+	empty
+	transparent
+
+	// Moving is the block that is being moved.
+	Moving world.Block
+	// Piston is the position of the piston that is moving the block.
+	Piston cube.Pos
+}
+
+// EncodeBlock ...
+func (MovingBlock) EncodeBlock() (string, map[string]any) {
+	return "minecraft:moving_block", nil
+}
+
+// EncodeNBT ...
+func (m MovingBlock) EncodeNBT() map[string]any {
+	moving := m.Moving
+	if moving == nil {
+		moving = Air{}
+	}
+	return map[string]any{
+		"id":               "MovingBlock",
+		"movingBlock":      nbtconv.WriteBlock(moving),
+		"movingBlockExtra": nbtconv.WriteBlock(Air{}),
+		"pistonPosX":       int32(m.Piston[0]),
+		"pistonPosY":       int32(m.Piston[1]),
+		"pistonPosZ":       int32(m.Piston[2]),
+	}
+}
+
+// DecodeNBT ...
+func (m MovingBlock) DecodeNBT(data map[string]any) any {
+	m.Moving = nbtconv.Block(data, "movingBlock")
+	m.Piston = cube.Pos{int(nbtconv.Int32(data, "pistonPosX")), int(nbtconv.Int32(data, "pistonPosY")), int(nbtconv.Int32(data, "pistonPosZ"))}
+	return m
+}
@@ -0,0 +1,314 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"slices"
+)
+
+// NetherPortal is a translucent block that fills an obsidian frame once it is lit with fire. Entities that stand in a
+// nether portal are transported between the overworld and the nether.
+type NetherPortal struct {
+	transparent
+	empty
+
+	// Axis is the horizontal axis that the portal is aligned with. It is either cube.X or cube.Z.
+	Axis cube.Axis
+}
+
+// PortalTraveller represents an entity that can travel through nether portals and end portals.
+type PortalTraveller interface {
+	world.Entity
+	// EnterPortal is called every tick that the entity is inside a portal block at the position passed. dim is the
+	// Dimension of the portal: world.Nether for nether portals and world.End for end portals. The entity itself
+	// decides when to travel, for example after standing in the portal for a few seconds.
+	EnterPortal(dim world.Dimension, pos cube.Pos)
+}
+
+// maxPortalSize is the maximum width and height of the inside of a nether portal frame.
+const maxPortalSize = 21
+
+// LightEmissionLevel ...
+func (NetherPortal) LightEmissionLevel() uint8 {
+	return 11
+}
+
+// EntityInside ...
+func (NetherPortal) EntityInside(pos cube.Pos, _ *world.Tx, e world.Entity) {
+	if t, ok := e.(PortalTraveller); ok {
+		t.EnterPortal(world.Nether, pos)
+	}
+}
+
+// NeighbourUpdateTick removes the portal if its frame was broken.
+func (p NetherPortal) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	right := portalFace(p.Axis)
+	for _, face := range []cube.Face{cube.FaceUp, cube.FaceDown, right, right.Opposite()} {
+		side := pos.Side(face)
+		if other, ok := tx.Block(side).(NetherPortal); (ok && other.Axis == p.Axis) || portalObsidian(side, tx) {
+			continue
+		}
+		tx.SetBlock(pos, nil, nil)
+		return
+	}
+}
+
+// EncodeBlock ...
+func (p NetherPortal) EncodeBlock() (string, map[string]any) {
+	return "minecraft:portal", map[string]any{"portal_axis": p.Axis.String()}
+}
+
+// allNetherPortals returns all possible nether portal blocks.
+func allNetherPortals() []world.Block {
+	return []world.Block{NetherPortal{Axis: cube.X}, NetherPortal{Axis: cube.Z}}
+}
+
+// netherPortalFrame is the inside of an obsidian frame that holds a nether portal.
+type netherPortalFrame struct {
+	axis cube.Axis
+	// origin is the bottom position of the inside of the frame with the lowest coordinate on the axis.
+	origin        cube.Pos
+	width, height int
+}
+
+// at returns the position at the horizontal offset x and vertical offset y from the origin of the frame.
+func (f netherPortalFrame) at(x, y int) cube.Pos {
+	off := cube.Pos{}.Side(portalFace(f.axis))
+	return f.origin.Add(cube.Pos{off[0] * x, y, off[2] * x})
+}
+
+// fill fills the inside of the frame with nether portal blocks.
+func (f netherPortalFrame) fill(tx *world.Tx) {
+	for y := 0; y < f.height; y++ {
+		for x := 0; x < f.width; x++ {
+			tx.SetBlock(f.at(x, y), NetherPortal{Axis: f.axis}, nil)
+		}
+	}
+}
+
+// lightNetherPortal fills the empty obsidian frame that encloses pos with nether portal blocks. False is returned if
+// pos is not inside a frame or if the World does not allow nether portals.
+func lightNetherPortal(pos cube.Pos, tx *world.Tx) bool {
+	if tx.World().Dimension() == world.End {
+		return false
+	}
+	for _, axis := range []cube.Axis{cube.X, cube.Z} {
+		if f, ok := findNetherPortalFrame(pos, axis, tx); ok {
+			f.fill(tx)
+			return true
+		}
+	}
+	return false
+}
+
+// findNetherPortalFrame finds the empty obsidian frame aligned with the axis passed that encloses pos. False is
+// returned if no such frame exists or if it is too small or too large.
+func findNetherPortalFrame(pos cube.Pos, axis cube.Axis, tx *world.Tx) (netherPortalFrame, bool) {
+	left := portalFace(axis).Opposite()
+	for i := 0; i < maxPortalSize && portalFillable(pos.Side(cube.FaceDown), tx); i++ {
+		pos = pos.Side(cube.FaceDown)
+	}
+	for i := 0; i < maxPortalSize && portalFillable(pos.Side(left), tx); i++ {
+		pos = pos.Side(left)
+	}
+	if !portalFillable(pos, tx) || !portalObsidian(pos.Side(left), tx) || !portalObsidian(pos.Side(cube.FaceDown), tx) {
+		return netherPortalFrame{}, false
+	}
+
+	f := netherPortalFrame{axis: axis, origin: pos}
+	for ; f.width <= maxPortalSize && portalFillable(f.at(f.width, 0), tx); f.width++ {
+		if !portalObsidian(f.at(f.width, -1), tx) {
+			return f, false
+		}
+	}
+	if f.width < 2 || f.width > maxPortalSize || !portalObsidian(f.at(f.width, 0), tx) {
+		return f, false
+	}
+	for ; f.height <= maxPortalSize; f.height++ {
+		fillable := 0
+		for x := 0; x < f.width; x++ {
+			if portalFillable(f.at(x, f.height), tx) {
+				fillable++
+			}
+		}
+		if fillable == 0 {
+			// This is the top of the frame, which must consist of obsidian only.
+			for x := 0; x < f.width; x++ {
+				if !portalObsidian(f.at(x, f.height), tx) {
+					return f, false
+				}
+			}
+			break
+		}
+		if fillable != f.width || !portalObsidian(f.at(-1, f.height), tx) || !portalObsidian(f.at(f.width, f.height), tx) {
+			return f, false
+		}
+	}
+	return f, f.height >= 3 && f.height <= maxPortalSize
+}
+
+// NetherPortalExit returns the position at which an entity travelling through a nether portal arrives in the World
+// of tx. pos is the position of the entity in the World of the Dimension passed and is scaled if either of the worlds
+// is the nether. The nearest nether portal around the scaled position is used as the exit. If no portal is found, a
+// new portal is created.
+func NetherPortalExit(tx *world.Tx, from world.Dimension, pos mgl64.Vec3) mgl64.Vec3 {
+	pos, radius := netherPortalTarget(from, tx.World().Dimension(), pos)
+	r := tx.Range()
+	target := cube.PosFromVec3(pos)
+	target[1] = min(max(target[1], r[0]+1), r[1]-5)
+
+	if portal, ok := findNetherPortal(tx, target, radius); ok {
+		return portal.Vec3Middle()
+	}
+	return createNetherPortal(tx, target)
+}
+
+// NetherPortalExitChunks returns the positions of the chunks that NetherPortalExit searches for a portal, or builds
+// a new portal in, when an entity at pos in the Dimension from travels to the Dimension to. Loading these chunks
+// before calling NetherPortalExit, for example using world.World.ExecLoaded, prevents NetherPortalExit from having
+// to load them.
+func NetherPortalExitChunks(from, to world.Dimension, pos mgl64.Vec3) []world.ChunkPos {
+	pos, radius := netherPortalTarget(from, to, pos)
+	x, z := int(math.Floor(pos[0])), int(math.Floor(pos[2]))
+	var chunks []world.ChunkPos
+	for cx := (x - radius - 1) >> 4; cx <= (x+radius+1)>>4; cx++ {
+		for cz := (z - radius - 1) >> 4; cz <= (z+radius+1)>>4; cz++ {
+			chunks = append(chunks, world.ChunkPos{int32(cx), int32(cz)})
+		}
+	}
+	return chunks
+}
+
+// netherPortalTarget scales the position of an entity in the Dimension from travelling through a nether portal to
+// the Dimension to. The horizontal radius around the position that is searched for an existing portal is returned
+// too.
+func netherPortalTarget(from, to world.Dimension, pos mgl64.Vec3) (mgl64.Vec3, int) {
+	if from != world.Nether && to == world.Nether {
+		return mgl64.Vec3{pos[0] / 8, pos[1], pos[2] / 8}, 16
+	} else if from == world.Nether && to != world.Nether {
+		return mgl64.Vec3{pos[0] * 8, pos[1], pos[2] * 8}, 32
+	}
+	return pos, 32
+}
+
+// findNetherPortal finds the nether portal block closest to pos within the horizontal radius passed. If found, the
+// lowest portal block in the column is returned.
+func findNetherPortal(tx *world.Tx, pos cube.Pos, radius int) (cube.Pos, bool) {
+	var (
+		found   bool
+		nearest cube.Pos
+		dist    = math.MaxInt
+		r       = tx.Range()
+	)
+	isPortal := func(b world.Block) bool {
+		_, ok := b.(NetherPortal)
+		return ok
+	}
+	low, high := cube.Pos{pos[0] - radius, r[0], pos[2] - radius}, cube.Pos{pos[0] + radius, r[1], pos[2] + radius}
+	for candidate := range tx.BlocksWithin(low, high, isPortal) {
+		if below := candidate.Side(cube.FaceDown); !below.OutOfBounds(r) && isPortal(tx.Block(below)) {
+			// Only the lowest block of a portal column is of interest.
+			continue
+		}
+		if d := candidate.Sub(pos); d[0]*d[0]+d[1]*d[1]+d[2]*d[2] < dist {
+			found, nearest, dist = true, candidate, d[0]*d[0]+d[1]*d[1]+d[2]*d[2]
+		}
+	}
+	return nearest, found
+}
+
+// createNetherPortal builds a new nether portal with a 2x3 inside as close as possible to pos and returns the position
+// at which an entity should arrive in it. If there is no room for a portal close to pos, the portal is built at pos
+// with an obsidian platform to stand on.
+func createNetherPortal(tx *world.Tx, pos cube.Pos) mgl64.Vec3 {
+	const radius = 16
+	columns := make([]cube.Pos, 0, (radius*2+1)*(radius*2+1))
+	for x := -radius; x <= radius; x++ {
+		for z := -radius; z <= radius; z++ {
+			columns = append(columns, cube.Pos{x, 0, z})
+		}
+	}
+	slices.SortFunc(columns, func(a, b cube.Pos) int {
+		return (a[0]*a[0] + a[2]*a[2]) - (b[0]*b[0] + b[2]*b[2])
+	})
+
+	r := tx.Range()
+	for _, axis := range []cube.Axis{cube.X, cube.Z} {
+		for _, c := range columns {
+			x, z := pos[0]+c[0], pos[2]+c[2]
+			for y := min(tx.HighestBlock(x, z)+1, r[1]-4); y > r[0]; y-- {
+				f := netherPortalFrame{axis: axis, origin: cube.Pos{x, y + 1, z}, width: 2, height: 3}
+				if netherPortalFits(f, tx) {
+					return buildNetherPortal(f, tx)
+				}
+			}
+		}
+	}
+	f := netherPortalFrame{axis: cube.X, origin: pos.Add(cube.Pos{0, 1, 0}), width: 2, height: 3}
+	for x := 0; x < f.width; x++ {
+		for _, side := range []cube.Face{cube.FaceNorth, cube.FaceSouth} {
+			tx.SetBlock(f.at(x, -1).Side(side), Obsidian{}, nil)
+			for y := 0; y < f.height; y++ {
+				tx.SetBlock(f.at(x, y).Side(side), nil, nil)
+			}
+		}
+	}
+	return buildNetherPortal(f, tx)
+}
+
+// netherPortalFits checks if the frame passed, including its obsidian border, may be built without replacing any
+// blocks and if it stands on solid ground.
+func netherPortalFits(f netherPortalFrame, tx *world.Tx) bool {
+	for x := -1; x <= f.width; x++ {
+		below := f.at(x, -2)
+		if !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+			return false
+		}
+		for y := -1; y <= f.height; y++ {
+			if _, ok := tx.Block(f.at(x, y)).(Air); !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// buildNetherPortal places the obsidian border of the frame passed and fills it with nether portal blocks. The
+// position at which an entity should arrive in the portal is returned.
+func buildNetherPortal(f netherPortalFrame, tx *world.Tx) mgl64.Vec3 {
+	for x := -1; x <= f.width; x++ {
+		for y := -1; y <= f.height; y++ {
+			if x == -1 || x == f.width || y == -1 || y == f.height {
+				tx.SetBlock(f.at(x, y), Obsidian{}, nil)
+			}
+		}
+	}
+	f.fill(tx)
+	return f.origin.Vec3Middle()
+}
+
+// portalFace returns the face pointing in the positive direction along the horizontal axis passed.
+func portalFace(axis cube.Axis) cube.Face {
+	if axis == cube.X {
+		return cube.FaceEast
+	}
+	return cube.FaceSouth
+}
+
+// portalFillable checks if the block at the position passed may be replaced by a nether portal block when lighting a
+// frame.
+func portalFillable(pos cube.Pos, tx *world.Tx) bool {
+	switch tx.Block(pos).(type) {
+	case Air, Fire:
+		return true
+	}
+	return false
+}
+
+// portalObsidian checks if the block at the position passed is obsidian that may form a nether portal frame.
+func portalObsidian(pos cube.Pos, tx *world.Tx) bool {
+	o, ok := tx.Block(pos).(Obsidian)
+	return ok && !o.Crying
+}
@@ -17,6 +17,9 @@ type Note struct {
 
 	// Pitch is the current pitch the note block is set to. Value ranges from 0-24.
 	Pitch int
+	// Powered is whether the note block is currently powered by redstone. A note block plays its note when it
+	// becomes powered.
+	Powered bool
 }
 
 // playNote ...
@@ -38,12 +41,26 @@ func (n Note) instrument(pos cube.Pos, tx *world.Tx) sound.Instrument {
 // DecodeNBT ...
 func (n Note) DecodeNBT(data map[string]any) any {
 	n.Pitch = int(nbtconv.Uint8(data, "note"))
+	n.Powered = nbtconv.Bool(data, "powered")
 	return n
 }
 
 // EncodeNBT ...
 func (n Note) EncodeNBT() map[string]any {
-	return map[string]any{"note": byte(n.Pitch)}
+	return map[string]any{"note": byte(n.Pitch), "powered": boolByte(n.Powered)}
+}
+
+// NeighbourUpdateTick ...
+func (n Note) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	powered := receivesPower(pos, tx)
+	if powered == n.Powered {
+		return
+	}
+	n.Powered = powered
+	if _, ok := tx.Block(pos.Side(cube.FaceUp)).(Air); ok && powered {
+		n.playNote(pos, tx)
+	}
+	tx.SetBlock(pos, n, &world.SetOpts{DisableBlockUpdates: true, DisableLiquidDisplacement: true})
 }
 
 // Activate ...
@@ -0,0 +1,54 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+)
+
+// Nylium is a variant of netherrack covered with fungal growth. It generates
+// as the floor of crimson and warped forests in the Nether.
+type Nylium struct {
+	solid
+	bassDrum
+
+	// Warped specifies if the nylium is the turquoise variant found in warped
+	// forests. If false, the nylium is the crimson variant.
+	Warped bool
+}
+
+// SoilFor ...
+func (n Nylium) SoilFor(block world.Block) bool {
+	_, ok := block.(NetherSprouts)
+	return ok
+}
+
+// RandomTick turns the nylium into netherrack if a solid block is placed on
+// top of it.
+func (n Nylium) RandomTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	up := pos.Side(cube.FaceUp)
+	if tx.Block(up).Model().FaceSolid(up, cube.FaceDown, tx) {
+		tx.SetBlock(pos, Netherrack{}, nil)
+	}
+}
+
+// BreakInfo ...
+func (n Nylium) BreakInfo() BreakInfo {
+	return newBreakInfo(0.4, pickaxeHarvestable, pickaxeEffective, silkTouchOneOf(Netherrack{}, n))
+}
+
+// EncodeItem ...
+func (n Nylium) EncodeItem() (name string, meta int16) {
+	if n.Warped {
+		return "minecraft:warped_nylium", 0
+	}
+	return "minecraft:crimson_nylium", 0
+}
+
+// EncodeBlock ...
+func (n Nylium) EncodeBlock() (string, map[string]any) {
+	if n.Warped {
+		return "minecraft:warped_nylium", nil
+	}
+	return "minecraft:crimson_nylium", nil
+}
@@ -0,0 +1,266 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Piston is a block that pushes the blocks in front of it when it receives redstone power. A sticky piston
+// additionally pulls the block in front of it back when it stops receiving power. Blocks next to sticky blocks, such
+// as slime blocks, are moved along with them.
+type Piston struct {
+	// Facing is the face that the piston pushes blocks towards.
+	Facing cube.Face
+	// Sticky specifies if the piston is a sticky piston.
+	Sticky bool
+
+	// state is the state of the arm of the piston: Retracted, extending, extended or retracting.
+	state pistonState
+	// progress is the progress of the arm of the piston, ranging from 0 (retracted) to 1 (extended), and
+	// lastProgress is its progress during the previous tick.
+	progress, lastProgress float64
+	// attached holds the positions of the blocks that are currently being moved by the piston.
+	attached []cube.Pos
+}
+
+// pistonState is the state that the arm of a piston is in.
+type pistonState uint8
+
+const (
+	pistonRetracted pistonState = iota
+	pistonExtending
+	pistonExtended
+	pistonRetracting
+)
+
+// pistonPushLimit is the maximum amount of blocks that a piston is able to push at once.
+const pistonPushLimit = 12
+
+// Model ...
+func (p Piston) Model() world.BlockModel {
+	return model.Piston{Facing: p.Facing, Extended: p.state != pistonRetracted}
+}
+
+// BreakInfo ...
+func (p Piston) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, pickaxeEffective, oneOf(Piston{Sticky: p.Sticky})).withBreakHandler(func(pos cube.Pos, tx *world.Tx, _ item.User) {
+		if _, ok := tx.Block(pos.Side(p.Facing)).(PistonArmCollision); ok {
+			tx.SetBlock(pos.Side(p.Facing), nil, nil)
+		}
+	})
+}
+
+// UseOnBlock ...
+func (p Piston) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, p)
+	if !used {
+		return false
+	}
+	p.Facing = calculateFace(user, pos)
+
+	place(tx, pos, p, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (p Piston) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	p.update(pos, tx)
+}
+
+// Tick ...
+func (p Piston) Tick(_ int64, pos cube.Pos, tx *world.Tx) {
+	p.lastProgress = p.progress
+	switch p.state {
+	case pistonExtending:
+		if p.progress += 0.5; p.progress >= 1 {
+			p.progress = 1
+			p.state, p.attached = pistonExtended, nil
+			if head := pos.Side(p.Facing); replaceableWith(tx, head, PistonArmCollision{}) {
+				tx.SetBlock(head, PistonArmCollision{Facing: p.Facing, Sticky: p.Sticky}, nil)
+			}
+		}
+	case pistonRetracting:
+		if p.progress -= 0.5; p.progress <= 0 {
+			p.progress = 0
+			p.state, p.attached = pistonRetracted, nil
+		}
+	default:
+		return
+	}
+	tx.SetBlock(pos, p, &world.SetOpts{DisableBlockUpdates: true})
+	if p.state == pistonRetracted || p.state == pistonExtended {
+		// The power of the piston might have changed while the arm was moving.
+		p.update(pos, tx)
+	}
+}
+
+// update extends or retracts the piston depending on whether it receives redstone power.
+func (p Piston) update(pos cube.Pos, tx *world.Tx) {
+	switch powered := p.powered(pos, tx); {
+	case powered && p.state == pistonRetracted:
+		p.extend(pos, tx)
+	case !powered && p.state == pistonExtended:
+		p.retract(pos, tx)
+	}
+}
+
+// powered checks if the piston receives redstone power through any face other than its front.
+func (p Piston) powered(pos cube.Pos, tx *world.Tx) bool {
+	for _, face := range cube.Faces() {
+		if face != p.Facing && tx.RedstonePower(pos.Side(face), face.Opposite(), true) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// extend extends the arm of the piston, pushing the blocks in front of it. Nothing happens if the blocks in front
+// of the piston cannot be pushed.
+func (p Piston) extend(pos cube.Pos, tx *world.Tx) {
+	s := pistonStructure{tx: tx, piston: pos, face: p.Facing, extending: true}
+	if !s.resolve() {
+		return
+	}
+	for _, broken := range s.broken {
+		breakBlock(tx.Block(broken), broken, tx)
+	}
+	tx.MoveBlocks(s.moved, p.Facing, p.movingBlock(pos))
+
+	p.state, p.attached = pistonExtending, p.destinations(s.moved, p.Facing)
+	tx.SetBlock(pos, p, &world.SetOpts{DisableBlockUpdates: true})
+	tx.PlaySound(pos.Vec3Centre(), sound.PistonExtend{})
+}
+
+// retract retracts the arm of the piston. A sticky piston pulls back the block in front of its arm.
+func (p Piston) retract(pos cube.Pos, tx *world.Tx) {
+	head := pos.Side(p.Facing)
+	if _, ok := tx.Block(head).(PistonArmCollision); ok {
+		tx.SetBlock(head, nil, nil)
+	}
+	p.state, p.attached = pistonRetracting, nil
+	if s := (pistonStructure{tx: tx, piston: pos, face: p.Facing.Opposite()}); p.Sticky && s.resolve() {
+		tx.MoveBlocks(s.moved, s.face, p.movingBlock(pos))
+		p.attached = p.destinations(s.moved, s.face)
+	}
+	tx.SetBlock(pos, p, &world.SetOpts{DisableBlockUpdates: true})
+	tx.PlaySound(pos.Vec3Centre(), sound.PistonRetract{})
+}
+
+// movingBlock returns a function that wraps a block moved by the piston at the position passed into a
+// MovingBlock.
+func (p Piston) movingBlock(pos cube.Pos) func(b world.Block) world.Block {
+	return func(b world.Block) world.Block {
+		return MovingBlock{Moving: b, Piston: pos}
+	}
+}
+
+// destinations returns the positions that the blocks at the positions passed are moved to when moved towards
+// the face passed.
+func (p Piston) destinations(positions []cube.Pos, face cube.Face) []cube.Pos {
+	destinations := make([]cube.Pos, len(positions))
+	for i, pos := range positions {
+		destinations[i] = pos.Side(face)
+	}
+	return destinations
+}
+
+// pistonMovable checks if the block passed may be pushed or pulled by a piston. Blocks that carry additional data,
+// such as chests, are never movable. Blocks that are broken by pistons are considered movable.
+func pistonMovable(b world.Block) bool {
+	if _, ok := b.(world.NBTer); ok {
+		return false
+	}
+	if immovable, ok := b.(PistonImmovable); ok && immovable.PistonImmovable() {
+		return false
+	}
+	breakable, ok := b.(Breakable)
+	return ok && breakable.BreakInfo().Hardness >= 0
+}
+
+// pistonBreaks checks if the block passed is broken when pushed by a piston.
+func pistonBreaks(pos cube.Pos, b world.Block, tx *world.Tx) bool {
+	if breakable, ok := b.(PistonBreakable); ok {
+		return breakable.PistonBreakable()
+	}
+	return len(b.Model().BBox(pos, tx)) == 0
+}
+
+// EncodeItem ...
+func (p Piston) EncodeItem() (name string, meta int16) {
+	if p.Sticky {
+		return "minecraft:sticky_piston", 0
+	}
+	return "minecraft:piston", 0
+}
+
+// EncodeBlock ...
+func (p Piston) EncodeBlock() (string, map[string]any) {
+	name := "minecraft:piston"
+	if p.Sticky {
+		name = "minecraft:sticky_piston"
+	}
+	return name, map[string]any{"facing_direction": pistonFacing(p.Facing)}
+}
+
+// EncodeNBT ...
+func (p Piston) EncodeNBT() map[string]any {
+	attached := make([]int32, 0, len(p.attached)*3)
+	for _, pos := range p.attached {
+		attached = append(attached, int32(pos[0]), int32(pos[1]), int32(pos[2]))
+	}
+	return map[string]any{
+		"id":             "PistonArm",
+		"AttachedBlocks": attached,
+		"BreakBlocks":    []int32{},
+		"Progress":       float32(p.progress),
+		"LastProgress":   float32(p.lastProgress),
+		"State":          uint8(p.state),
+		"NewState":       uint8(p.state),
+		"Sticky":         boolByte(p.Sticky),
+	}
+}
+
+// DecodeNBT ...
+func (p Piston) DecodeNBT(data map[string]any) any {
+	p.state = pistonState(nbtconv.Uint8(data, "State"))
+	p.progress = float64(nbtconv.Float32(data, "Progress"))
+	p.lastProgress = float64(nbtconv.Float32(data, "LastProgress"))
+	var attached []int32
+	switch v := data["AttachedBlocks"].(type) {
+	case []int32:
+		attached = v
+	case []any:
+		for _, a := range v {
+			i, _ := a.(int32)
+			attached = append(attached, i)
+		}
+	}
+	p.attached = make([]cube.Pos, 0, len(attached)/3)
+	for i := 0; i+2 < len(attached); i += 3 {
+		p.attached = append(p.attached, cube.Pos{int(attached[i]), int(attached[i+1]), int(attached[i+2])})
+	}
+	return p
+}
+
+// pistonFacing converts the face passed to the facing direction of a piston or piston arm as used in its block
+// state. The horizontal faces of pistons are reversed compared to other blocks.
+func pistonFacing(face cube.Face) int32 {
+	if face.Axis() == cube.Y {
+		return int32(face)
+	}
+	return int32(face.Opposite())
+}
+
+// allPistons ...
+func allPistons() (pistons []world.Block) {
+	for _, f := range cube.Faces() {
+		pistons = append(pistons, Piston{Facing: f})
+		pistons = append(pistons, Piston{Facing: f, Sticky: true})
+	}
+	return
+}
@@ -0,0 +1,64 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// PistonArmCollision is the arm of an extended piston. It is placed in front of a piston when it extends and is
+// removed when the piston retracts.
+type PistonArmCollision struct {
+	transparent
+
+	// Facing is the face that the piston of the arm is pushing towards.
+	Facing cube.Face
+	// Sticky specifies if the arm belongs to a sticky piston.
+	Sticky bool
+}
+
+// Model ...
+func (p PistonArmCollision) Model() world.BlockModel {
+	return model.PistonArm{Facing: p.Facing}
+}
+
+// BreakInfo ...
+func (p PistonArmCollision) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, pickaxeEffective, simpleDrops()).withBreakHandler(func(pos cube.Pos, tx *world.Tx, _ item.User) {
+		// Breaking the arm of a piston also breaks the piston itself.
+		if piston, ok := tx.Block(pos.Side(p.Facing.Opposite())).(Piston); ok {
+			breakBlock(piston, pos.Side(p.Facing.Opposite()), tx)
+		}
+	})
+}
+
+// PistonImmovable ...
+func (PistonArmCollision) PistonImmovable() bool {
+	return true
+}
+
+// NeighbourUpdateTick ...
+func (p PistonArmCollision) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if _, ok := tx.Block(pos.Side(p.Facing.Opposite())).(Piston); !ok {
+		tx.SetBlock(pos, nil, nil)
+	}
+}
+
+// EncodeBlock ...
+func (p PistonArmCollision) EncodeBlock() (string, map[string]any) {
+	name := "minecraft:piston_arm_collision"
+	if p.Sticky {
+		name = "minecraft:sticky_piston_arm_collision"
+	}
+	return name, map[string]any{"facing_direction": pistonFacing(p.Facing)}
+}
+
+// allPistonArmCollisions ...
+func allPistonArmCollisions() (arms []world.Block) {
+	for _, f := range cube.Faces() {
+		arms = append(arms, PistonArmCollision{Facing: f})
+		arms = append(arms, PistonArmCollision{Facing: f, Sticky: true})
+	}
+	return
+}
@@ -0,0 +1,188 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"slices"
+)
+
+// pistonStructure resolves the blocks that are moved when a piston extends or retracts. Next to the line of blocks
+// in front of the piston, blocks that stick to sticky blocks such as slime blocks are moved along, which may in turn
+// push other blocks out of the way.
+type pistonStructure struct {
+	tx *world.Tx
+	// piston is the position of the piston and face is the face that the blocks are moved towards.
+	piston cube.Pos
+	face   cube.Face
+	// extending specifies if the piston is extending. If false, the piston is a sticky piston pulling blocks.
+	extending bool
+
+	// moved holds the positions of the blocks that are moved and broken the positions of the blocks that are
+	// broken because they are in the way of the moved blocks.
+	moved, broken []cube.Pos
+}
+
+// resolve resolves the blocks moved and broken by the piston. False is returned if the blocks cannot be moved, for
+// example because an immovable block is in the way or because more than pistonPushLimit blocks would be moved.
+func (s *pistonStructure) resolve() bool {
+	start := s.piston.Side(s.face)
+	if !s.extending {
+		// A retracting piston pulls the block in front of its arm.
+		start = s.piston.Side(s.face.Opposite()).Side(s.face.Opposite())
+	}
+	if start.OutOfBounds(s.tx.Range()) {
+		return false
+	}
+	b := s.tx.Block(start)
+	if pistonEmpty(b) {
+		return true
+	}
+	if !pistonMovable(b) {
+		return false
+	}
+	if pistonBreaks(start, b, s.tx) {
+		if s.extending {
+			s.broken = append(s.broken, start)
+			return true
+		}
+		return false
+	}
+	if !s.addLine(start) {
+		return false
+	}
+	for i := 0; i < len(s.moved); i++ {
+		if !s.addBranches(s.moved[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// addLine adds the block at the position passed to the structure, together with the blocks sticking to it behind
+// it and the blocks in front of it that it pushes. False is returned if the blocks in front of it cannot be pushed.
+func (s *pistonStructure) addLine(origin cube.Pos) bool {
+	b := s.tx.Block(origin)
+	if pistonEmpty(b) || !pistonMovable(b) || pistonBreaks(origin, b, s.tx) || origin == s.piston || slices.Contains(s.moved, origin) {
+		// Blocks that cannot be moved are not pulled along by sticky blocks.
+		return true
+	}
+	back := s.face.Opposite()
+
+	// Blocks behind the origin that stick to it are moved along with it.
+	n := 1
+	if n+len(s.moved) > pistonPushLimit {
+		return false
+	}
+	for prev := b; ; {
+		if _, sticky := prev.(PistonSticky); !sticky {
+			break
+		}
+		pos := pistonOffset(origin, back, n)
+		if pos.OutOfBounds(s.tx.Range()) {
+			break
+		}
+		next := s.tx.Block(pos)
+		if pistonEmpty(next) || !pistonSticks(prev, next) || !pistonMovable(next) || pistonBreaks(pos, next, s.tx) || pos == s.piston {
+			break
+		}
+		if n++; n+len(s.moved) > pistonPushLimit {
+			return false
+		}
+		prev = next
+	}
+	added := 0
+	for i := n - 1; i >= 0; i-- {
+		s.moved = append(s.moved, pistonOffset(origin, back, i))
+		added++
+	}
+
+	// Blocks in front of the origin are pushed out of the way.
+	for i := 1; ; i++ {
+		pos := pistonOffset(origin, s.face, i)
+		if index := slices.Index(s.moved, pos); index != -1 {
+			// The line collides with blocks that are already moved. The blocks added are reordered so that they
+			// are moved after those blocks.
+			s.reorder(added, index)
+			for j := 0; j <= index+added; j++ {
+				if !s.addBranches(s.moved[j]) {
+					return false
+				}
+			}
+			return true
+		}
+		if pos.OutOfBounds(s.tx.Range()) {
+			return false
+		}
+		next := s.tx.Block(pos)
+		if pistonEmpty(next) {
+			return true
+		}
+		if !pistonMovable(next) || pos == s.piston {
+			return false
+		}
+		if pistonBreaks(pos, next, s.tx) {
+			s.broken = append(s.broken, pos)
+			return true
+		}
+		if len(s.moved) >= pistonPushLimit {
+			return false
+		}
+		s.moved = append(s.moved, pos)
+		added++
+	}
+}
+
+// addBranches adds the blocks that stick to the sticky block at the position passed to the structure. Blocks in
+// front of or behind the sticky block are already handled by addLine.
+func (s *pistonStructure) addBranches(pos cube.Pos) bool {
+	b := s.tx.Block(pos)
+	if _, sticky := b.(PistonSticky); !sticky {
+		return true
+	}
+	for _, face := range cube.Faces() {
+		if face.Axis() == s.face.Axis() {
+			continue
+		}
+		side := pos.Side(face)
+		if side.OutOfBounds(s.tx.Range()) || !pistonSticks(b, s.tx.Block(side)) {
+			continue
+		}
+		if !s.addLine(side) {
+			return false
+		}
+	}
+	return true
+}
+
+// reorder moves the last n blocks added to the moved blocks to the index passed, so that blocks are moved in the
+// order that they are pushed.
+func (s *pistonStructure) reorder(n, index int) {
+	last := slices.Clone(s.moved[len(s.moved)-n:])
+	s.moved = slices.Insert(s.moved[:len(s.moved)-n], index, last...)
+}
+
+// pistonEmpty checks if the block passed does not have to be moved by a piston, because it is air or a liquid.
+func pistonEmpty(b world.Block) bool {
+	if _, ok := b.(Air); ok {
+		return true
+	}
+	_, ok := b.(world.Liquid)
+	return ok
+}
+
+// pistonOffset returns the position n blocks away from the position passed towards the face passed.
+func pistonOffset(pos cube.Pos, face cube.Face, n int) cube.Pos {
+	d := cube.Pos{}.Side(face)
+	return pos.Add(cube.Pos{d[0] * n, d[1] * n, d[2] * n})
+}
+
+// pistonSticks checks if the blocks passed stick to each other when moved by a piston. This is the case if either
+// of them is sticky and neither refuses to stick to the other.
+func pistonSticks(a, b world.Block) bool {
+	sa, aSticky := a.(PistonSticky)
+	sb, bSticky := b.(PistonSticky)
+	if (aSticky && !sa.PistonSticksTo(b)) || (bSticky && !sb.PistonSticksTo(a)) {
+		return false
+	}
+	return aSticky || bSticky
+}
@@ -0,0 +1,120 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// PoweredRail is a rail that accelerates minecarts moving over it while it is
+// powered by redstone. Minecarts on an unpowered powered rail are slowed down.
+// Powered rails cannot curve.
+type PoweredRail struct {
+	empty
+	transparent
+
+	// Shape is the shape of the rail, which specifies the directions that it
+	// connects to. Powered rails only have straight shapes.
+	Shape RailShape
+	// Powered specifies if the rail is powered. A powered rail passes its
+	// power on to up to eight powered rails connected to it.
+	Powered bool
+}
+
+// BreakInfo ...
+func (r PoweredRail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(PoweredRail{}))
+}
+
+// UseOnBlock ...
+func (r PoweredRail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, r)
+	if !used || !railSupported(pos, tx) {
+		return false
+	}
+	r.Shape = placementRailShape(pos, user, r, tx)
+	r.Powered = railPowered(pos, r, tx)
+
+	place(tx, pos, r, user, ctx)
+	if placed(ctx) {
+		connectRail(pos, r, tx)
+	}
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r PoweredRail) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !railSupported(pos, tx) {
+		breakBlock(r, pos, tx)
+		return
+	}
+	if powered := railPowered(pos, r, tx); powered != r.Powered {
+		r.Powered = powered
+		tx.SetBlock(pos, r, nil)
+		updateAroundRedstone(pos, tx)
+	}
+}
+
+// Ascending returns true if the rail slopes upwards.
+func (r PoweredRail) Ascending() bool {
+	_, ok := r.Shape.Ascending()
+	return ok
+}
+
+// EncodeItem ...
+func (PoweredRail) EncodeItem() (name string, meta int16) {
+	return "minecraft:golden_rail", 0
+}
+
+// EncodeBlock ...
+func (r PoweredRail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:golden_rail", map[string]any{"rail_direction": int32(r.Shape.Uint8()), "rail_data_bit": boolByte(r.Powered)}
+}
+
+// railShape ...
+func (r PoweredRail) railShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r PoweredRail) withRailShape(s RailShape) world.Block {
+	r.Shape = s
+	return r
+}
+
+// allPoweredRails ...
+func allPoweredRails() (rails []world.Block) {
+	for _, s := range RailShapes()[:6] {
+		rails = append(rails, PoweredRail{Shape: s}, PoweredRail{Shape: s, Powered: true})
+	}
+	return
+}
+
+// railPowered checks if the rail passed at the position passed is powered.
+// This is the case if it receives redstone power itself or if one of the up
+// to eight rails of the same type connected to it in a line does.
+func railPowered(pos cube.Pos, r rail, tx *world.Tx) bool {
+	if receivesPower(pos, tx) {
+		return true
+	}
+	base, _ := r.Hash()
+	a, b := r.railShape().Directions()
+	for _, d := range [...]cube.Direction{a, b} {
+		current := pos
+		for i := 0; i < 8; i++ {
+			next, neighbour, ok := railNeighbour(current, d, tx)
+			if !ok || !neighbour.railShape().Connects(d.Opposite()) {
+				break
+			}
+			if neighbourBase, _ := neighbour.Hash(); neighbourBase != base {
+				break
+			}
+			if receivesPower(next, tx) {
+				return true
+			}
+			current = next
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// PressurePlate is a non-solid block that provides redstone power while entities are standing on it.
+type PressurePlate struct {
+	empty
+	transparent
+
+	// Type is the type of the pressure plate.
+	Type PressurePlateType
+	// Power is the level of redstone power currently provided by the pressure plate, ranging from 0 to 15.
+	Power int
+}
+
+// BreakInfo ...
+func (p PressurePlate) BreakInfo() BreakInfo {
+	harvestTool, effectiveTool := pickaxeHarvestable, pickaxeEffective
+	if p.Type.Wooden() {
+		harvestTool, effectiveTool = alwaysHarvestable, axeEffective
+	}
+	return newBreakInfo(0.5, harvestTool, effectiveTool, oneOf(PressurePlate{Type: p.Type})).withBreakHandler(redstoneBreakHandler)
+}
+
+// FuelInfo ...
+func (p PressurePlate) FuelInfo() item.FuelInfo {
+	if !p.Type.Wooden() {
+		return item.FuelInfo{}
+	}
+	return newFuelInfo(time.Second * 15)
+}
+
+// UseOnBlock ...
+func (p PressurePlate) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, p)
+	if !used {
+		return false
+	}
+	if !p.supported(pos, tx) {
+		return false
+	}
+	p.Power = 0
+
+	place(tx, pos, p, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (p PressurePlate) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !p.supported(pos, tx) {
+		breakBlock(p, pos, tx)
+	}
+}
+
+// supported checks if the pressure plate at the position passed is placed on top of a block that can support it.
+func (p PressurePlate) supported(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	return tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx)
+}
+
+// EntityInside ...
+func (p PressurePlate) EntityInside(pos cube.Pos, tx *world.Tx, _ world.Entity) {
+	if p.Power == 0 {
+		// Once pressed, the pressure plate checks for entities on top of it using scheduled ticks.
+		p.update(pos, tx)
+	}
+}
+
+// ScheduledTick ...
+func (p PressurePlate) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if p.Power > 0 {
+		p.update(pos, tx)
+	}
+}
+
+// update recalculates the power of the pressure plate depending on the entities on top of it. As long as the
+// pressure plate is pressed, update schedules a block update to check again.
+func (p PressurePlate) update(pos cube.Pos, tx *world.Tx) {
+	power := p.power(pos, tx)
+	if power != p.Power {
+		if p.Power == 0 {
+			tx.PlaySound(pos.Vec3Centre(), sound.PowerOn{})
+		} else if power == 0 {
+			tx.PlaySound(pos.Vec3Centre(), sound.PowerOff{})
+		}
+		p.Power = power
+		tx.SetBlock(pos, p, nil)
+		updateAroundRedstone(pos, tx)
+	}
+	if power > 0 {
+		delay := time.Second
+		if p.Type.Weighted() {
+			// Weighted pressure plates check for entities more frequently.
+			delay = time.Second / 2
+		}
+		tx.ScheduleBlockUpdate(pos, p, delay)
+	}
+}
+
+// power calculates the redstone power that the pressure plate at the position passed provides, depending on the
+// entities on top of it.
+func (p PressurePlate) power(pos cube.Pos, tx *world.Tx) int {
+	box := cube.Box(0.0625, 0, 0.0625, 0.9375, 0.25, 0.9375).Translate(pos.Vec3())
+
+	count := 0
+	for e := range tx.EntitiesWithin(box.Grow(2)) {
+		if _, living := e.(livingEntity); !living && !p.Type.Wooden() && !p.Type.Weighted() {
+			// Stone and polished blackstone pressure plates are only pressed by players and mobs.
+			continue
+		}
+		if e.H().Type().BBox(e).Translate(e.Position()).IntersectsWith(box) {
+			count++
+		}
+	}
+	switch p.Type {
+	case LightWeightedPressurePlate():
+		return min(count, 15)
+	case HeavyWeightedPressurePlate():
+		return min((count+9)/10, 15)
+	}
+	if count > 0 {
+		return 15
+	}
+	return 0
+}
+
+// RedstoneSource ...
+func (PressurePlate) RedstoneSource() bool {
+	return true
+}
+
+// WeakPower ...
+func (p PressurePlate) WeakPower(cube.Pos, cube.Face, *world.Tx, bool) int {
+	return p.Power
+}
+
+// StrongPower ...
+func (p PressurePlate) StrongPower(_ cube.Pos, face cube.Face, _ *world.Tx, _ bool) int {
+	if face == cube.FaceDown {
+		return p.Power
+	}
+	return 0
+}
+
+// EncodeItem ...
+func (p PressurePlate) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + p.Type.String() + "_pressure_plate", 0
+}
+
+// EncodeBlock ...
+func (p PressurePlate) EncodeBlock() (string, map[string]any) {
+	return "minecraft:" + p.Type.String() + "_pressure_plate", map[string]any{"redstone_signal": int32(p.Power)}
+}
+
+// allPressurePlates ...
+func allPressurePlates() (plates []world.Block) {
+	for _, t := range PressurePlateTypes() {
+		for i := 0; i <= 15; i++ {
+			plates = append(plates, PressurePlate{Type: t, Power: i})
+		}
+	}
+	return
+}
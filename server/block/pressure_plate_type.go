@@ -0,0 +1,104 @@
+package block
+
+import "strings"
+
+// PressurePlateType represents a type of pressure plate.
+type PressurePlateType struct {
+	pressurePlate
+
+	// Wood is the type of wood of the pressure plate. This field is only used for wooden pressure plates.
+	Wood WoodType
+}
+
+type pressurePlate uint8
+
+// StonePressurePlate is a pressure plate made of stone. It is only pressed by players and mobs.
+func StonePressurePlate() PressurePlateType {
+	return PressurePlateType{pressurePlate: 0}
+}
+
+// PolishedBlackstonePressurePlate is a pressure plate made of polished blackstone. It is only pressed by
+// players and mobs.
+func PolishedBlackstonePressurePlate() PressurePlateType {
+	return PressurePlateType{pressurePlate: 1}
+}
+
+// WoodenPressurePlate returns a wooden pressure plate made of the wood type passed. It is pressed by all
+// entities.
+func WoodenPressurePlate(w WoodType) PressurePlateType {
+	return PressurePlateType{pressurePlate: 2, Wood: w}
+}
+
+// LightWeightedPressurePlate is a pressure plate made of gold. The power it provides increases with the number
+// of entities on top of it, up to 15 entities.
+func LightWeightedPressurePlate() PressurePlateType {
+	return PressurePlateType{pressurePlate: 3}
+}
+
+// HeavyWeightedPressurePlate is a pressure plate made of iron. The power it provides increases by one for every
+// 10 entities on top of it.
+func HeavyWeightedPressurePlate() PressurePlateType {
+	return PressurePlateType{pressurePlate: 4}
+}
+
+// Uint8 returns the pressure plate type as a uint8.
+func (p PressurePlateType) Uint8() uint8 {
+	return p.Wood.Uint8()<<3 | uint8(p.pressurePlate)
+}
+
+// Name ...
+func (p PressurePlateType) Name() string {
+	switch p.pressurePlate {
+	case 0:
+		return "Stone Pressure Plate"
+	case 1:
+		return "Polished Blackstone Pressure Plate"
+	case 2:
+		return strings.TrimSuffix(p.Wood.Name(), " Wood") + " Pressure Plate"
+	case 3:
+		return "Light Weighted Pressure Plate"
+	case 4:
+		return "Heavy Weighted Pressure Plate"
+	}
+	panic("unknown pressure plate type")
+}
+
+// String ...
+func (p PressurePlateType) String() string {
+	switch p.pressurePlate {
+	case 0:
+		return "stone"
+	case 1:
+		return "polished_blackstone"
+	case 2:
+		if p.Wood == OakWood() {
+			return "wooden"
+		}
+		return p.Wood.String()
+	case 3:
+		return "light_weighted"
+	case 4:
+		return "heavy_weighted"
+	}
+	panic("unknown pressure plate type")
+}
+
+// Wooden checks if the pressure plate is made of wood.
+func (p PressurePlateType) Wooden() bool {
+	return p.pressurePlate == 2
+}
+
+// Weighted checks if the pressure plate is a weighted pressure plate, of which the power depends on the number of
+// entities on top of it.
+func (p PressurePlateType) Weighted() bool {
+	return p.pressurePlate > 2
+}
+
+// PressurePlateTypes returns all pressure plate types.
+func PressurePlateTypes() []PressurePlateType {
+	types := []PressurePlateType{StonePressurePlate(), PolishedBlackstonePressurePlate(), LightWeightedPressurePlate(), HeavyWeightedPressurePlate()}
+	for _, w := range WoodTypes() {
+		types = append(types, WoodenPressurePlate(w))
+	}
+	return types
+}
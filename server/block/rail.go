@@ -0,0 +1,230 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Rail is a non-solid block that minecarts move along. A rail connects to the
+// rails next to it when placed, forming straight lines, slopes and curves.
+type Rail struct {
+	empty
+	transparent
+
+	// Shape is the shape of the rail, which specifies the directions that it
+	// connects to.
+	Shape RailShape
+}
+
+// BreakInfo ...
+func (r Rail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(Rail{}))
+}
+
+// UseOnBlock ...
+func (r Rail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, r)
+	if !used || !railSupported(pos, tx) {
+		return false
+	}
+	r.Shape = placementRailShape(pos, user, r, tx)
+
+	place(tx, pos, r, user, ctx)
+	if placed(ctx) {
+		connectRail(pos, r, tx)
+	}
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r Rail) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !railSupported(pos, tx) {
+		breakBlock(r, pos, tx)
+	}
+}
+
+// Ascending returns true if the rail slopes upwards.
+func (r Rail) Ascending() bool {
+	_, ok := r.Shape.Ascending()
+	return ok
+}
+
+// EncodeItem ...
+func (Rail) EncodeItem() (name string, meta int16) {
+	return "minecraft:rail", 0
+}
+
+// EncodeBlock ...
+func (r Rail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:rail", map[string]any{"rail_direction": int32(r.Shape.Uint8())}
+}
+
+// railShape ...
+func (r Rail) railShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r Rail) withRailShape(s RailShape) world.Block {
+	r.Shape = s
+	return r
+}
+
+// allRails ...
+func allRails() (rails []world.Block) {
+	for _, s := range RailShapes() {
+		rails = append(rails, Rail{Shape: s})
+	}
+	return
+}
+
+// rail is implemented by all rail blocks. Rails other than Rail cannot curve
+// and only have the first six RailShapes.
+type rail interface {
+	world.Block
+	railShape() RailShape
+	withRailShape(s RailShape) world.Block
+}
+
+// railCurves checks if the rail passed is able to form curves.
+func railCurves(r rail) bool {
+	_, ok := r.(Rail)
+	return ok
+}
+
+// railSupported checks if a rail at the position passed is placed on top of a
+// block that is able to support it.
+func railSupported(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	return tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx)
+}
+
+// railNeighbour returns the rail next to the position passed in the direction
+// passed. Rails on the same level are returned first, followed by rails one
+// block higher and rails one block lower.
+func railNeighbour(pos cube.Pos, d cube.Direction, tx *world.Tx) (cube.Pos, rail, bool) {
+	side := pos.Side(d.Face())
+	for _, p := range [...]cube.Pos{side, side.Side(cube.FaceUp), side.Side(cube.FaceDown)} {
+		if r, ok := tx.Block(p).(rail); ok {
+			return p, r, true
+		}
+	}
+	return cube.Pos{}, nil, false
+}
+
+// railConnections returns the number of rails that the rail at the position
+// passed is currently connected to.
+func railConnections(pos cube.Pos, r rail, tx *world.Tx) (n int) {
+	a, b := r.railShape().Directions()
+	for _, d := range [...]cube.Direction{a, b} {
+		if _, neighbour, ok := railNeighbour(pos, d, tx); ok && neighbour.railShape().Connects(d.Opposite()) {
+			n++
+		}
+	}
+	return n
+}
+
+// railConnectable checks if the rail at the position passed may connect to a
+// rail next to it in the direction passed. This is the case if the other rail
+// already connects to the position or if it is connected to fewer than two
+// rails.
+func railConnectable(pos cube.Pos, d cube.Direction, tx *world.Tx) bool {
+	neighbourPos, neighbour, ok := railNeighbour(pos, d, tx)
+	if !ok {
+		return false
+	}
+	return neighbour.railShape().Connects(d.Opposite()) || railConnections(neighbourPos, neighbour, tx) < 2
+}
+
+// railAbove checks if a rail is present one block above the block next to the
+// position passed in the direction passed. A rail at the position then
+// ascends towards that direction.
+func railAbove(pos cube.Pos, d cube.Direction, tx *world.Tx) bool {
+	_, ok := tx.Block(pos.Side(d.Face()).Side(cube.FaceUp)).(rail)
+	return ok
+}
+
+// placementRailShape returns the shape of the rail passed placed at the
+// position passed by a user. The rail is aligned with the direction the user
+// is facing, unless it is able to connect to other rails.
+func placementRailShape(pos cube.Pos, user item.User, r rail, tx *world.Tx) RailShape {
+	d := user.Rotation().Direction()
+	return calculateRailShape(pos, railShapeFrom(d, d.Opposite(), false), railCurves(r), tx)
+}
+
+// calculateRailShape calculates the shape of a rail at the position passed
+// based on the rails around it. The current shape is returned if no rails
+// are around that it could connect to.
+func calculateRailShape(pos cube.Pos, current RailShape, curves bool, tx *world.Tx) RailShape {
+	var connectable []cube.Direction
+	// South and east are checked first so that a rail with neighbours on all
+	// sides curves towards the south-east, like in vanilla.
+	for _, d := range [...]cube.Direction{cube.South, cube.East, cube.North, cube.West} {
+		if railConnectable(pos, d, tx) {
+			connectable = append(connectable, d)
+		}
+	}
+	if len(connectable) == 0 {
+		return current
+	}
+	has := func(d cube.Direction) bool {
+		for _, c := range connectable {
+			if c == d {
+				return true
+			}
+		}
+		return false
+	}
+	straight := func(d cube.Direction) RailShape {
+		if railAbove(pos, d, tx) {
+			return railShapeFrom(d, d.Opposite(), true)
+		} else if railAbove(pos, d.Opposite(), tx) {
+			return railShapeFrom(d.Opposite(), d, true)
+		}
+		return railShapeFrom(d, d.Opposite(), false)
+	}
+	for _, d := range connectable {
+		if has(d.Opposite()) {
+			return straight(d)
+		}
+	}
+	if curves && len(connectable) > 1 {
+		return railShapeFrom(connectable[0], connectable[1], false)
+	}
+	return straight(connectable[0])
+}
+
+// connectRail makes the rails that the rail at the position passed connects
+// to connect back to it, provided they are not yet connected to two other
+// rails.
+func connectRail(pos cube.Pos, r rail, tx *world.Tx) {
+	a, b := r.railShape().Directions()
+	for _, d := range [...]cube.Direction{a, b} {
+		neighbourPos, neighbour, ok := railNeighbour(pos, d, tx)
+		if !ok {
+			continue
+		}
+		connected := neighbour.railShape().Connects(d.Opposite())
+		if connected && (neighbourPos[1] >= pos[1] || railAscendsTowards(neighbour, d.Opposite())) {
+			// The neighbour is already connected to the rail and, if it is
+			// lower, ascends towards it.
+			continue
+		}
+		if !connected && railConnections(neighbourPos, neighbour, tx) >= 2 {
+			continue
+		}
+		shape := calculateRailShape(neighbourPos, neighbour.railShape(), railCurves(neighbour), tx)
+		if shape != neighbour.railShape() {
+			tx.SetBlock(neighbourPos, neighbour.withRailShape(shape), nil)
+		}
+	}
+}
+
+// railAscendsTowards checks if the rail passed ascends towards the direction
+// passed.
+func railAscendsTowards(r rail, d cube.Direction) bool {
+	up, ok := r.railShape().Ascending()
+	return ok && up == d
+}
@@ -0,0 +1,180 @@
+package block
+
+import "github.com/df-mc/dragonfly/server/block/cube"
+
+// RailShape represents the shape of a rail, which determines the directions
+// that the rail connects to.
+type RailShape struct {
+	railShape
+}
+
+// RailShapeNorthSouth is a straight rail running from north to south.
+func RailShapeNorthSouth() RailShape {
+	return RailShape{0}
+}
+
+// RailShapeEastWest is a straight rail running from east to west.
+func RailShapeEastWest() RailShape {
+	return RailShape{1}
+}
+
+// RailShapeAscendingEast is a straight rail running from west to east that
+// ascends towards the east.
+func RailShapeAscendingEast() RailShape {
+	return RailShape{2}
+}
+
+// RailShapeAscendingWest is a straight rail running from east to west that
+// ascends towards the west.
+func RailShapeAscendingWest() RailShape {
+	return RailShape{3}
+}
+
+// RailShapeAscendingNorth is a straight rail running from south to north that
+// ascends towards the north.
+func RailShapeAscendingNorth() RailShape {
+	return RailShape{4}
+}
+
+// RailShapeAscendingSouth is a straight rail running from north to south that
+// ascends towards the south.
+func RailShapeAscendingSouth() RailShape {
+	return RailShape{5}
+}
+
+// RailShapeSouthEast is a curved rail connecting the south and east.
+func RailShapeSouthEast() RailShape {
+	return RailShape{6}
+}
+
+// RailShapeSouthWest is a curved rail connecting the south and west.
+func RailShapeSouthWest() RailShape {
+	return RailShape{7}
+}
+
+// RailShapeNorthWest is a curved rail connecting the north and west.
+func RailShapeNorthWest() RailShape {
+	return RailShape{8}
+}
+
+// RailShapeNorthEast is a curved rail connecting the north and east.
+func RailShapeNorthEast() RailShape {
+	return RailShape{9}
+}
+
+// RailShapes returns all rail shapes. Only the first six shapes, which are
+// straight, are available to rails that cannot curve.
+func RailShapes() []RailShape {
+	return []RailShape{RailShapeNorthSouth(), RailShapeEastWest(), RailShapeAscendingEast(), RailShapeAscendingWest(), RailShapeAscendingNorth(), RailShapeAscendingSouth(), RailShapeSouthEast(), RailShapeSouthWest(), RailShapeNorthWest(), RailShapeNorthEast()}
+}
+
+type railShape uint8
+
+// Uint8 returns the rail shape as a uint8.
+func (s railShape) Uint8() uint8 {
+	return uint8(s)
+}
+
+// Directions returns the two directions that a rail with the shape connects
+// to.
+func (s railShape) Directions() (cube.Direction, cube.Direction) {
+	switch s {
+	case 0, 4, 5:
+		return cube.North, cube.South
+	case 1, 2, 3:
+		return cube.West, cube.East
+	case 6:
+		return cube.South, cube.East
+	case 7:
+		return cube.South, cube.West
+	case 8:
+		return cube.North, cube.West
+	case 9:
+		return cube.North, cube.East
+	}
+	panic("unknown rail shape")
+}
+
+// Ascending returns the direction in which a rail with the shape ascends. False
+// is returned if the shape is flat.
+func (s railShape) Ascending() (cube.Direction, bool) {
+	switch s {
+	case 2:
+		return cube.East, true
+	case 3:
+		return cube.West, true
+	case 4:
+		return cube.North, true
+	case 5:
+		return cube.South, true
+	}
+	return 0, false
+}
+
+// Curved returns true if the shape is curved.
+func (s railShape) Curved() bool {
+	return s >= 6
+}
+
+// Connects checks if a rail with the shape connects to the direction passed.
+func (s railShape) Connects(d cube.Direction) bool {
+	a, b := s.Directions()
+	return a == d || b == d
+}
+
+// String ...
+func (s railShape) String() string {
+	switch s {
+	case 0:
+		return "north_south"
+	case 1:
+		return "east_west"
+	case 2:
+		return "ascending_east"
+	case 3:
+		return "ascending_west"
+	case 4:
+		return "ascending_north"
+	case 5:
+		return "ascending_south"
+	case 6:
+		return "south_east"
+	case 7:
+		return "south_west"
+	case 8:
+		return "north_west"
+	case 9:
+		return "north_east"
+	}
+	panic("unknown rail shape")
+}
+
+// railShapeFrom returns the RailShape that connects the two directions passed.
+// If up is true, the shape ascends towards the first direction. The
+// directions must not be equal.
+func railShapeFrom(a, b cube.Direction, up bool) RailShape {
+	if a.Opposite() == b {
+		if up {
+			switch a {
+			case cube.East:
+				return RailShapeAscendingEast()
+			case cube.West:
+				return RailShapeAscendingWest()
+			case cube.North:
+				return RailShapeAscendingNorth()
+			default:
+				return RailShapeAscendingSouth()
+			}
+		}
+		if a == cube.North || a == cube.South {
+			return RailShapeNorthSouth()
+		}
+		return RailShapeEastWest()
+	}
+	for _, s := range RailShapes()[6:] {
+		if s.Connects(a) && s.Connects(b) {
+			return s
+		}
+	}
+	panic("unreachable")
+}
@@ -0,0 +1,59 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// updateAroundRedstone updates the blocks around the position passed, as well as the blocks around each of
+// those. It is called when the power emitted by a redstone component changes, so that blocks powered through a
+// solid block next to the component are updated too.
+func updateAroundRedstone(pos cube.Pos, tx *world.Tx) {
+	for _, face := range cube.Faces() {
+		tx.UpdateBlocksAround(pos.Side(face))
+	}
+}
+
+// redstoneBreakHandler is a break handler for redstone components that updates the blocks around the component
+// when it is broken.
+func redstoneBreakHandler(pos cube.Pos, tx *world.Tx, _ item.User) {
+	// The block is only removed after the break handler is called, but neighbour updates are not performed until
+	// the end of the tick, at which point the component will no longer be there.
+	updateAroundRedstone(pos, tx)
+}
+
+// receivesPower checks if the block at the position passed receives any redstone power.
+func receivesPower(pos cube.Pos, tx *world.Tx) bool {
+	return tx.ReceivedRedstonePower(pos) > 0
+}
+
+// toggledByPower checks if a block opened by redstone power, such as a door, should be opened or closed after
+// the block at changedNeighbour changed. Power always opens the block, but the block is only closed if the
+// change may have taken its power away, so that a block opened by hand stays open when an unrelated block is
+// placed next to it.
+func toggledByPower(open, powered bool, changedNeighbour cube.Pos, tx *world.Tx) bool {
+	if powered == open {
+		return false
+	}
+	return powered || redstoneChange(changedNeighbour, tx)
+}
+
+// redstoneChange checks if a change of the block at the position passed may have changed the redstone power
+// received by the blocks next to it. This is the case for redstone components, for blocks conducting the power
+// of a component next to them, and for air, which is left behind when a component is broken.
+func redstoneChange(pos cube.Pos, tx *world.Tx) bool {
+	switch tx.Block(pos).(type) {
+	case world.Conductor, Air:
+		return true
+	}
+	if !tx.ConductsRedstone(pos) {
+		return false
+	}
+	for _, face := range cube.Faces() {
+		if _, ok := tx.Block(pos.Side(face)).(world.Conductor); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// RedstoneBlock is a mineral block crafted using 9 redstone dust. It is a permanent source of redstone power.
+type RedstoneBlock struct {
+	solid
+}
+
+// BreakInfo ...
+func (r RedstoneBlock) BreakInfo() BreakInfo {
+	return newBreakInfo(5, pickaxeHarvestable, pickaxeEffective, oneOf(r)).withBlastResistance(30)
+}
+
+// RedstoneSource ...
+func (RedstoneBlock) RedstoneSource() bool {
+	return true
+}
+
+// WeakPower ...
+func (RedstoneBlock) WeakPower(cube.Pos, cube.Face, *world.Tx, bool) int {
+	return 15
+}
+
+// StrongPower ...
+func (RedstoneBlock) StrongPower(cube.Pos, cube.Face, *world.Tx, bool) int {
+	return 0
+}
+
+// EncodeItem ...
+func (RedstoneBlock) EncodeItem() (name string, meta int16) {
+	return "minecraft:redstone_block", 0
+}
+
+// EncodeBlock ...
+func (RedstoneBlock) EncodeBlock() (string, map[string]any) {
+	return "minecraft:redstone_block", nil
+}
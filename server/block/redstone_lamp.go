@@ -0,0 +1,67 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+	"time"
+)
+
+// RedstoneLamp is a block that emits light when it is powered by redstone.
+type RedstoneLamp struct {
+	solid
+
+	// Lit is whether the lamp is lit and emitting light.
+	Lit bool
+}
+
+// BreakInfo ...
+func (l RedstoneLamp) BreakInfo() BreakInfo {
+	return newBreakInfo(0.3, alwaysHarvestable, nothingEffective, oneOf(RedstoneLamp{}))
+}
+
+// LightEmissionLevel ...
+func (l RedstoneLamp) LightEmissionLevel() uint8 {
+	if l.Lit {
+		return 15
+	}
+	return 0
+}
+
+// NeighbourUpdateTick ...
+func (l RedstoneLamp) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	powered := receivesPower(pos, tx)
+	if powered && !l.Lit {
+		l.Lit = true
+		tx.SetBlock(pos, l, nil)
+	} else if !powered && l.Lit {
+		// Lamps turn off with a slight delay, so that they do not flicker on short interruptions of power.
+		tx.ScheduleBlockUpdate(pos, l, time.Second/5)
+	}
+}
+
+// ScheduledTick ...
+func (l RedstoneLamp) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if l.Lit && !receivesPower(pos, tx) {
+		l.Lit = false
+		tx.SetBlock(pos, l, nil)
+	}
+}
+
+// EncodeItem ...
+func (RedstoneLamp) EncodeItem() (name string, meta int16) {
+	return "minecraft:redstone_lamp", 0
+}
+
+// EncodeBlock ...
+func (l RedstoneLamp) EncodeBlock() (string, map[string]any) {
+	if l.Lit {
+		return "minecraft:lit_redstone_lamp", nil
+	}
+	return "minecraft:redstone_lamp", nil
+}
+
+// allRedstoneLamps ...
+func allRedstoneLamps() []world.Block {
+	return []world.Block{RedstoneLamp{}, RedstoneLamp{Lit: true}}
+}
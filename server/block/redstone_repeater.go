@@ -0,0 +1,166 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// RedstoneRepeater is a block that repeats the redstone power it receives from behind at full strength in front of
+// it, after a configurable delay. A repeater may be locked by another powered repeater facing into its side.
+type RedstoneRepeater struct {
+	transparent
+
+	// Facing is the direction that the repeater outputs power to.
+	Facing cube.Direction
+	// Delay is the delay of the repeater, ranging from 0 to 3. The actual delay of the repeater is one tenth of a
+	// second more than that for every level of the delay, starting at one tenth of a second for a delay of 0.
+	Delay int
+	// Powered is whether the repeater is currently powered and emits power in front of it.
+	Powered bool
+}
+
+// Model ...
+func (RedstoneRepeater) Model() world.BlockModel {
+	return model.Repeater{}
+}
+
+// BreakInfo ...
+func (r RedstoneRepeater) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(RedstoneRepeater{})).withBreakHandler(redstoneBreakHandler)
+}
+
+// HasLiquidDrops ...
+func (RedstoneRepeater) HasLiquidDrops() bool {
+	return true
+}
+
+// UseOnBlock ...
+func (r RedstoneRepeater) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, r)
+	if !used {
+		return false
+	}
+	if !r.supported(pos, tx) {
+		return false
+	}
+	r.Facing = user.Rotation().Direction()
+	r.Delay, r.Powered = 0, false
+
+	place(tx, pos, r, user, ctx)
+	return placed(ctx)
+}
+
+// Activate ...
+func (r RedstoneRepeater) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
+	r.Delay = (r.Delay + 1) % 4
+	tx.SetBlock(pos, r, &world.SetOpts{DisableBlockUpdates: true})
+	return true
+}
+
+// NeighbourUpdateTick ...
+func (r RedstoneRepeater) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !r.supported(pos, tx) {
+		breakBlock(r, pos, tx)
+		return
+	}
+	if !r.locked(pos, tx) && r.inputPowered(pos, tx) != r.Powered {
+		tx.ScheduleBlockUpdate(pos, r, r.delay())
+	}
+}
+
+// ScheduledTick ...
+func (r RedstoneRepeater) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if r.locked(pos, tx) {
+		return
+	}
+	input := r.inputPowered(pos, tx)
+	switch {
+	case r.Powered && !input:
+		r.Powered = false
+	case !r.Powered:
+		r.Powered = true
+		if !input {
+			// The input was only powered for a short time. The repeater still outputs a pulse at least as
+			// long as its delay.
+			tx.ScheduleBlockUpdate(pos, r, r.delay())
+		}
+	default:
+		return
+	}
+	tx.SetBlock(pos, r, nil)
+	updateAroundRedstone(pos, tx)
+}
+
+// delay returns the delay of the repeater as a time.Duration.
+func (r RedstoneRepeater) delay() time.Duration {
+	return time.Duration(r.Delay+1) * time.Second / 10
+}
+
+// supported checks if the repeater at the position passed is placed on top of a block that can support it.
+func (r RedstoneRepeater) supported(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	return tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx)
+}
+
+// inputPowered checks if the block behind the repeater provides it with redstone power.
+func (r RedstoneRepeater) inputPowered(pos cube.Pos, tx *world.Tx) bool {
+	return tx.RedstonePower(pos.Side(r.Facing.Opposite().Face()), r.Facing.Face(), true) > 0
+}
+
+// locked checks if the repeater is locked by a powered repeater facing into one of its sides.
+func (r RedstoneRepeater) locked(pos cube.Pos, tx *world.Tx) bool {
+	for _, d := range []cube.Direction{r.Facing.RotateLeft(), r.Facing.RotateRight()} {
+		if side, ok := tx.Block(pos.Side(d.Face())).(RedstoneRepeater); ok && side.Powered && side.Facing == d.Opposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// RedstoneSource ...
+func (RedstoneRepeater) RedstoneSource() bool {
+	return true
+}
+
+// WeakPower ...
+func (r RedstoneRepeater) WeakPower(_ cube.Pos, face cube.Face, _ *world.Tx, _ bool) int {
+	if r.Powered && face == r.Facing.Face() {
+		return 15
+	}
+	return 0
+}
+
+// StrongPower ...
+func (r RedstoneRepeater) StrongPower(pos cube.Pos, face cube.Face, tx *world.Tx, accountForDust bool) int {
+	return r.WeakPower(pos, face, tx, accountForDust)
+}
+
+// EncodeItem ...
+func (RedstoneRepeater) EncodeItem() (name string, meta int16) {
+	return "minecraft:repeater", 0
+}
+
+// EncodeBlock ...
+func (r RedstoneRepeater) EncodeBlock() (string, map[string]any) {
+	name := "minecraft:unpowered_repeater"
+	if r.Powered {
+		name = "minecraft:powered_repeater"
+	}
+	return name, map[string]any{"minecraft:cardinal_direction": r.Facing.String(), "repeater_delay": int32(r.Delay)}
+}
+
+// allRedstoneRepeaters ...
+func allRedstoneRepeaters() (repeaters []world.Block) {
+	for _, d := range cube.Directions() {
+		for delay := 0; delay < 4; delay++ {
+			repeaters = append(repeaters, RedstoneRepeater{Facing: d, Delay: delay})
+			repeaters = append(repeaters, RedstoneRepeater{Facing: d, Delay: delay, Powered: true})
+		}
+	}
+	return
+}
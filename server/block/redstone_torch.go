@@ -0,0 +1,152 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// RedstoneTorch is a non-solid block that emits redstone power. A redstone torch inverts the power of the block
+// it is attached to: It turns off if that block is powered.
+type RedstoneTorch struct {
+	transparent
+	empty
+
+	// Facing is the direction from the torch to the block.
+	Facing cube.Face
+	// Lit is whether the torch is lit. A lit torch emits redstone power.
+	Lit bool
+}
+
+// BreakInfo ...
+func (t RedstoneTorch) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(RedstoneTorch{})).withBreakHandler(redstoneBreakHandler)
+}
+
+// LightEmissionLevel ...
+func (t RedstoneTorch) LightEmissionLevel() uint8 {
+	if t.Lit {
+		return 7
+	}
+	return 0
+}
+
+// UseOnBlock ...
+func (t RedstoneTorch) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, face, used := firstReplaceable(tx, pos, face, t)
+	if !used {
+		return false
+	}
+	if face == cube.FaceDown {
+		return false
+	}
+	if !tx.Block(pos.Side(face.Opposite())).Model().FaceSolid(pos.Side(face.Opposite()), face, tx) {
+		found := false
+		for _, i := range []cube.Face{cube.FaceSouth, cube.FaceWest, cube.FaceNorth, cube.FaceEast, cube.FaceDown} {
+			if tx.Block(pos.Side(i)).Model().FaceSolid(pos.Side(i), i.Opposite(), tx) {
+				found = true
+				face = i.Opposite()
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	t.Facing = face.Opposite()
+	t.Lit = true
+
+	place(tx, pos, t, user, ctx)
+	if placed(ctx) {
+		updateAroundRedstone(pos, tx)
+	}
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (t RedstoneTorch) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !tx.Block(pos.Side(t.Facing)).Model().FaceSolid(pos.Side(t.Facing), t.Facing.Opposite(), tx) {
+		breakBlock(t, pos, tx)
+		return
+	}
+	if t.Lit == t.inputPowered(pos, tx) {
+		tx.ScheduleBlockUpdate(pos, t, time.Second/10)
+	}
+}
+
+// ScheduledTick ...
+func (t RedstoneTorch) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if t.Lit != t.inputPowered(pos, tx) {
+		return
+	}
+	t.Lit = !t.Lit
+	tx.SetBlock(pos, t, nil)
+	updateAroundRedstone(pos, tx)
+}
+
+// inputPowered checks if the block that the torch is attached to is powered.
+func (t RedstoneTorch) inputPowered(pos cube.Pos, tx *world.Tx) bool {
+	return tx.RedstonePower(pos.Side(t.Facing), t.Facing.Opposite(), true) > 0
+}
+
+// RedstoneSource ...
+func (RedstoneTorch) RedstoneSource() bool {
+	return true
+}
+
+// WeakPower ...
+func (t RedstoneTorch) WeakPower(_ cube.Pos, face cube.Face, _ *world.Tx, _ bool) int {
+	if !t.Lit || face == t.Facing {
+		return 0
+	}
+	return 15
+}
+
+// StrongPower ...
+func (t RedstoneTorch) StrongPower(_ cube.Pos, face cube.Face, _ *world.Tx, _ bool) int {
+	if !t.Lit || face != cube.FaceUp {
+		return 0
+	}
+	return 15
+}
+
+// HasLiquidDrops ...
+func (t RedstoneTorch) HasLiquidDrops() bool {
+	return true
+}
+
+// EncodeItem ...
+func (t RedstoneTorch) EncodeItem() (name string, meta int16) {
+	return "minecraft:redstone_torch", 0
+}
+
+// EncodeBlock ...
+func (t RedstoneTorch) EncodeBlock() (name string, properties map[string]any) {
+	var face string
+	if t.Facing == cube.FaceDown {
+		face = "top"
+	} else if t.Facing == unknownFace {
+		face = "unknown"
+	} else {
+		face = t.Facing.String()
+	}
+	if t.Lit {
+		return "minecraft:redstone_torch", map[string]any{"torch_facing_direction": face}
+	}
+	return "minecraft:unlit_redstone_torch", map[string]any{"torch_facing_direction": face}
+}
+
+// allRedstoneTorches ...
+func allRedstoneTorches() (torch []world.Block) {
+	for _, face := range cube.Faces() {
+		if face == cube.FaceUp {
+			face = unknownFace
+		}
+		torch = append(torch, RedstoneTorch{Facing: face, Lit: true})
+		torch = append(torch, RedstoneTorch{Facing: face})
+	}
+	return
+}
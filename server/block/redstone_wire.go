@@ -0,0 +1,214 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// RedstoneWire is a block placed using redstone dust. It carries redstone power from one place to another,
+// losing one level of power for every block it travels.
+type RedstoneWire struct {
+	empty
+	transparent
+
+	// Power is the level of redstone power carried by the wire, ranging from 0 to 15.
+	Power int
+}
+
+// HasLiquidDrops ...
+func (RedstoneWire) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (r RedstoneWire) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(RedstoneWire{})).withBreakHandler(redstoneBreakHandler)
+}
+
+// UseOnBlock ...
+func (r RedstoneWire) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, r)
+	if !used {
+		return false
+	}
+	if !r.supported(pos, tx) {
+		return false
+	}
+	r.Power = 0
+
+	place(tx, pos, r, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r RedstoneWire) NeighbourUpdateTick(pos, changedNeighbour cube.Pos, tx *world.Tx) {
+	if !r.supported(pos, tx) {
+		breakBlock(r, pos, tx)
+		return
+	}
+	if _, ok := tx.Block(changedNeighbour).(RedstoneWire); ok && changedNeighbour != pos {
+		// Wires always update the power of their entire network at once, so a change of power in a
+		// neighbouring wire is already accounted for.
+		return
+	}
+	updateWireNetwork(pos, tx)
+}
+
+// supported checks if the wire at the position passed is placed on top of a block that can support it.
+func (r RedstoneWire) supported(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	return tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx)
+}
+
+// RedstoneSource ...
+func (RedstoneWire) RedstoneSource() bool {
+	return true
+}
+
+// WeakPower ...
+func (r RedstoneWire) WeakPower(pos cube.Pos, face cube.Face, tx *world.Tx, accountForDust bool) int {
+	if !accountForDust || r.Power == 0 || face == cube.FaceUp {
+		return 0
+	}
+	if face == cube.FaceDown {
+		return r.Power
+	}
+	var connections []cube.Face
+	for _, f := range cube.HorizontalFaces() {
+		if wireConnectsTo(pos, f, tx) {
+			connections = append(connections, f)
+		}
+	}
+	switch len(connections) {
+	case 0:
+		// A wire without any connections powers blocks on all sides.
+		return r.Power
+	case 1:
+		// A wire with a single connection forms a line, powering the block on the opposite side too.
+		if connections[0] == face || connections[0] == face.Opposite() {
+			return r.Power
+		}
+		return 0
+	}
+	for _, f := range connections {
+		if f == face {
+			return r.Power
+		}
+	}
+	return 0
+}
+
+// StrongPower ...
+func (r RedstoneWire) StrongPower(pos cube.Pos, face cube.Face, tx *world.Tx, accountForDust bool) int {
+	return r.WeakPower(pos, face, tx, accountForDust)
+}
+
+// EncodeItem ...
+func (RedstoneWire) EncodeItem() (name string, meta int16) {
+	return "minecraft:redstone", 0
+}
+
+// EncodeBlock ...
+func (r RedstoneWire) EncodeBlock() (string, map[string]any) {
+	return "minecraft:redstone_wire", map[string]any{"redstone_signal": int32(r.Power)}
+}
+
+// allRedstoneWires ...
+func allRedstoneWires() (wires []world.Block) {
+	for i := 0; i <= 15; i++ {
+		wires = append(wires, RedstoneWire{Power: i})
+	}
+	return
+}
+
+// wireConnectsTo checks if the redstone wire at the position passed connects to the block on the horizontal face
+// passed. Wires connect to other wires, including those one block higher or lower, and to sources of power.
+func wireConnectsTo(pos cube.Pos, face cube.Face, tx *world.Tx) bool {
+	side := pos.Side(face)
+	if c, ok := tx.Block(side).(world.Conductor); ok && c.RedstoneSource() {
+		return true
+	}
+	_, ok := wireNeighbour(pos, face, tx)
+	return ok
+}
+
+// wireNeighbour returns the position of the redstone wire that the wire at the position passed connects to on the
+// horizontal face passed. If there is no such wire, false is returned.
+func wireNeighbour(pos cube.Pos, face cube.Face, tx *world.Tx) (cube.Pos, bool) {
+	side := pos.Side(face)
+	if _, ok := tx.Block(side).(RedstoneWire); ok {
+		return side, true
+	}
+	// Wires may go up the side of a block, unless a block above the wire cuts it off.
+	if up := side.Side(cube.FaceUp); !tx.ConductsRedstone(pos.Side(cube.FaceUp)) {
+		if _, ok := tx.Block(up).(RedstoneWire); ok {
+			return up, true
+		}
+	}
+	// Similarly, wires may go down the side of a block, unless the side is blocked.
+	if down := side.Side(cube.FaceDown); !tx.ConductsRedstone(side) {
+		if _, ok := tx.Block(down).(RedstoneWire); ok {
+			return down, true
+		}
+	}
+	return cube.Pos{}, false
+}
+
+// updateWireNetwork recalculates the power of all redstone wires connected to the wire at the position passed.
+// The power of the entire network is calculated at once, so that a change in power reaches all wires within the
+// same tick and the network never powers itself.
+func updateWireNetwork(pos cube.Pos, tx *world.Tx) {
+	connections := map[cube.Pos][]cube.Pos{pos: nil}
+	queue := []cube.Pos{pos}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, face := range cube.HorizontalFaces() {
+			n, ok := wireNeighbour(p, face, tx)
+			if !ok {
+				continue
+			}
+			connections[p] = append(connections[p], n)
+			if _, ok := connections[n]; !ok {
+				connections[n] = nil
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	// Every wire starts with the power it receives from blocks that are not wires. From there, power spreads
+	// through the network, starting at the wires with the highest power.
+	var levels [16][]cube.Pos
+	power := make(map[cube.Pos]int, len(connections))
+	for p := range connections {
+		received := 0
+		for _, face := range cube.Faces() {
+			received = max(received, tx.RedstonePower(p.Side(face), face.Opposite(), false))
+		}
+		power[p] = received
+		levels[received] = append(levels[received], p)
+	}
+	for level := 15; level > 1; level-- {
+		for _, p := range levels[level] {
+			if power[p] != level {
+				continue
+			}
+			for _, n := range connections[p] {
+				if power[n] < level-1 {
+					power[n] = level - 1
+					levels[level-1] = append(levels[level-1], n)
+				}
+			}
+		}
+	}
+
+	for p, level := range power {
+		if w := tx.Block(p).(RedstoneWire); w.Power != level {
+			w.Power = level
+			tx.SetBlock(p, w, &world.SetOpts{DisableBlockUpdates: true})
+			updateAroundRedstone(p, tx)
+		}
+	}
+}
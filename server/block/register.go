@@ -44,6 +44,7 @@ func init() {
 	world.RegisterBlock(Emerald{})
 	world.RegisterBlock(EnchantingTable{})
 	world.RegisterBlock(EndBricks{})
+	world.RegisterBlock(EndPortal{})
 	world.RegisterBlock(EndStone{})
 	world.RegisterBlock(FletchingTable{})
 	world.RegisterBlock(GlassPane{})
@@ -54,6 +55,7 @@ func init() {
 	world.RegisterBlock(Granite{})
 	world.RegisterBlock(Grass{})
 	world.RegisterBlock(Gravel{})
+	world.RegisterBlock(Honey{})
 	world.RegisterBlock(Honeycomb{})
 	world.RegisterBlock(InvisibleBedrock{})
 	world.RegisterBlock(IronBars{})
@@ -62,6 +64,7 @@ func init() {
 	world.RegisterBlock(Lapis{})
 	world.RegisterBlock(Melon{})
 	world.RegisterBlock(MossCarpet{})
+	world.RegisterBlock(MovingBlock{})
 	world.RegisterBlock(MudBricks{})
 	world.RegisterBlock(Mud{})
 	world.RegisterBlock(NetherBrickFence{})
@@ -72,6 +75,8 @@ func init() {
 	world.RegisterBlock(NetherWartBlock{})
 	world.RegisterBlock(Netherite{})
 	world.RegisterBlock(Netherrack{})
+	world.RegisterBlock(Nylium{Warped: true})
+	world.RegisterBlock(Nylium{})
 	world.RegisterBlock(Note{})
 	world.RegisterBlock(Obsidian{Crying: true})
 	world.RegisterBlock(Obsidian{})
@@ -84,6 +89,7 @@ func init() {
 	world.RegisterBlock(RawCopper{})
 	world.RegisterBlock(RawGold{})
 	world.RegisterBlock(RawIron{})
+	world.RegisterBlock(RedstoneBlock{})
 	world.RegisterBlock(ReinforcedDeepslate{})
 	world.RegisterBlock(ResinBricks{Chiseled: true})
 	world.RegisterBlock(ResinBricks{})
@@ -92,6 +98,7 @@ func init() {
 	world.RegisterBlock(Sand{})
 	world.RegisterBlock(SeaLantern{})
 	world.RegisterBlock(Shroomlight{})
+	world.RegisterBlock(Slime{})
 	world.RegisterBlock(SmithingTable{})
 	world.RegisterBlock(Snow{})
 	world.RegisterBlock(SoulSand{})
@@ -121,15 +128,18 @@ func init() {
 		world.RegisterBlock(LapisOre{Type: ore})
 	}
 
+	registerAll(allActivatorRails())
 	registerAll(allAnvils())
 	registerAll(allBanners())
 	registerAll(allBarrels())
 	registerAll(allBasalt())
+	registerAll(allBeds())
 	registerAll(allBeetroot())
 	registerAll(allBlackstone())
 	registerAll(allBlastFurnaces())
 	registerAll(allBoneBlock())
 	registerAll(allBrewingStands())
+	registerAll(allButtons())
 	registerAll(allCactus())
 	registerAll(allCake())
 	registerAll(allCampfires())
@@ -144,9 +154,11 @@ func init() {
 	registerAll(allCoral())
 	registerAll(allCoralBlocks())
 	registerAll(allDeepslate())
+	registerAll(allDetectorRails())
 	registerAll(allDoors())
 	registerAll(allDoubleFlowers())
 	registerAll(allDoubleTallGrass())
+	registerAll(allEndPortalFrames())
 	registerAll(allEndRods())
 	registerAll(allEnderChests())
 	registerAll(allFarmland())
@@ -167,22 +179,33 @@ func init() {
 	registerAll(allLava())
 	registerAll(allLeaves())
 	registerAll(allLecterns())
+	registerAll(allLevers())
 	registerAll(allLight())
 	registerAll(allLitPumpkins())
 	registerAll(allLogs())
 	registerAll(allLooms())
 	registerAll(allMelonStems())
 	registerAll(allMuddyMangroveRoots())
+	registerAll(allNetherPortals())
 	registerAll(allNetherBricks())
 	registerAll(allNetherWart())
 	registerAll(allPinkPetals())
+	registerAll(allPistonArmCollisions())
+	registerAll(allPistons())
 	registerAll(allPlanks())
 	registerAll(allPotato())
+	registerAll(allPoweredRails())
+	registerAll(allPressurePlates())
 	registerAll(allPrismarine())
 	registerAll(allPumpkinStems())
 	registerAll(allPumpkins())
 	registerAll(allPurpurs())
 	registerAll(allQuartz())
+	registerAll(allRails())
+	registerAll(allRedstoneLamps())
+	registerAll(allRedstoneRepeaters())
+	registerAll(allRedstoneTorches())
+	registerAll(allRedstoneWires())
 	registerAll(allSandstones())
 	registerAll(allSeaPickles())
 	registerAll(allSigns())
@@ -212,6 +235,7 @@ func init() {
 }
 
 func init() {
+	world.RegisterItem(ActivatorRail{})
 	world.RegisterItem(Air{})
 	world.RegisterItem(Amethyst{})
 	world.RegisterItem(AncientDebris{})
@@ -249,6 +273,7 @@ func init() {
 	world.RegisterItem(DeepslateBricks{})
 	world.RegisterItem(DeepslateTiles{Cracked: true})
 	world.RegisterItem(DeepslateTiles{})
+	world.RegisterItem(DetectorRail{})
 	world.RegisterItem(Diamond{})
 	world.RegisterItem(Diorite{Polished: true})
 	world.RegisterItem(Diorite{})
@@ -261,6 +286,7 @@ func init() {
 	world.RegisterItem(Emerald{})
 	world.RegisterItem(EnchantingTable{})
 	world.RegisterItem(EndBricks{})
+	world.RegisterItem(EndPortalFrame{})
 	world.RegisterItem(EndRod{})
 	world.RegisterItem(EndStone{})
 	world.RegisterItem(EnderChest{})
@@ -277,6 +303,7 @@ func init() {
 	world.RegisterItem(Gravel{})
 	world.RegisterItem(Grindstone{})
 	world.RegisterItem(HayBale{})
+	world.RegisterItem(Honey{})
 	world.RegisterItem(Honeycomb{})
 	world.RegisterItem(Hopper{})
 	world.RegisterItem(InvisibleBedrock{})
@@ -289,6 +316,7 @@ func init() {
 	world.RegisterItem(Ladder{})
 	world.RegisterItem(Lapis{})
 	world.RegisterItem(Lectern{})
+	world.RegisterItem(Lever{})
 	world.RegisterItem(LitPumpkin{})
 	world.RegisterItem(Loom{})
 	world.RegisterItem(MelonSeeds{})
@@ -306,16 +334,21 @@ func init() {
 	world.RegisterItem(NetherWart{})
 	world.RegisterItem(Netherite{})
 	world.RegisterItem(Netherrack{})
+	world.RegisterItem(Nylium{Warped: true})
+	world.RegisterItem(Nylium{})
 	world.RegisterItem(Note{Pitch: 24})
 	world.RegisterItem(Obsidian{Crying: true})
 	world.RegisterItem(Obsidian{})
 	world.RegisterItem(PackedIce{})
 	world.RegisterItem(PackedMud{})
 	world.RegisterItem(PinkPetals{})
+	world.RegisterItem(Piston{Sticky: true})
+	world.RegisterItem(Piston{})
 	world.RegisterItem(Podzol{})
 	world.RegisterItem(PolishedBlackstoneBrick{Cracked: true})
 	world.RegisterItem(PolishedBlackstoneBrick{})
 	world.RegisterItem(Potato{})
+	world.RegisterItem(PoweredRail{})
 	world.RegisterItem(PumpkinSeeds{})
 	world.RegisterItem(Pumpkin{Carved: true})
 	world.RegisterItem(Pumpkin{})
@@ -325,9 +358,15 @@ func init() {
 	world.RegisterItem(QuartzPillar{})
 	world.RegisterItem(Quartz{Smooth: true})
 	world.RegisterItem(Quartz{})
+	world.RegisterItem(Rail{})
 	world.RegisterItem(RawCopper{})
 	world.RegisterItem(RawGold{})
 	world.RegisterItem(RawIron{})
+	world.RegisterItem(RedstoneBlock{})
+	world.RegisterItem(RedstoneLamp{})
+	world.RegisterItem(RedstoneRepeater{})
+	world.RegisterItem(RedstoneTorch{})
+	world.RegisterItem(RedstoneWire{})
 	world.RegisterItem(ReinforcedDeepslate{})
 	world.RegisterItem(ResinBricks{Chiseled: true})
 	world.RegisterItem(ResinBricks{})
@@ -337,6 +376,7 @@ func init() {
 	world.RegisterItem(SeaLantern{})
 	world.RegisterItem(SeaPickle{})
 	world.RegisterItem(Shroomlight{})
+	world.RegisterItem(Slime{})
 	world.RegisterItem(SmithingTable{})
 	world.RegisterItem(Smoker{})
 	world.RegisterItem(Snow{})
@@ -386,6 +426,7 @@ func init() {
 	}
 	for _, c := range item.Colours() {
 		world.RegisterItem(Banner{Colour: c})
+		world.RegisterItem(Bed{Colour: c})
 		world.RegisterItem(Carpet{Colour: c})
 		world.RegisterItem(ConcretePowder{Colour: c})
 		world.RegisterItem(Concrete{Colour: c})
@@ -427,6 +468,12 @@ func init() {
 	for _, f := range FlowerTypes() {
 		world.RegisterItem(Flower{Type: f})
 	}
+	for _, t := range ButtonTypes() {
+		world.RegisterItem(Button{Type: t})
+	}
+	for _, t := range PressurePlateTypes() {
+		world.RegisterItem(PressurePlate{Type: t})
+	}
 	for _, f := range DoubleFlowerTypes() {
 		world.RegisterItem(DoubleFlower{Type: f})
 	}
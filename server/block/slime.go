@@ -0,0 +1,46 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Slime is a translucent block that is sticky when moved by pistons, moving the blocks next to it along with it.
+// Entities landing on a slime block do not take fall damage.
+type Slime struct {
+	solid
+	transparent
+}
+
+// EntityLand ...
+func (Slime) EntityLand(_ cube.Pos, _ *world.Tx, e world.Entity, distance *float64) {
+	if _, ok := e.(fallDistanceEntity); ok {
+		*distance = 0
+	}
+}
+
+// Friction ...
+func (Slime) Friction() float64 {
+	return 0.8
+}
+
+// PistonSticksTo ...
+func (Slime) PistonSticksTo(b world.Block) bool {
+	_, honey := b.(Honey)
+	return !honey
+}
+
+// BreakInfo ...
+func (s Slime) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(s))
+}
+
+// EncodeItem ...
+func (Slime) EncodeItem() (name string, meta int16) {
+	return "minecraft:slime", 0
+}
+
+// EncodeBlock ...
+func (Slime) EncodeBlock() (string, map[string]any) {
+	return "minecraft:slime", nil
+}
@@ -48,16 +48,25 @@ func (d WoodDoor) Model() world.BlockModel {
 }
 
 // NeighbourUpdateTick ...
-func (d WoodDoor) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+func (d WoodDoor) NeighbourUpdateTick(pos, changedNeighbour cube.Pos, tx *world.Tx) {
 	if d.Top {
 		if _, ok := tx.Block(pos.Side(cube.FaceDown)).(WoodDoor); !ok {
 			breakBlockNoDrops(d, pos, tx)
+			return
 		}
 	} else if solid := tx.Block(pos.Side(cube.FaceDown)).Model().FaceSolid(pos.Side(cube.FaceDown), cube.FaceUp, tx); !solid {
 		breakBlock(d, pos, tx)
+		return
 	} else if _, ok := tx.Block(pos.Side(cube.FaceUp)).(WoodDoor); !ok {
 		breakBlockNoDrops(d, pos, tx)
+		return
 	}
+	d.updatePower(pos, changedNeighbour, tx)
+}
+
+// PistonBreakable ...
+func (WoodDoor) PistonBreakable() bool {
+	return true
 }
 
 // UseOnBlock handles the directional placing of doors
@@ -100,7 +109,13 @@ func (d WoodDoor) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *wor
 
 // Activate ...
 func (d WoodDoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
-	d.Open = !d.Open
+	d.setOpen(pos, tx, !d.Open)
+	return true
+}
+
+// setOpen opens or closes both halves of the door and plays the corresponding sound.
+func (d WoodDoor) setOpen(pos cube.Pos, tx *world.Tx, open bool) {
+	d.Open = open
 	tx.SetBlock(pos, d, nil)
 
 	otherPos := pos.Side(cube.Face(boolByte(!d.Top)))
@@ -111,10 +126,22 @@ func (d WoodDoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User,
 	}
 	if d.Open {
 		tx.PlaySound(pos.Vec3Centre(), sound.DoorOpen{Block: d})
-		return true
+		return
 	}
 	tx.PlaySound(pos.Vec3Centre(), sound.DoorClose{Block: d})
-	return true
+}
+
+// updatePower opens the door if either of its halves is powered by redstone and closes it if neither is after
+// the redstone around it changed. Updates caused by the door itself are ignored, so that a door opened or closed
+// by hand stays that way until the power around it changes.
+func (d WoodDoor) updatePower(pos, changedNeighbour cube.Pos, tx *world.Tx) {
+	otherPos := pos.Side(cube.Face(boolByte(!d.Top)))
+	if changedNeighbour == pos || changedNeighbour == otherPos {
+		return
+	}
+	if powered := receivesPower(pos, tx) || receivesPower(otherPos, tx); toggledByPower(d.Open, powered, changedNeighbour, tx) {
+		d.setOpen(pos, tx, powered)
+	}
 }
 
 // BreakInfo ...
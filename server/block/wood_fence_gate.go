@@ -59,11 +59,19 @@ func (f WoodFenceGate) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx
 }
 
 // NeighbourUpdateTick ...
-func (f WoodFenceGate) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+func (f WoodFenceGate) NeighbourUpdateTick(pos, changedNeighbour cube.Pos, tx *world.Tx) {
 	if f.shouldBeLowered(pos, tx) != f.Lowered {
 		f.Lowered = !f.Lowered
 		tx.SetBlock(pos, f, nil)
 	}
+	if changedNeighbour == pos {
+		// Ignore updates caused by the fence gate itself, so that a fence gate opened or closed by hand stays
+		// that way until the power around it changes.
+		return
+	}
+	if powered := receivesPower(pos, tx); toggledByPower(f.Open, powered, changedNeighbour, tx) {
+		f.setOpen(pos, tx, powered)
+	}
 }
 
 // shouldBeLowered returns if the fence gate should be lowered or not, based on the neighbouring walls.
@@ -76,17 +84,22 @@ func (f WoodFenceGate) shouldBeLowered(pos cube.Pos, tx *world.Tx) bool {
 
 // Activate ...
 func (f WoodFenceGate) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
-	f.Open = !f.Open
-	if f.Open && f.Facing.Opposite() == u.Rotation().Direction() {
+	if !f.Open && f.Facing.Opposite() == u.Rotation().Direction() {
 		f.Facing = f.Facing.Opposite()
 	}
+	f.setOpen(pos, tx, !f.Open)
+	return true
+}
+
+// setOpen opens or closes the fence gate and plays the corresponding sound.
+func (f WoodFenceGate) setOpen(pos cube.Pos, tx *world.Tx, open bool) {
+	f.Open = open
 	tx.SetBlock(pos, f, nil)
 	if f.Open {
 		tx.PlaySound(pos.Vec3Centre(), sound.FenceGateOpen{Block: f})
-		return true
+		return
 	}
 	tx.PlaySound(pos.Vec3Centre(), sound.FenceGateClose{Block: f})
-	return true
 }
 
 // SideClosed ...
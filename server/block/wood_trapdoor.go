@@ -57,14 +57,31 @@ func (t WoodTrapdoor) UseOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Ve
 
 // Activate ...
 func (t WoodTrapdoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
-	t.Open = !t.Open
+	t.setOpen(pos, tx, !t.Open)
+	return true
+}
+
+// NeighbourUpdateTick ...
+func (t WoodTrapdoor) NeighbourUpdateTick(pos, changedNeighbour cube.Pos, tx *world.Tx) {
+	if changedNeighbour == pos {
+		// Ignore updates caused by the trapdoor itself, so that a trapdoor opened or closed by hand stays that
+		// way until the power around it changes.
+		return
+	}
+	if powered := receivesPower(pos, tx); toggledByPower(t.Open, powered, changedNeighbour, tx) {
+		t.setOpen(pos, tx, powered)
+	}
+}
+
+// setOpen opens or closes the trapdoor and plays the corresponding sound.
+func (t WoodTrapdoor) setOpen(pos cube.Pos, tx *world.Tx, open bool) {
+	t.Open = open
 	tx.SetBlock(pos, t, nil)
 	if t.Open {
 		tx.PlaySound(pos.Vec3Centre(), sound.TrapdoorOpen{Block: t})
-		return true
+		return
 	}
 	tx.PlaySound(pos.Vec3Centre(), sound.TrapdoorClose{Block: t})
-	return true
 }
 
 // BreakInfo ...
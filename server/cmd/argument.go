@@ -1,16 +1,17 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
-	"github.com/df-mc/dragonfly/server/internal/sliceutil"
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
-	"math/rand/v2"
+	"math"
 	"reflect"
 	"slices"
-	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Line represents a command line holding command arguments that were passed upon the execution of the
@@ -86,6 +87,27 @@ func (line *Line) Len() int {
 	return len(line.args)
 }
 
+// nextBalanced reads the next value from the Line passed that may contain brackets or braces, such as a target
+// selector, block states or SNBT. Because arguments are separated by spaces, a value with spaces between its brackets
+// spans multiple arguments of the Line. The value and the number of arguments it spans are returned.
+func nextBalanced(line *Line) (string, int, error) {
+	depth := 0
+	for i, arg := range line.args {
+		for _, c := range arg {
+			switch c {
+			case '[', '{':
+				depth++
+			case ']', '}':
+				depth--
+			}
+		}
+		if depth <= 0 {
+			return strings.Join(line.args[:i+1], " "), i + 1, nil
+		}
+	}
+	return "", 0, line.SyntaxError()
+}
+
 // parser manages the parsing of a Line, turning the raw arguments into values which are then stored in the
 // struct fields.
 type parser struct {
@@ -117,8 +139,22 @@ func (p parser) parseArgument(line *Line, v reflect.Value, optional bool, name s
 		err = p.bool(line, v)
 	case mgl64.Vec3:
 		err = p.vec3(line, v)
+	case cube.Pos:
+		err = p.pos(line, v)
+	case cube.Rotation:
+		err = p.rotation(line, v)
+	case time.Duration:
+		err = p.duration(line, v)
 	case Varargs:
 		err = p.varargs(line, v)
+	case JSON:
+		err = p.json(line, v)
+	case BlockState:
+		err = p.blockState(line, v)
+	case ItemStack:
+		err = p.itemStack(line, v)
+	case EntityType:
+		err = p.entityType(line, v, tx)
 	case []Target:
 		err = p.targets(line, v, tx)
 	case SubCommand:
@@ -238,15 +274,224 @@ func (p parser) sub(line *Line, name string) error {
 
 // vec3 ...
 func (p parser) vec3(line *Line, v reflect.Value) error {
-	if err := p.float(line, v.Index(0)); err != nil {
+	vec, err := p.coordinates(line)
+	if err != nil {
 		return err
 	}
-	line.RemoveNext()
-	if err := p.float(line, v.Index(1)); err != nil {
+	v.Set(reflect.ValueOf(vec))
+	return nil
+}
+
+// pos ...
+func (p parser) pos(line *Line, v reflect.Value) error {
+	vec, err := p.coordinates(line)
+	if err != nil {
 		return err
 	}
-	line.RemoveNext()
-	return p.float(line, v.Index(2))
+	v.Set(reflect.ValueOf(cube.PosFromVec3(vec)))
+	return nil
+}
+
+// coordinates parses three coordinates from the Line passed. Coordinates prefixed with ~ are relative to the position
+// of the Source. Coordinates prefixed with ^ are local to the position and rotation of the Source: They move left, up
+// and forwards respectively. Local coordinates cannot be mixed with other coordinates.
+func (p parser) coordinates(line *Line) (mgl64.Vec3, error) {
+	var (
+		vec    mgl64.Vec3
+		local  int
+		origin = line.src.Position()
+	)
+	for i := range 3 {
+		if i > 0 {
+			line.RemoveNext()
+		}
+		arg, ok := line.Next()
+		if !ok {
+			return vec, line.UsageError()
+		}
+		base := origin[i]
+		if after, ok := strings.CutPrefix(arg, "^"); ok {
+			arg, base = "~"+after, 0
+			local++
+		}
+		f, err := relative(arg, base)
+		if err != nil {
+			return vec, err
+		}
+		vec[i] = f
+	}
+	switch local {
+	case 0:
+		return vec, nil
+	case 3:
+		return localCoordinates(origin, sourceRotation(line.src), vec), nil
+	}
+	arg, _ := line.Next()
+	return vec, MessageParameterInvalid.F(arg)
+}
+
+// rotation ...
+func (p parser) rotation(line *Line, v reflect.Value) error {
+	var (
+		rot    cube.Rotation
+		origin = sourceRotation(line.src)
+	)
+	for i := range 2 {
+		if i > 0 {
+			line.RemoveNext()
+		}
+		arg, ok := line.Next()
+		if !ok {
+			return line.UsageError()
+		}
+		f, err := relative(arg, origin[i])
+		if err != nil {
+			return err
+		}
+		rot[i] = f
+	}
+	v.Set(reflect.ValueOf(rot))
+	return nil
+}
+
+// relative parses a number that is relative to base if prefixed with ~. A ~ without number is equal to base.
+func relative(arg string, base float64) (float64, error) {
+	num, rel := strings.CutPrefix(arg, "~")
+	if rel && num == "" {
+		return base, nil
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, MessageNumberInvalid.F(arg)
+	}
+	if rel {
+		f += base
+	}
+	return f, nil
+}
+
+// localCoordinates converts local coordinates, holding the distances to move left, up and forwards, to world
+// coordinates using the origin and rotation passed.
+func localCoordinates(origin mgl64.Vec3, rot cube.Rotation, local mgl64.Vec3) mgl64.Vec3 {
+	yaw, pitch := mgl64.DegToRad(rot.Yaw()+90), mgl64.DegToRad(-rot.Pitch())
+	forwards := mgl64.Vec3{math.Cos(yaw) * math.Cos(pitch), math.Sin(pitch), math.Sin(yaw) * math.Cos(pitch)}
+	up := mgl64.Vec3{math.Cos(yaw) * math.Cos(pitch+math.Pi/2), math.Sin(pitch + math.Pi/2), math.Sin(yaw) * math.Cos(pitch+math.Pi/2)}
+	left := forwards.Cross(up).Mul(-1)
+	return origin.Add(left.Mul(local[0])).Add(up.Mul(local[1])).Add(forwards.Mul(local[2]))
+}
+
+// sourceRotation returns the rotation of the Source passed, if it has one.
+func sourceRotation(src Source) cube.Rotation {
+	if r, ok := src.(interface{ Rotation() cube.Rotation }); ok {
+		return r.Rotation()
+	}
+	return cube.Rotation{}
+}
+
+// duration parses a time.Duration, such as 1m30s. Numbers suffixed with t or d are a number of ticks or in-game days
+// respectively and numbers without a unit are a number of seconds.
+func (p parser) duration(line *Line, v reflect.Value) error {
+	arg, ok := line.Next()
+	if !ok {
+		return line.UsageError()
+	}
+	unit, num := time.Second, arg
+	if n, ok := strings.CutSuffix(arg, "t"); ok {
+		unit, num = time.Second/20, n
+	} else if n, ok := strings.CutSuffix(arg, "d"); ok {
+		unit, num = time.Minute*20, n
+	}
+	d, err := time.ParseDuration(arg)
+	if f, ferr := strconv.ParseFloat(num, 64); ferr == nil {
+		d, err = time.Duration(f*float64(unit)), nil
+	}
+	if err != nil || d < 0 {
+		return MessageParameterInvalid.F(arg)
+	}
+	v.SetInt(int64(d))
+	return nil
+}
+
+// json ...
+func (p parser) json(line *Line, v reflect.Value) error {
+	if line.Len() == 0 {
+		return line.UsageError()
+	}
+	s := strings.Join(line.Leftover(), " ")
+	if !json.Valid([]byte(s)) {
+		return MessageParameterInvalid.F(s)
+	}
+	v.SetBytes([]byte(s))
+	return nil
+}
+
+// blockState parses a block name followed by optional block states. The block states may either directly follow the
+// name, as in stone["stone_type"="granite"], or be passed as the next argument.
+func (p parser) blockState(line *Line, v reflect.Value) error {
+	arg, ok := line.Next()
+	if !ok {
+		return line.UsageError()
+	}
+	name, states := arg, ""
+	if i := strings.IndexByte(arg, '['); i != -1 {
+		s, n, err := nextBalanced(line)
+		if err != nil {
+			return err
+		}
+		name, states = s[:i], s[i:]
+		line.RemoveN(n - 1)
+	} else if line.Len() > 1 && strings.HasPrefix(line.args[1], "[") {
+		line.RemoveNext()
+		s, n, err := nextBalanced(line)
+		if err != nil {
+			return err
+		}
+		states = s
+		line.RemoveN(n - 1)
+	}
+	b, err := parseBlockState(name, states)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(BlockState{Block: b}))
+	return nil
+}
+
+// itemStack parses an item name that may be directly followed by its NBT, as in diamond_sword{Damage:5}.
+func (p parser) itemStack(line *Line, v reflect.Value) error {
+	arg, ok := line.Next()
+	if !ok {
+		return line.UsageError()
+	}
+	name, data := arg, ""
+	if i := strings.IndexByte(arg, '{'); i != -1 {
+		s, n, err := nextBalanced(line)
+		if err != nil {
+			return err
+		}
+		name, data = s[:i], s[i:]
+		line.RemoveN(n - 1)
+	}
+	s, err := parseItemStack(name, data)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(ItemStack{Stack: s}))
+	return nil
+}
+
+// entityType ...
+func (p parser) entityType(line *Line, v reflect.Value, tx *world.Tx) error {
+	arg, ok := line.Next()
+	if !ok {
+		return line.UsageError()
+	}
+	t, ok := tx.World().EntityRegistry().Lookup(namespaced(arg))
+	if !ok {
+		return MessageParameterInvalid.F(arg)
+	}
+	v.Set(reflect.ValueOf(EntityType{EntityType: t}))
+	return nil
 }
 
 // varargs ...
@@ -268,45 +513,33 @@ func (p parser) targets(line *Line, v reflect.Value, tx *world.Tx) error {
 	return nil
 }
 
-// parseTargets parses one or more Targets from the Line passed.
+// parseTargets parses one or more Targets from the Line passed. The argument may either be the name of a player or a
+// target selector, such as @a or @e[type=cow,r=10].
 func (p parser) parseTargets(line *Line, tx *world.Tx) ([]Target, error) {
 	entities, players := targets(tx)
 	first, ok := line.Next()
 	if !ok {
 		return nil, line.UsageError()
 	}
-	switch first[:min(len(first), 2)] {
-	case "@p":
-		pos := line.src.Position()
-		playerDistances := make([]float64, len(players))
-		for i, p := range players {
-			playerDistances[i] = p.Position().Sub(pos).Len()
-		}
-		sort.Slice(players, func(i, j int) bool {
-			return playerDistances[i] < playerDistances[j]
-		})
-		if len(players) == 0 {
-			return nil, nil
-		}
-		return sliceutil.Convert[Target](players[0:1]), nil
-	case "@e":
-		return entities, nil
-	case "@a":
-		return sliceutil.Convert[Target](players), nil
-	case "@s":
-		return []Target{line.src}, nil
-	case "@r":
-		if len(players) == 0 {
-			return nil, nil
-		}
-		return []Target{players[rand.IntN(len(players))]}, nil
-	default:
+	if !isSelector(first) {
 		target, err := p.parsePlayer(first, players)
 		if err != nil {
 			return nil, err
 		}
 		return []Target{target}, nil
 	}
+	s, n, err := nextBalanced(line)
+	if err != nil {
+		return nil, err
+	}
+	sel, err := parseSelector(line, s, tx)
+	if err != nil {
+		return nil, err
+	}
+	// The selector may span multiple arguments. All but the last are consumed here: The last argument is consumed
+	// once parsing succeeds.
+	line.RemoveN(n - 1)
+	return sel.targets(line.src, entities, players), nil
 }
 
 // parsePlayer attempts to find a target whose name matches the name passed.
@@ -16,9 +16,12 @@ import (
 // below) have their values copied but retained.
 // A Runnable may have exported fields only of the following types:
 // int8, int16, int32, int64, int, uint8, uint16, uint32, uint64, uint,
-// float32, float64, string, bool, mgl64.Vec3, Varargs, []Target, cmd.SubCommand, Optional[T] (to make a parameter
-// optional), or a type that implements the cmd.Parameter or cmd.Enum interface. cmd.Enum implementations must be of the
-// type string.
+// float32, float64, string, bool, mgl64.Vec3, cube.Pos, cube.Rotation, time.Duration, Varargs, JSON, BlockState,
+// ItemStack, EntityType, []Target, cmd.SubCommand, Optional[T] (to make a parameter optional), or a type that implements
+// the cmd.Parameter or cmd.Enum interface. cmd.Enum implementations must be of the type string.
+// Coordinates of mgl64.Vec3 and cube.Pos parameters may be relative to the position of the Source (~ ~1 ~) or local
+// to its position and rotation (^ ^ ^5). Similarly, cube.Rotation parameters may be relative to the rotation of the
+// Source.
 // Fields in the Runnable struct may have `cmd:` struct tag to specify the name and suffix of a parameter as such:
 //
 //	type T struct {
@@ -42,6 +45,13 @@ type Allower interface {
 	Allow(src Source) bool
 }
 
+// Permissible may be implemented by a Source that holds permissions. Commands that require a permission, as set
+// using Command.WithPermission, may only be run by a Source that implements Permissible and has the permission.
+type Permissible interface {
+	// HasPermission checks if the Source has the permission passed, such as "dragonfly.command.gamemode".
+	HasPermission(perm string) bool
+}
+
 // Command is a wrapper around a Runnable. It provides additional identity and utility methods for the actual
 // runnable command so that it may be identified more easily.
 type Command struct {
@@ -49,6 +59,7 @@ type Command struct {
 	name        string
 	description string
 	usage       string
+	permission  string
 	aliases     []string
 }
 
@@ -115,6 +126,29 @@ func (cmd Command) Aliases() []string {
 	return cmd.aliases
 }
 
+// WithPermission returns a copy of the Command that requires the permission passed to be run. Sources that do not
+// implement Permissible or that do not have the permission are unable to run the Command, and the Command is not
+// sent to them for auto-completion. An empty permission means the Command may be run by any Source.
+func (cmd Command) WithPermission(perm string) Command {
+	cmd.permission = perm
+	return cmd
+}
+
+// Permission returns the permission required to run the Command, as set using WithPermission. If the Command does not
+// require a permission, Permission returns an empty string.
+func (cmd Command) Permission() string {
+	return cmd.permission
+}
+
+// Permitted checks if the Source passed has the permission required to run the Command.
+func (cmd Command) Permitted(src Source) bool {
+	if cmd.permission == "" {
+		return true
+	}
+	p, ok := src.(Permissible)
+	return ok && p.HasPermission(cmd.permission)
+}
+
 // Execute executes the Command as a source with the args passed. The args are parsed assuming they do not
 // start with the command name. Execute will attempt to parse and execute one Runnable at a time. If one of
 // the Runnable was able to parse args correctly, it will be executed and no more Runnables will be attempted
@@ -129,6 +163,11 @@ func (cmd Command) Execute(args string, source Source, tx *world.Tx) {
 	output := &Output{}
 	defer source.SendCommandOutput(output)
 
+	if !cmd.Permitted(source) {
+		output.Error(MessageUnknown.F(cmd.name))
+		return
+	}
+
 	var leastErroneous error
 	var leastArgsLeft *Line
 
@@ -177,6 +216,9 @@ type ParamInfo struct {
 // they hold: Only the types are guaranteed to be consistent.
 func (cmd Command) Params(src Source) [][]ParamInfo {
 	params := make([][]ParamInfo, 0, len(cmd.v))
+	if !cmd.Permitted(src) {
+		return params
+	}
 	for _, runnable := range cmd.v {
 		elem := reflect.New(runnable.Type()).Elem()
 		elem.Set(runnable)
@@ -204,6 +246,9 @@ func (cmd Command) Params(src Source) [][]ParamInfo {
 // Runnables returns a map of all Runnable implementations of the Command that a Source can execute.
 func (cmd Command) Runnables(src Source) map[int]Runnable {
 	m := make(map[int]Runnable, len(cmd.v))
+	if !cmd.Permitted(src) {
+		return m
+	}
 	for i, runnable := range cmd.v {
 		v := runnable.Interface().(Runnable)
 		if allower, ok := v.(Allower); !ok || allower.Allow(src) {
@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"encoding/json"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 	"reflect"
 	"strings"
+	"time"
 )
 
 // Parameter is an interface for a generic parameters. Users may have types as command parameters that
@@ -48,6 +53,30 @@ type SubCommand struct{}
 // for example, messages and names.
 type Varargs string
 
+// BlockState is an argument type that holds a block parsed from its name and, optionally, its block states, for
+// example stone or stone["stone_type"="granite"]. The minecraft: prefix of the name may be omitted. Block states that
+// are not specified are set to those of the default state of the block.
+type BlockState struct {
+	world.Block
+}
+
+// ItemStack is an argument type that holds an item stack with a count of 1 parsed from the name of the item and,
+// optionally, its NBT written in SNBT, for example diamond_sword or diamond_sword{Damage:5,display:{Name:"Sword"}}.
+// The minecraft: prefix of the name may be omitted.
+type ItemStack struct {
+	item.Stack
+}
+
+// EntityType is an argument type that holds a world.EntityType parsed from its name, such as cow or minecraft:cow.
+// Only entity types registered in the world.EntityRegistry of the World that the command is run in are accepted.
+type EntityType struct {
+	world.EntityType
+}
+
+// JSON is an argument type that captures all arguments that follow and ensures they form valid JSON, for example
+// {"rawtext":[{"text":"Hello"}]}. Like json.RawMessage, it may be decoded using json.Unmarshal.
+type JSON json.RawMessage
+
 // Optional is an argument type that may be used to make any of the available parameter types optional. Optional command
 // parameters may only occur at the end of the Runnable struct. No non-optional parameter is allowed after an optional
 // parameter.
@@ -97,8 +126,20 @@ func typeNameOf(i any, name string) string {
 		return "text"
 	case bool:
 		return "bool"
-	case mgl64.Vec3:
+	case mgl64.Vec3, cube.Pos:
 		return "x y z"
+	case cube.Rotation:
+		return "yaw pitch"
+	case time.Duration:
+		return "duration"
+	case BlockState:
+		return "block"
+	case ItemStack:
+		return "item"
+	case EntityType:
+		return "entity type"
+	case JSON:
+		return "json"
 	case []Target:
 		return "target"
 	case SubCommand:
@@ -0,0 +1,478 @@
+package cmd
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// TaggedTarget is a Target that may carry tags. Tags of a TaggedTarget may be matched using the tag argument of a
+// target selector, such as @e[tag=red].
+type TaggedTarget interface {
+	Target
+	// Tags returns all tags that the Target currently has.
+	Tags() []string
+}
+
+// ScoredTarget is a Target that has scores for one or more objectives. Scores of a ScoredTarget may be matched
+// using the scores argument of a target selector, such as @a[scores={kills=5..}].
+type ScoredTarget interface {
+	Target
+	// Score returns the score of the Target for the objective passed. If the Target has no score for the
+	// objective, false is returned.
+	Score(objective string) (int, bool)
+}
+
+// selector is a target selector, such as @e[type=cow,r=10], that was parsed from a command line. It holds the
+// selector variable and the filters specified in the arguments of the selector.
+type selector struct {
+	// variable is the character following the @ of the selector: p, a, e, s or r.
+	variable byte
+
+	pos    mgl64.Vec3
+	volume *mgl64.Vec3
+
+	distance, pitch, yaw, level bounds
+
+	count    int
+	hasCount bool
+
+	types, names, tags, modes []selectorMatch
+	scores                    []scoreMatch
+}
+
+// selectorMatch is a value of a selector argument that may be negated using a ! prefix, such as type=!cow.
+type selectorMatch struct {
+	value  string
+	negate bool
+}
+
+// scoreMatch is a range of scores that the score of an objective must be in, such as kills=1..5.
+type scoreMatch struct {
+	objective string
+	bounds    bounds
+	negate    bool
+}
+
+// bounds is an inclusive range of values. Either end of the range may be infinite.
+type bounds struct {
+	min, max float64
+}
+
+// unbounded returns bounds that contain all values.
+func unbounded() bounds {
+	return bounds{min: math.Inf(-1), max: math.Inf(1)}
+}
+
+// contains checks if v is within the bounds.
+func (b bounds) contains(v float64) bool {
+	return v >= b.min && v <= b.max
+}
+
+// isSelector checks if the argument passed is a target selector, such as @e or @a[r=5].
+func isSelector(arg string) bool {
+	return len(arg) >= 2 && arg[0] == '@' && strings.IndexByte("paesr", arg[1]) != -1 && (len(arg) == 2 || arg[2] == '[')
+}
+
+// parseSelector parses a target selector such as @e[type=cow,r=10] passed as s. Positions and rotations in the
+// selector are relative to the Source of the Line.
+func parseSelector(line *Line, s string, tx *world.Tx) (selector, error) {
+	sel := selector{
+		variable: s[1],
+		pos:      line.src.Position(),
+		distance: unbounded(), pitch: unbounded(), yaw: unbounded(), level: unbounded(),
+	}
+	if len(s) == 2 {
+		return sel, nil
+	}
+	if !strings.HasSuffix(s, "]") {
+		return sel, line.SyntaxError()
+	}
+	var volume mgl64.Vec3
+	for _, arg := range splitArgs(s[3 : len(s)-1]) {
+		if arg == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return sel, line.SyntaxError()
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		var err error
+		switch key {
+		case "x", "y", "z":
+			i := int(key[0] - 'x')
+			sel.pos[i], err = parseSelectorCoordinate(value, sel.pos[i])
+		case "dx", "dy", "dz":
+			i := int(key[1] - 'x')
+			volume[i], err = parseSelectorFloat(value)
+			sel.volume = &volume
+		case "r":
+			sel.distance.max, err = parseSelectorFloat(value)
+		case "rm":
+			sel.distance.min, err = parseSelectorFloat(value)
+		case "rx":
+			sel.pitch.max, err = parseSelectorFloat(value)
+		case "rxm":
+			sel.pitch.min, err = parseSelectorFloat(value)
+		case "ry":
+			sel.yaw.max, err = parseSelectorFloat(value)
+		case "rym":
+			sel.yaw.min, err = parseSelectorFloat(value)
+		case "l":
+			sel.level.max, err = parseSelectorFloat(value)
+		case "lm":
+			sel.level.min, err = parseSelectorFloat(value)
+		case "c":
+			sel.hasCount = true
+			if sel.count, err = strconv.Atoi(value); err != nil {
+				err = MessageNumberInvalid.F(value)
+			}
+		case "type":
+			m := parseSelectorMatch(value)
+			if !strings.Contains(m.value, ":") {
+				m.value = "minecraft:" + m.value
+			}
+			sel.types = append(sel.types, m)
+		case "name":
+			sel.names = append(sel.names, parseSelectorMatch(value))
+		case "tag":
+			sel.tags = append(sel.tags, parseSelectorMatch(value))
+		case "m":
+			m := parseSelectorMatch(value)
+			if m.value, err = parseSelectorGameMode(m.value, tx); err == nil {
+				sel.modes = append(sel.modes, m)
+			}
+		case "scores":
+			sel.scores, err = parseSelectorScores(line, value)
+		default:
+			return sel, line.SyntaxError()
+		}
+		if err != nil {
+			return sel, err
+		}
+	}
+	return sel, nil
+}
+
+// splitArgs splits a list of arguments, such as those of a target selector or block states, by commas, leaving commas
+// between braces and quotes intact.
+func splitArgs(s string) []string {
+	var (
+		args          []string
+		depth, start  int
+		quoted        bool
+		lastCharacter = len(s) - 1
+	)
+	for i, c := range s {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			args = append(args, s[start:i])
+			start = i + 1
+		}
+		if i == lastCharacter {
+			args = append(args, s[start:])
+		}
+	}
+	return args
+}
+
+// parseSelectorMatch parses a selector argument value that may be negated with a ! prefix and that may be quoted.
+func parseSelectorMatch(value string) selectorMatch {
+	m := selectorMatch{value: value}
+	if strings.HasPrefix(value, "!") {
+		m.value, m.negate = strings.TrimSpace(value[1:]), true
+	}
+	if unquoted, err := strconv.Unquote(m.value); err == nil {
+		m.value = unquoted
+	}
+	return m
+}
+
+// parseSelectorFloat parses a number passed as the value of a selector argument.
+func parseSelectorFloat(value string) (float64, error) {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, MessageNumberInvalid.F(value)
+	}
+	return v, nil
+}
+
+// parseSelectorCoordinate parses a coordinate passed as the value of a selector argument. The coordinate may be
+// relative to the coordinate of the Source if prefixed with ~.
+func parseSelectorCoordinate(value string, relative float64) (float64, error) {
+	if !strings.HasPrefix(value, "~") {
+		return parseSelectorFloat(value)
+	}
+	if value == "~" {
+		return relative, nil
+	}
+	v, err := parseSelectorFloat(value[1:])
+	return relative + v, err
+}
+
+// parseSelectorGameMode parses the value of an m selector argument into the ID of a world.GameMode, returned as
+// a string.
+func parseSelectorGameMode(value string, tx *world.Tx) (string, error) {
+	var mode world.GameMode
+	switch strings.ToLower(value) {
+	case "0", "s", "survival":
+		mode = world.GameModeSurvival
+	case "1", "c", "creative":
+		mode = world.GameModeCreative
+	case "2", "a", "adventure":
+		mode = world.GameModeAdventure
+	case "spectator":
+		mode = world.GameModeSpectator
+	case "5", "d", "default":
+		mode = tx.World().DefaultGameMode()
+	default:
+		return "", MessageParameterInvalid.F(value)
+	}
+	id, _ := world.GameModeID(mode)
+	return strconv.Itoa(id), nil
+}
+
+// parseSelectorScores parses the value of a scores selector argument, such as {kills=1..5,deaths=!0}.
+func parseSelectorScores(line *Line, value string) ([]scoreMatch, error) {
+	if !strings.HasPrefix(value, "{") || !strings.HasSuffix(value, "}") {
+		return nil, line.SyntaxError()
+	}
+	var scores []scoreMatch
+	for _, arg := range splitArgs(value[1 : len(value)-1]) {
+		objective, r, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, line.SyntaxError()
+		}
+		m := parseSelectorMatch(strings.TrimSpace(r))
+		b, err := parseSelectorRange(m.value)
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, scoreMatch{objective: strings.TrimSpace(objective), bounds: b, negate: m.negate})
+	}
+	return scores, nil
+}
+
+// parseSelectorRange parses an inclusive range of integers, such as 5, 1..5, ..5 or 1.. into bounds.
+func parseSelectorRange(value string) (bounds, error) {
+	b := unbounded()
+	minimum, maximum, isRange := strings.Cut(value, "..")
+	if !isRange {
+		maximum = minimum
+	}
+	if minimum != "" {
+		v, err := strconv.Atoi(minimum)
+		if err != nil {
+			return b, MessageNumberInvalid.F(minimum)
+		}
+		b.min = float64(v)
+	}
+	if maximum != "" {
+		v, err := strconv.Atoi(maximum)
+		if err != nil {
+			return b, MessageNumberInvalid.F(maximum)
+		}
+		b.max = float64(v)
+	}
+	return b, nil
+}
+
+// targets returns all Targets out of the entities and players passed that are selected by the selector, sorted and
+// limited as specified by the selector.
+func (sel selector) targets(src Source, entities []Target, players []NamedTarget) []Target {
+	var candidates []Target
+	switch sel.variable {
+	case 'e':
+		candidates = entities
+	case 's':
+		candidates = []Target{src}
+	case 'r':
+		if len(sel.types) > 0 {
+			// Random selectors select players only, unless a type is specified explicitly.
+			candidates = entities
+			break
+		}
+		fallthrough
+	default:
+		candidates = make([]Target, len(players))
+		for i, p := range players {
+			candidates[i] = p
+		}
+	}
+	selected := make([]Target, 0, len(candidates))
+	for _, t := range candidates {
+		if sel.matches(t) {
+			selected = append(selected, t)
+		}
+	}
+
+	count := sel.count
+	switch sel.variable {
+	case 'r':
+		rand.Shuffle(len(selected), func(i, j int) {
+			selected[i], selected[j] = selected[j], selected[i]
+		})
+		if !sel.hasCount {
+			count = 1
+		}
+		return selected[:min(len(selected), max(count, -count))]
+	case 'p':
+		if !sel.hasCount {
+			count = 1
+		}
+	case 's':
+		return selected
+	default:
+		if !sel.hasCount {
+			return selected
+		}
+	}
+	// Selectors with a count select the nearest targets first, or the furthest targets first if the count is
+	// negative.
+	slices.SortStableFunc(selected, func(a, b Target) int {
+		da, db := a.Position().Sub(sel.pos).LenSqr(), b.Position().Sub(sel.pos).LenSqr()
+		if count < 0 {
+			da, db = db, da
+		}
+		if da < db {
+			return -1
+		} else if da > db {
+			return 1
+		}
+		return 0
+	})
+	return selected[:min(len(selected), max(count, -count))]
+}
+
+// matches checks if a Target matches all arguments of the selector.
+func (sel selector) matches(t Target) bool {
+	pos := t.Position()
+	if !sel.distance.contains(pos.Sub(sel.pos).Len()) {
+		return false
+	}
+	if sel.volume != nil {
+		// The volume includes the full blocks at both of its corners.
+		for i, d := range sel.volume {
+			lo, hi := min(sel.pos[i], sel.pos[i]+d), max(sel.pos[i], sel.pos[i]+d)+1
+			if pos[i] < lo || pos[i] > hi {
+				return false
+			}
+		}
+	}
+	if sel.pitch != unbounded() || sel.yaw != unbounded() {
+		r, ok := t.(interface{ Rotation() cube.Rotation })
+		if !ok || !sel.pitch.contains(r.Rotation().Pitch()) || !sel.yaw.contains(wrapYaw(r.Rotation().Yaw())) {
+			return false
+		}
+	}
+	if sel.level != unbounded() {
+		l, ok := t.(interface{ ExperienceLevel() int })
+		if !ok || !sel.level.contains(float64(l.ExperienceLevel())) {
+			return false
+		}
+	}
+	return matchAll(sel.types, targetType(t)) && matchAll(sel.names, targetName(t)) && sel.matchesTags(t) &&
+		matchAll(sel.modes, targetGameMode(t)) && sel.matchesScores(t)
+}
+
+// matchesTags checks if a Target matches all tag arguments of the selector. An empty tag argument matches targets
+// without any tags, and a negated empty tag argument matches targets with at least one tag.
+func (sel selector) matchesTags(t Target) bool {
+	var tags []string
+	if tagged, ok := t.(TaggedTarget); ok {
+		tags = tagged.Tags()
+	}
+	for _, m := range sel.tags {
+		has := slices.Contains(tags, m.value)
+		if m.value == "" {
+			has = len(tags) == 0
+		}
+		if has == m.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesScores checks if a Target matches all scores specified in the scores argument of the selector. A Target
+// without a score for an objective never matches a range for that objective.
+func (sel selector) matchesScores(t Target) bool {
+	if len(sel.scores) == 0 {
+		return true
+	}
+	scored, ok := t.(ScoredTarget)
+	if !ok {
+		return false
+	}
+	for _, m := range sel.scores {
+		score, ok := scored.Score(m.objective)
+		if !ok || m.bounds.contains(float64(score)) == m.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// matchAll checks if value matches all selectorMatches passed. If value is empty, the Target does not have the
+// property matched, so only negated matches succeed.
+func matchAll(matches []selectorMatch, value string) bool {
+	for _, m := range matches {
+		if (value != "" && value == m.value) == m.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// targetType returns the encoded entity type of a Target, such as minecraft:cow, or an empty string if the Target
+// is not an entity.
+func targetType(t Target) string {
+	if e, ok := t.(world.Entity); ok {
+		return e.H().Type().EncodeEntity()
+	}
+	return ""
+}
+
+// targetName returns the name of a Target. For targets without a name, the name tag is returned instead.
+func targetName(t Target) string {
+	if n, ok := t.(NamedTarget); ok {
+		return n.Name()
+	} else if n, ok := t.(interface{ NameTag() string }); ok {
+		return n.NameTag()
+	}
+	return ""
+}
+
+// targetGameMode returns the ID of the world.GameMode of a Target as a string, or an empty string if the Target has
+// no game mode.
+func targetGameMode(t Target) string {
+	if g, ok := t.(interface{ GameMode() world.GameMode }); ok {
+		if id, ok := world.GameModeID(g.GameMode()); ok {
+			return strconv.Itoa(id)
+		}
+	}
+	return ""
+}
+
+// wrapYaw wraps a yaw value so that it is within the range [-180, 180).
+func wrapYaw(yaw float64) float64 {
+	yaw = math.Mod(yaw+180, 360)
+	if yaw < 0 {
+		yaw += 360
+	}
+	return yaw - 180
+}
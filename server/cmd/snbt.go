@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseSNBT parses a compound written in stringified NBT (SNBT), such as {display:{Name:"Sword"},Damage:5}. Values are
+// decoded to the Go types used by the nbt package: Numbers are an int32 or float64 unless suffixed with b (uint8),
+// s (int16), l (int64), f (float32) or d (float64), true and false are a uint8 and lists are a []any.
+func parseSNBT(s string) (map[string]any, error) {
+	d := &snbt{s: s}
+	m, err := d.compound()
+	if err != nil {
+		return nil, err
+	}
+	if d.peek(); d.off != len(d.s) {
+		return nil, fmt.Errorf("unexpected trailing data at offset %v", d.off)
+	}
+	return m, nil
+}
+
+// snbt holds the state of a parser of SNBT.
+type snbt struct {
+	s   string
+	off int
+}
+
+// peek skips whitespace and returns the next character without consuming it. If there are no more characters, 0 is
+// returned.
+func (d *snbt) peek() byte {
+	for d.off < len(d.s) && (d.s[d.off] == ' ' || d.s[d.off] == '\t') {
+		d.off++
+	}
+	if d.off >= len(d.s) {
+		return 0
+	}
+	return d.s[d.off]
+}
+
+// expect consumes the next character if it is equal to c. An error is returned if it is not.
+func (d *snbt) expect(c byte) error {
+	if d.peek() != c {
+		return fmt.Errorf("expected %q at offset %v", c, d.off)
+	}
+	d.off++
+	return nil
+}
+
+// value parses any SNBT value.
+func (d *snbt) value() (any, error) {
+	switch d.peek() {
+	case '{':
+		return d.compound()
+	case '[':
+		return d.list()
+	case '"', '\'':
+		return d.quoted()
+	}
+	return d.literal()
+}
+
+// compound parses a compound such as {a:1,b:"c"}.
+func (d *snbt) compound() (map[string]any, error) {
+	if err := d.expect('{'); err != nil {
+		return nil, err
+	}
+	m := map[string]any{}
+	if d.peek() == '}' {
+		d.off++
+		return m, nil
+	}
+	for {
+		var (
+			key string
+			err error
+		)
+		if c := d.peek(); c == '"' || c == '\'' {
+			key, err = d.quoted()
+		} else {
+			key, err = d.unquoted()
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := d.expect(':'); err != nil {
+			return nil, err
+		}
+		if m[key], err = d.value(); err != nil {
+			return nil, err
+		}
+		if end, err := d.separator('}'); err != nil || end {
+			return m, err
+		}
+	}
+}
+
+// list parses a list such as [1,2,3] or an array such as [I;1,2,3].
+func (d *snbt) list() (any, error) {
+	if err := d.expect('['); err != nil {
+		return nil, err
+	}
+	var array byte
+	if d.off+1 < len(d.s) && d.s[d.off+1] == ';' {
+		array, d.off = d.s[d.off], d.off+2
+	}
+	var list []any
+	if d.peek() == ']' {
+		d.off++
+	} else {
+		for {
+			v, err := d.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+			end, err := d.separator(']')
+			if err != nil {
+				return nil, err
+			} else if end {
+				break
+			}
+		}
+	}
+	switch array {
+	case 'B':
+		return typedArray[uint8](list)
+	case 'I':
+		return typedArray[int32](list)
+	case 'L':
+		return typedArray[int64](list)
+	case 0:
+		return list, nil
+	}
+	return nil, fmt.Errorf("unknown array type %q", array)
+}
+
+// separator consumes either a comma or the end character passed and returns true if it was the end character. An
+// error is returned if the next character is neither.
+func (d *snbt) separator(end byte) (bool, error) {
+	if c := d.peek(); c == ',' || c == end {
+		d.off++
+		return c == end, nil
+	}
+	return false, fmt.Errorf("expected ',' or %q at offset %v", end, d.off)
+}
+
+// quoted parses a string quoted with either single or double quotes. Characters in the string may be escaped using a
+// backslash.
+func (d *snbt) quoted() (string, error) {
+	q := d.s[d.off]
+	d.off++
+
+	var b strings.Builder
+	for d.off < len(d.s) {
+		c := d.s[d.off]
+		d.off++
+		switch {
+		case c == '\\' && d.off < len(d.s):
+			b.WriteByte(d.s[d.off])
+			d.off++
+		case c == q:
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+// unquoted parses an unquoted string, which may consist of letters, digits and the characters _-.+ only.
+func (d *snbt) unquoted() (string, error) {
+	start := d.off
+	for ; d.off < len(d.s); d.off++ {
+		c := d.s[d.off]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("_-.+", c) != -1) {
+			break
+		}
+	}
+	if start == d.off {
+		return "", fmt.Errorf("unexpected character at offset %v", d.off)
+	}
+	return d.s[start:d.off], nil
+}
+
+// literal parses an unquoted value, which is either a number, a boolean or a string.
+func (d *snbt) literal() (any, error) {
+	s, err := d.unquoted()
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(s) {
+	case "true":
+		return uint8(1), nil
+	case "false":
+		return uint8(0), nil
+	}
+	num := s[:len(s)-1]
+	switch s[len(s)-1] {
+	case 'b', 'B':
+		if n, err := strconv.ParseInt(num, 10, 8); err == nil {
+			return uint8(n), nil
+		}
+	case 's', 'S':
+		if n, err := strconv.ParseInt(num, 10, 16); err == nil {
+			return int16(n), nil
+		}
+	case 'l', 'L':
+		if n, err := strconv.ParseInt(num, 10, 64); err == nil {
+			return n, nil
+		}
+	case 'f', 'F':
+		if f, err := strconv.ParseFloat(num, 32); err == nil {
+			return float32(f), nil
+		}
+	case 'd', 'D':
+		if f, err := strconv.ParseFloat(num, 64); err == nil {
+			return f, nil
+		}
+	}
+	if n, err := strconv.ParseInt(s, 10, 32); err == nil {
+		return int32(n), nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && strings.ContainsAny(s, "0123456789") {
+		return f, nil
+	}
+	return s, nil
+}
+
+// typedArray converts the values of a list to an array of the type T. An error is returned if any of the values is
+// not of the type T.
+func typedArray[T any](list []any) ([]T, error) {
+	arr := make([]T, len(list))
+	for i, v := range list {
+		t, ok := v.(T)
+		if !ok {
+			return nil, fmt.Errorf("array value %v is of the wrong type %T", v, v)
+		}
+		arr[i] = t
+	}
+	return arr, nil
+}
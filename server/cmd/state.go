@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"strconv"
+	"strings"
+)
+
+// parseBlockState parses a block with the name passed and the block states, such as ["stone_type"="granite"], in s.
+// States that are not present in s are set to those of the default state of the block.
+func parseBlockState(name, s string) (world.Block, error) {
+	properties, ok := world.BlockProperties(namespaced(name))
+	if !ok {
+		return nil, MessageParameterInvalid.F(name)
+	}
+	if s != "" {
+		if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+			return nil, MessageParameterInvalid.F(s)
+		}
+		for _, state := range splitArgs(s[1 : len(s)-1]) {
+			if state = strings.TrimSpace(state); state == "" {
+				continue
+			}
+			key, value, ok := cutState(state)
+			if !ok {
+				return nil, MessageParameterInvalid.F(state)
+			}
+			v, ok := stateValue(properties[key], value)
+			if !ok {
+				return nil, MessageParameterInvalid.F(state)
+			}
+			properties[key] = v
+		}
+	}
+	b, ok := world.BlockByName(namespaced(name), properties)
+	if !ok {
+		return nil, MessageParameterInvalid.F(name + s)
+	}
+	return b, nil
+}
+
+// cutState cuts a block state such as "stone_type"="granite" into its unquoted key and value. Both = and : are
+// accepted as separator.
+func cutState(state string) (key, value string, ok bool) {
+	end := 0
+	if strings.HasPrefix(state, `"`) {
+		if end = strings.IndexByte(state[1:], '"') + 1; end == 0 {
+			return "", "", false
+		}
+	}
+	i := strings.IndexAny(state[end:], "=:")
+	if i == -1 {
+		return "", "", false
+	}
+	key, value = strings.TrimSpace(state[:end+i]), strings.TrimSpace(state[end+i+1:])
+	return unquote(key), unquote(value), true
+}
+
+// unquote removes the quotes around s if it is quoted.
+func unquote(s string) string {
+	if v, err := strconv.Unquote(s); err == nil {
+		return v
+	}
+	return s
+}
+
+// stateValue converts the value of a block state passed as a string to the type of the current value of the state.
+// False is returned if the block has no such state or if the value could not be converted.
+func stateValue(current any, value string) (any, bool) {
+	switch current.(type) {
+	case string:
+		return value, true
+	case int32:
+		n, err := strconv.ParseInt(value, 10, 32)
+		return int32(n), err == nil
+	case uint8:
+		b, err := strconv.ParseBool(value)
+		if b {
+			return uint8(1), err == nil
+		}
+		return uint8(0), err == nil
+	case bool:
+		b, err := strconv.ParseBool(value)
+		return b, err == nil
+	}
+	return nil, false
+}
+
+// parseItemStack parses an item stack with a count of 1 from the name of the item passed and its NBT in data, written
+// in SNBT. data may be empty if the item has no NBT.
+func parseItemStack(name, data string) (item.Stack, error) {
+	it, ok := world.ItemByName(namespaced(name), 0)
+	if !ok {
+		return item.Stack{}, MessageParameterInvalid.F(name)
+	}
+	if data == "" {
+		return item.NewStack(it, 1), nil
+	}
+	tag, err := parseSNBT(data)
+	if err != nil {
+		return item.Stack{}, MessageParameterInvalid.F(data)
+	}
+	if n, ok := it.(world.NBTer); ok {
+		it = n.DecodeNBT(tag).(world.Item)
+	}
+	s := item.NewStack(it, 1)
+	return nbtconv.Item(tag, &s), nil
+}
+
+// namespaced adds the minecraft: namespace to the name passed if it does not have a namespace yet.
+func namespaced(name string) string {
+	if strings.Contains(name, ":") {
+		return name
+	}
+	return "minecraft:" + name
+}
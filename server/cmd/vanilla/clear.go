@@ -0,0 +1,66 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// clearItems implements the /clear command, which removes items from the inventories of players.
+type clearItems struct {
+	Targets  cmd.Optional[[]cmd.Target]  `cmd:"player"`
+	Item     cmd.Optional[cmd.ItemStack] `cmd:"itemName"`
+	MaxCount cmd.Optional[int]           `cmd:"maxCount"`
+}
+
+// Run ...
+func (c clearItems) Run(src cmd.Source, o *cmd.Output, _ *world.Tx) {
+	targets, ok := targetsOrSelf(src, c.Targets, o)
+	if !ok {
+		return
+	}
+	filter, filtered := c.Item.Load()
+	name := ""
+	if filtered {
+		name, _ = filter.Item().EncodeItem()
+	}
+	matches := func(s item.Stack) bool {
+		if s.Empty() {
+			return false
+		}
+		n, _ := s.Item().EncodeItem()
+		return !filtered || n == name
+	}
+	maxCount := c.MaxCount.LoadOr(-1)
+
+	for _, p := range players(targets, o) {
+		removed := 0
+		for _, inv := range []*inventory.Inventory{p.Inventory(), p.Armour().Inventory()} {
+			for slot, s := range inv.Slots() {
+				if !matches(s) || (maxCount >= 0 && removed >= maxCount) {
+					continue
+				}
+				n := s.Count()
+				if maxCount >= 0 {
+					n = min(n, maxCount-removed)
+				}
+				_ = inv.SetItem(slot, s.Grow(-n))
+				removed += n
+			}
+		}
+		if held, off := p.HeldItems(); matches(off) && (maxCount < 0 || removed < maxCount) {
+			n := off.Count()
+			if maxCount >= 0 {
+				n = min(n, maxCount-removed)
+			}
+			p.SetHeldItems(held, off.Grow(-n))
+			removed += n
+		}
+		if removed == 0 {
+			o.Errort(messageClearFailure, p.Name())
+			continue
+		}
+		o.Printt(messageClearSuccess, p.Name(), removed)
+	}
+}
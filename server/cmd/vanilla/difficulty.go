@@ -0,0 +1,39 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// difficulty implements the /difficulty command, which changes the world.Difficulty of the world.
+type difficulty struct {
+	Difficulty difficultyName `cmd:"difficulty"`
+}
+
+// Run ...
+func (d difficulty) Run(_ cmd.Source, o *cmd.Output, tx *world.Tx) {
+	diff, n := world.Difficulty(world.DifficultyNormal), "Normal"
+	switch d.Difficulty {
+	case "peaceful", "p", "0":
+		diff, n = world.DifficultyPeaceful, "Peaceful"
+	case "easy", "e", "1":
+		diff, n = world.DifficultyEasy, "Easy"
+	case "hard", "h", "3":
+		diff, n = world.DifficultyHard, "Hard"
+	}
+	tx.World().SetDifficulty(diff)
+	o.Printt(messageDifficultySuccess, n)
+}
+
+// difficultyName is an Enum for the names of difficulties.
+type difficultyName string
+
+// Type ...
+func (difficultyName) Type() string {
+	return "Difficulty"
+}
+
+// Options ...
+func (difficultyName) Options(cmd.Source) []string {
+	return []string{"peaceful", "easy", "normal", "hard", "p", "e", "n", "h", "0", "1", "2", "3"}
+}
@@ -0,0 +1,12 @@
+// Package vanilla implements a set of operator commands found in vanilla Minecraft, such as /gamemode, /tp and
+// /give. The commands are built on top of the cmd package and the player and world APIs. None of the commands are
+// registered by default: Register must be called to make them available, or a selection of the commands returned
+// by Commands may be registered using cmd.Register.
+//
+// Every command requires the permission "dragonfly.command.<name>" to be run. Players that are operators have these
+// permissions unless they were denied using player.Player.SetPermission. Other players have none of them, unless they
+// were granted explicitly.
+//
+// Messages sent by the commands use the vanilla translation strings, so that they show up translated in the
+// language of the player that executed the command.
+package vanilla
@@ -0,0 +1,130 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+	"slices"
+	"time"
+)
+
+// effectGive implements /effect <target> <effect>, adding an effect to targets.
+type effectGive struct {
+	Targets       []cmd.Target       `cmd:"player"`
+	Effect        effectName         `cmd:"effect"`
+	Seconds       cmd.Optional[int]  `cmd:"seconds"`
+	Amplifier     cmd.Optional[int]  `cmd:"amplifier"`
+	HideParticles cmd.Optional[bool] `cmd:"hideParticles"`
+}
+
+// effectClear implements /effect <target> clear, removing all effects from targets.
+type effectClear struct {
+	Targets []cmd.Target   `cmd:"player"`
+	Clear   cmd.SubCommand `cmd:"clear"`
+}
+
+// maxEffectSeconds is the longest duration in seconds that an effect may be given for.
+const maxEffectSeconds = 1_000_000
+
+// effectHolder is a Target that can have effects.
+type effectHolder interface {
+	cmd.Target
+	AddEffect(e effect.Effect)
+	RemoveEffect(e effect.Type)
+	Effects() []effect.Effect
+}
+
+// Run ...
+func (e effectGive) Run(_ cmd.Source, o *cmd.Output, _ *world.Tx) {
+	t := effects[e.Effect]
+	seconds, amplifier := e.Seconds.LoadOr(30), e.Amplifier.LoadOr(0)
+	if seconds < 0 || seconds > maxEffectSeconds {
+		o.Errort(cmd.MessageNumberInvalid, seconds)
+		return
+	}
+	if amplifier < 0 || amplifier > 255 {
+		o.Errort(cmd.MessageNumberInvalid, amplifier)
+		return
+	}
+	eff := effect.NewInstant(t, amplifier+1)
+	if lasting, ok := t.(effect.LastingType); ok {
+		eff = effect.New(lasting, amplifier+1, time.Duration(seconds)*time.Second)
+	}
+	if e.HideParticles.LoadOr(false) {
+		eff = eff.WithoutParticles()
+	}
+	for _, target := range e.Targets {
+		if h, ok := target.(effectHolder); ok {
+			h.AddEffect(eff)
+			o.Printt(messageEffectSuccess, string(e.Effect), amplifier, name(target), seconds)
+		}
+	}
+}
+
+// Run ...
+func (e effectClear) Run(_ cmd.Source, o *cmd.Output, _ *world.Tx) {
+	for _, target := range e.Targets {
+		h, ok := target.(effectHolder)
+		if !ok {
+			continue
+		}
+		if len(h.Effects()) == 0 {
+			o.Errort(messageEffectFailure, name(target))
+			continue
+		}
+		for _, eff := range h.Effects() {
+			h.RemoveEffect(eff.Type())
+		}
+		o.Printt(messageEffectRemovedAll, name(target))
+	}
+}
+
+// effectName is an Enum for the names of all effects.
+type effectName string
+
+// Type ...
+func (effectName) Type() string {
+	return "Effect"
+}
+
+// Options ...
+func (effectName) Options(cmd.Source) []string {
+	names := make([]string, 0, len(effects))
+	for n := range effects {
+		names = append(names, string(n))
+	}
+	slices.Sort(names)
+	return names
+}
+
+// effects maps the names of effects to their effect.Type.
+var effects = map[effectName]effect.Type{
+	"speed":           effect.Speed,
+	"slowness":        effect.Slowness,
+	"haste":           effect.Haste,
+	"mining_fatigue":  effect.MiningFatigue,
+	"strength":        effect.Strength,
+	"instant_health":  effect.InstantHealth,
+	"instant_damage":  effect.InstantDamage,
+	"jump_boost":      effect.JumpBoost,
+	"nausea":          effect.Nausea,
+	"regeneration":    effect.Regeneration,
+	"resistance":      effect.Resistance,
+	"fire_resistance": effect.FireResistance,
+	"water_breathing": effect.WaterBreathing,
+	"invisibility":    effect.Invisibility,
+	"blindness":       effect.Blindness,
+	"night_vision":    effect.NightVision,
+	"hunger":          effect.Hunger,
+	"weakness":        effect.Weakness,
+	"poison":          effect.Poison,
+	"wither":          effect.Wither,
+	"health_boost":    effect.HealthBoost,
+	"absorption":      effect.Absorption,
+	"saturation":      effect.Saturation,
+	"levitation":      effect.Levitation,
+	"fatal_poison":    effect.FatalPoison,
+	"conduit_power":   effect.ConduitPower,
+	"slow_falling":    effect.SlowFalling,
+	"darkness":        effect.Darkness,
+}
@@ -0,0 +1,85 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"strings"
+)
+
+// enchant implements the /enchant command, which adds an enchantment to the item held by players.
+type enchant struct {
+	Targets     []cmd.Target      `cmd:"player"`
+	Enchantment enchantmentName   `cmd:"enchantmentName"`
+	Level       cmd.Optional[int] `cmd:"level"`
+}
+
+// Run ...
+func (e enchant) Run(_ cmd.Source, o *cmd.Output, _ *world.Tx) {
+	t, ok := e.Enchantment.enchantment()
+	if !ok {
+		o.Errort(cmd.MessageParameterInvalid, string(e.Enchantment))
+		return
+	}
+	lvl := e.Level.LoadOr(1)
+	if lvl < 1 || lvl > t.MaxLevel() {
+		o.Errort(messageEnchantInvalidLevel, lvl)
+		return
+	}
+	for _, p := range players(e.Targets, o) {
+		held, off := p.HeldItems()
+		if held.Empty() {
+			o.Errort(messageEnchantNoItem)
+			continue
+		}
+		if !t.CompatibleWithItem(held.Item()) {
+			o.Errort(messageEnchantCantEnchant)
+			continue
+		}
+		compatible := true
+		for _, other := range held.Enchantments() {
+			if other.Type() != t && !t.CompatibleWithEnchantment(other.Type()) {
+				o.Errort(messageEnchantCantCombine, enchantmentString(t), enchantmentString(other.Type()))
+				compatible = false
+				break
+			}
+		}
+		if compatible {
+			p.SetHeldItems(held.WithEnchantments(item.NewEnchantment(t, lvl)), off)
+			o.Printt(messageEnchantSuccess, p.Name())
+		}
+	}
+}
+
+// enchantmentName is an Enum for the names of all registered enchantments.
+type enchantmentName string
+
+// Type ...
+func (enchantmentName) Type() string {
+	return "Enchant"
+}
+
+// Options ...
+func (enchantmentName) Options(cmd.Source) []string {
+	all := item.Enchantments()
+	names := make([]string, len(all))
+	for i, t := range all {
+		names[i] = enchantmentString(t)
+	}
+	return names
+}
+
+// enchantment returns the item.EnchantmentType with the name.
+func (e enchantmentName) enchantment() (item.EnchantmentType, bool) {
+	for _, t := range item.Enchantments() {
+		if enchantmentString(t) == string(e) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// enchantmentString returns the name of an item.EnchantmentType as used in commands, such as fire_aspect.
+func enchantmentString(t item.EnchantmentType) string {
+	return strings.ToLower(strings.ReplaceAll(t.Name(), " ", "_"))
+}
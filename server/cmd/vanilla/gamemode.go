@@ -0,0 +1,72 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// gameMode implements the /gamemode command, which changes the world.GameMode of players.
+type gameMode struct {
+	GameMode gameModeName               `cmd:"gameMode"`
+	Targets  cmd.Optional[[]cmd.Target] `cmd:"player"`
+}
+
+// Run ...
+func (g gameMode) Run(src cmd.Source, o *cmd.Output, tx *world.Tx) {
+	targets, ok := targetsOrSelf(src, g.Targets, o)
+	if !ok {
+		return
+	}
+	mode := g.GameMode.mode(tx)
+	for _, p := range players(targets, o) {
+		p.SetGameMode(mode)
+		if cmd.Target(p) == src {
+			o.Printt(messageGameModeSelf, gameModeString(mode))
+			continue
+		}
+		p.Messaget(messageGameModeChanged, gameModeString(mode))
+		o.Printt(messageGameModeOther, gameModeString(mode), p.Name())
+	}
+}
+
+// gameModeName is an Enum for the names of game modes.
+type gameModeName string
+
+// Type ...
+func (gameModeName) Type() string {
+	return "GameMode"
+}
+
+// Options ...
+func (gameModeName) Options(cmd.Source) []string {
+	return []string{"survival", "creative", "adventure", "spectator", "default", "s", "c", "a", "d", "0", "1", "2"}
+}
+
+// mode returns the world.GameMode with the name. For the default game mode, the default game mode of the world
+// of tx is returned.
+func (g gameModeName) mode(tx *world.Tx) world.GameMode {
+	switch g {
+	case "creative", "c", "1":
+		return world.GameModeCreative
+	case "adventure", "a", "2":
+		return world.GameModeAdventure
+	case "spectator":
+		return world.GameModeSpectator
+	case "default", "d":
+		return tx.World().DefaultGameMode()
+	}
+	return world.GameModeSurvival
+}
+
+// gameModeString returns the name of a world.GameMode as shown in command output.
+func gameModeString(mode world.GameMode) string {
+	switch mode {
+	case world.GameModeCreative:
+		return "Creative"
+	case world.GameModeAdventure:
+		return "Adventure"
+	case world.GameModeSpectator:
+		return "Spectator"
+	}
+	return "Survival"
+}
@@ -0,0 +1,45 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+	"strings"
+)
+
+// give implements the /give command, which adds items to the inventory of players.
+type give struct {
+	Targets []cmd.Target      `cmd:"player"`
+	Item    cmd.ItemStack     `cmd:"itemName"`
+	Amount  cmd.Optional[int] `cmd:"amount"`
+	Data    cmd.Optional[int] `cmd:"data"`
+}
+
+// Run ...
+func (g give) Run(_ cmd.Source, o *cmd.Output, tx *world.Tx) {
+	it := g.Item.Item()
+	name, _ := it.EncodeItem()
+	if data, ok := g.Data.Load(); ok {
+		if it, ok = world.ItemByName(name, int16(data)); !ok {
+			o.Errort(messageGiveNotFound, name)
+			return
+		}
+	}
+	amount := g.Amount.LoadOr(1)
+	if amount < 1 || amount > 32767 {
+		o.Errort(cmd.MessageNumberInvalid, amount)
+		return
+	}
+	base := g.Item.WithItem(it)
+	for _, p := range players(g.Targets, o) {
+		for left := amount; left > 0; {
+			stack := base.Grow(min(left, base.MaxCount()) - 1)
+			left -= stack.Count()
+			if n, err := p.Inventory().AddItem(stack); err != nil {
+				// The inventory of the player is full, so drop the leftover items at the player's position instead.
+				opts := world.EntitySpawnOpts{Position: p.Position()}
+				tx.AddEntity(tx.World().EntityRegistry().Config().Item(opts, stack.Grow(-n)))
+			}
+		}
+		o.Printt(messageGiveSuccess, strings.TrimPrefix(name, "minecraft:"), amount, p.Name())
+	}
+}
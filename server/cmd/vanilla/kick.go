@@ -0,0 +1,27 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// kick implements the /kick command, which disconnects players from the server.
+type kick struct {
+	Targets []cmd.Target              `cmd:"name"`
+	Reason  cmd.Optional[cmd.Varargs] `cmd:"reason"`
+}
+
+// Run ...
+func (k kick) Run(_ cmd.Source, o *cmd.Output, _ *world.Tx) {
+	reason, hasReason := k.Reason.Load()
+	for _, p := range players(k.Targets, o) {
+		n := p.Name()
+		if hasReason {
+			p.Disconnect(string(reason))
+			o.Printt(messageKickSuccessReason, n, reason)
+			continue
+		}
+		p.Disconnect()
+		o.Printt(messageKickSuccess, n)
+	}
+}
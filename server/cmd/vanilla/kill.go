@@ -0,0 +1,41 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+	"math"
+)
+
+// kill implements the /kill command, which kills living entities and removes all other entities.
+type kill struct {
+	Targets cmd.Optional[[]cmd.Target] `cmd:"target"`
+}
+
+// Run ...
+func (k kill) Run(src cmd.Source, o *cmd.Output, tx *world.Tx) {
+	targets, ok := targetsOrSelf(src, k.Targets, o)
+	if !ok {
+		return
+	}
+	var killed []cmd.Target
+	for _, t := range targets {
+		switch e := t.(type) {
+		case entity.Living:
+			if _, vulnerable := e.Hurt(math.MaxFloat32, entity.VoidDamageSource{}); !vulnerable {
+				continue
+			}
+		case world.Entity:
+			tx.RemoveEntity(e)
+			_ = e.H().Close()
+		default:
+			continue
+		}
+		killed = append(killed, t)
+	}
+	if len(killed) == 0 {
+		o.Errort(cmd.MessageNoTargets)
+		return
+	}
+	o.Printt(messageKillSuccess, names(killed))
+}
@@ -0,0 +1,25 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+	"slices"
+	"strings"
+)
+
+// list implements the /list command, which lists all players online on the server.
+type list struct {
+	srv *server.Server
+}
+
+// Run ...
+func (l list) Run(_ cmd.Source, o *cmd.Output, tx *world.Tx) {
+	var n []string
+	for p := range l.srv.Players(tx) {
+		n = append(n, p.Name())
+	}
+	slices.Sort(n)
+	o.Printt(messageList, len(n), l.srv.MaxPlayerCount())
+	o.Print(strings.Join(n, ", "))
+}
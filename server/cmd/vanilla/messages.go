@@ -0,0 +1,59 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"golang.org/x/text/language"
+)
+
+// https://github.com/Mojang/bedrock-samples/blob/main/resource_pack/texts/en_US.lang
+
+var messagePlayerNotFound = chat.Translate(str("%commands.generic.player.notFound"), 0, `That player cannot be found`).Enc("<red>%v</red>")
+var messageOutOfWorld = chat.Translate(str("%commands.generic.outOfWorld"), 0, `Cannot access blocks outside of the world`).Enc("<red>%v</red>")
+var messageTooManyTargets = chat.Translate(str("%commands.generic.tooManyTargets"), 0, `Too many target matches`).Enc("<red>%v</red>")
+
+var messageClearSuccess = chat.Translate(str("%commands.clear.success"), 2, `Cleared the inventory of %v, removing %v items`)
+var messageClearFailure = chat.Translate(str("%commands.clear.failure.no.items"), 1, `Could not clear the inventory of %v, no items to remove`).Enc("<red>%v</red>")
+var messageDeopSuccess = chat.Translate(str("%commands.deop.success"), 1, `De-opped: %v`)
+var messageDeopFailed = chat.Translate(str("%commands.deop.failed"), 1, `Could not de-op (permission level too high): %v`).Enc("<red>%v</red>")
+var messageDifficultySuccess = chat.Translate(str("%commands.difficulty.success"), 1, `Set game difficulty to %v`)
+var messageEffectSuccess = chat.Translate(str("%commands.effect.success"), 4, `Gave %v * %v to %v for %v seconds`)
+var messageEffectRemovedAll = chat.Translate(str("%commands.effect.success.removed.all"), 1, `Took all effects from %v`)
+var messageEffectFailure = chat.Translate(str("%commands.effect.failure.notActive.all"), 1, `Couldn't take any effects from %v as they do not have any`).Enc("<red>%v</red>")
+var messageEnchantSuccess = chat.Translate(str("%commands.enchant.success"), 1, `Enchanting succeeded for %v`)
+var messageEnchantNoItem = chat.Translate(str("%commands.enchant.noItem"), 0, `Target doesn't hold an item`).Enc("<red>%v</red>")
+var messageEnchantCantEnchant = chat.Translate(str("%commands.enchant.cantEnchant"), 0, `The selected enchantment can't be added to the target item`).Enc("<red>%v</red>")
+var messageEnchantCantCombine = chat.Translate(str("%commands.enchant.cantCombine"), 2, `%v can't be combined with %v`).Enc("<red>%v</red>")
+var messageEnchantInvalidLevel = chat.Translate(str("%commands.enchant.invalidLevel"), 1, `Level %v is not supported by this enchantment`).Enc("<red>%v</red>")
+var messageGameModeSelf = chat.Translate(str("%commands.gamemode.success.self"), 1, `Set own game mode to %v`)
+var messageGameModeChanged = chat.Translate(str("%gameMode.changed"), 1, `Your game mode has been updated to %v`)
+var messageGameModeOther = chat.Translate(str("%commands.gamemode.success.other"), 2, `Set %[2]v's game mode to %[1]v`)
+var messageGiveSuccess = chat.Translate(str("%commands.give.success"), 3, `Gave %v * %v to %v`)
+var messageGiveNotFound = chat.Translate(str("%commands.give.item.notFound"), 1, `There is no such item with name %v`).Enc("<red>%v</red>")
+var messageKickSuccess = chat.Translate(str("%commands.kick.success"), 1, `Kicked %v from the game`)
+var messageKickSuccessReason = chat.Translate(str("%commands.kick.success.reason"), 2, `Kicked %v from the game: '%v'`)
+var messageKillSuccess = chat.Translate(str("%commands.kill.successful"), 1, `Killed %v`)
+var messageList = chat.Translate(str("%commands.players.list"), 2, `There are %v/%v players online:`)
+var messageOpSuccess = chat.Translate(str("%commands.op.success"), 1, `Opped: %v`)
+var messageOpFailed = chat.Translate(str("%commands.op.failed"), 1, `Could not op (already op or higher): %v`).Enc("<red>%v</red>")
+var messageSetBlockSuccess = chat.Translate(str("%commands.setblock.success"), 0, `Block placed`)
+var messageSetBlockNoChange = chat.Translate(str("%commands.setblock.noChange"), 0, `Unable to place block`).Enc("<red>%v</red>")
+var messageSetBlockOutOfWorld = chat.Translate(str("%commands.setblock.outOfWorld"), 0, `Cannot place block outside of the world`).Enc("<red>%v</red>")
+var messageSetWorldSpawn = chat.Translate(str("%commands.setworldspawn.success"), 3, `Set the world spawn point to (%v, %v, %v)`)
+var messageSpawnPoint = chat.Translate(str("%commands.spawnpoint.success.single"), 4, `Set %v's spawn point to (%v, %v, %v)`)
+var messageSummonSuccess = chat.Translate(str("%commands.summon.success"), 0, `Object successfully summoned`)
+var messageSummonOutOfWorld = chat.Translate(str("%commands.summon.outOfWorld"), 0, `Cannot summon the object out of the world`).Enc("<red>%v</red>")
+var messageSummonFailed = chat.Translate(str("%commands.summon.failed"), 0, `Unable to summon object`).Enc("<red>%v</red>")
+var messageTimeSet = chat.Translate(str("%commands.time.set"), 1, `Set the time to %v`)
+var messageTimeAdded = chat.Translate(str("%commands.time.added"), 1, `Added %v to the time`)
+var messageTimeQuery = chat.Translate(str("%commands.time.query"), 1, `Time is %v`)
+var messageTeleport = chat.Translate(str("%commands.tp.success"), 2, `Teleported %v to %v`)
+var messageTeleportOutOfWorld = chat.Translate(str("%commands.tp.outOfWorld"), 0, `Cannot teleport entities outside of the world`).Enc("<red>%v</red>")
+var messageTeleportCoordinates = chat.Translate(str("%commands.tp.success.coordinates"), 4, `Teleported %v to %v, %v, %v`)
+var messageWeatherClear = chat.Translate(str("%commands.weather.clear"), 0, `Changing to clear weather`)
+var messageWeatherRain = chat.Translate(str("%commands.weather.rain"), 0, `Changing to rainy weather`)
+var messageWeatherThunder = chat.Translate(str("%commands.weather.thunder"), 0, `Changing to rain and thunder`)
+
+type str string
+
+// Resolve returns the translation identifier as a string.
+func (s str) Resolve(language.Tag) string { return string(s) }
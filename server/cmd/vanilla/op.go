@@ -0,0 +1,53 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// op implements the /op command, which adds a player to the operator list of the server. Operators are identified
+// by their XUID, so the player must be online.
+type op struct {
+	srv    *server.Server
+	Player cmd.Varargs `cmd:"player"`
+}
+
+// Run ...
+func (c op) Run(_ cmd.Source, o *cmd.Output, tx *world.Tx) {
+	n := string(c.Player)
+	if _, ok := c.srv.Operators().Lookup(n); ok {
+		o.Errort(messageOpFailed, n)
+		return
+	}
+	if _, ok := c.srv.PlayerByName(n); !ok {
+		o.Errort(messagePlayerNotFound)
+		return
+	}
+	if err := c.srv.SetOperator(tx, n, true); err != nil {
+		o.Error(err)
+		return
+	}
+	o.Printt(messageOpSuccess, n)
+}
+
+// deop implements the /deop command, which removes a player from the operator list of the server. The player does
+// not have to be online.
+type deop struct {
+	srv    *server.Server
+	Player cmd.Varargs `cmd:"player"`
+}
+
+// Run ...
+func (c deop) Run(_ cmd.Source, o *cmd.Output, tx *world.Tx) {
+	n := string(c.Player)
+	if _, ok := c.srv.Operators().Lookup(n); !ok {
+		o.Errort(messageDeopFailed, n)
+		return
+	}
+	if err := c.srv.SetOperator(tx, n, false); err != nil {
+		o.Error(err)
+		return
+	}
+	o.Printt(messageDeopSuccess, n)
+}
@@ -0,0 +1,44 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/cmd"
+)
+
+// Register registers all commands implemented in the package using cmd.Register. srv is used by commands that
+// need information about the whole server, such as /list.
+func Register(srv *server.Server) {
+	for _, c := range Commands(srv) {
+		cmd.Register(c)
+	}
+}
+
+// Commands returns all commands implemented in the package without registering them. srv is used by commands that
+// need information about the whole server, such as /list. Each command requires the permission
+// "dragonfly.command.<name>", such as "dragonfly.command.gamemode", which operators have by default.
+func Commands(srv *server.Server) []cmd.Command {
+	commands := []cmd.Command{
+		cmd.New("clear", "Clears items from player inventory.", nil, clearItems{}),
+		cmd.New("deop", "Revokes operator status from a player.", nil, deop{srv: srv}),
+		cmd.New("difficulty", "Sets the difficulty level.", nil, difficulty{}),
+		cmd.New("effect", "Add or remove status effects.", nil, effectClear{}, effectGive{}),
+		cmd.New("enchant", "Adds an enchantment to a player's selected item.", nil, enchant{}),
+		cmd.New("gamemode", "Sets a player's game mode.", nil, gameMode{}),
+		cmd.New("give", "Gives an item to a player.", nil, give{}),
+		cmd.New("kick", "Kicks a player from the server.", nil, kick{}),
+		cmd.New("kill", "Kills entities (players, mobs, etc.).", nil, kill{}),
+		cmd.New("list", "Lists players on the server.", nil, list{srv: srv}),
+		cmd.New("op", "Grants operator status to a player.", nil, op{srv: srv}),
+		cmd.New("setblock", "Changes a block to another block.", nil, setBlock{}),
+		cmd.New("setworldspawn", "Sets the world spawn.", nil, setWorldSpawn{}),
+		cmd.New("spawnpoint", "Sets the spawn point for a player.", nil, spawnPoint{}),
+		cmd.New("summon", "Summons an entity.", nil, summon{}),
+		cmd.New("time", "Changes or queries the world's game time.", nil, timeSet{}, timeSetPreset{}, timeAdd{}, timeQuery{}),
+		cmd.New("tp", "Teleports entities.", []string{"teleport"}, teleportToTarget{}, teleportToPos{}, teleportTargetsToTarget{}, teleportTargetsToPos{}),
+		cmd.New("weather", "Sets the weather.", nil, weather{}),
+	}
+	for i, c := range commands {
+		commands[i] = c.WithPermission("dragonfly.command." + c.Name())
+	}
+	return commands
+}
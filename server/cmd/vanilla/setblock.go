@@ -0,0 +1,47 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// setBlock implements the /setblock command, which changes a block at a position in the world.
+type setBlock struct {
+	Position cube.Pos                   `cmd:"position"`
+	Block    cmd.BlockState             `cmd:"block"`
+	Mode     cmd.Optional[setBlockMode] `cmd:"mode"`
+}
+
+// Run ...
+func (s setBlock) Run(_ cmd.Source, o *cmd.Output, tx *world.Tx) {
+	if s.Position.OutOfBounds(tx.Range()) {
+		o.Errort(messageSetBlockOutOfWorld)
+		return
+	}
+	current := tx.Block(s.Position)
+	if _, air := current.(block.Air); s.Mode.LoadOr("replace") == "keep" && !air {
+		o.Errort(messageSetBlockNoChange)
+		return
+	}
+	if world.BlockRuntimeID(current) == world.BlockRuntimeID(s.Block.Block) {
+		o.Errort(messageSetBlockNoChange)
+		return
+	}
+	tx.SetBlock(s.Position, s.Block.Block, nil)
+	o.Printt(messageSetBlockSuccess)
+}
+
+// setBlockMode is an Enum for the modes that may be passed to /setblock.
+type setBlockMode string
+
+// Type ...
+func (setBlockMode) Type() string {
+	return "SetBlockMode"
+}
+
+// Options ...
+func (setBlockMode) Options(cmd.Source) []string {
+	return []string{"replace", "keep"}
+}
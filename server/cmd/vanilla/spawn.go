@@ -0,0 +1,49 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// setWorldSpawn implements the /setworldspawn command, which changes the spawn position of the world.
+type setWorldSpawn struct {
+	Position cmd.Optional[mgl64.Vec3] `cmd:"spawnPoint"`
+}
+
+// spawnPoint implements the /spawnpoint command, which changes the spawn position of players.
+type spawnPoint struct {
+	Targets  cmd.Optional[[]cmd.Target] `cmd:"player"`
+	Position cmd.Optional[mgl64.Vec3]   `cmd:"spawnPos"`
+}
+
+// Run ...
+func (s setWorldSpawn) Run(src cmd.Source, o *cmd.Output, tx *world.Tx) {
+	vec := s.Position.LoadOr(src.Position())
+	if !withinWorld(vec) {
+		o.Errort(messageOutOfWorld)
+		return
+	}
+	pos := cube.PosFromVec3(vec)
+	tx.World().SetSpawn(pos)
+	o.Printt(messageSetWorldSpawn, pos[0], pos[1], pos[2])
+}
+
+// Run ...
+func (s spawnPoint) Run(src cmd.Source, o *cmd.Output, tx *world.Tx) {
+	targets, ok := targetsOrSelf(src, s.Targets, o)
+	if !ok {
+		return
+	}
+	for _, p := range players(targets, o) {
+		vec := s.Position.LoadOr(p.Position())
+		if !withinWorld(vec) {
+			o.Errort(messageOutOfWorld)
+			return
+		}
+		pos := cube.PosFromVec3(vec)
+		tx.World().SetPlayerSpawn(p.UUID(), pos)
+		o.Printt(messageSpawnPoint, p.Name(), pos[0], pos[1], pos[2])
+	}
+}
@@ -0,0 +1,64 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"maps"
+	"slices"
+	"time"
+)
+
+// summon implements the /summon command, which spawns an entity.
+type summon struct {
+	EntityType entityType               `cmd:"entityType"`
+	Position   cmd.Optional[mgl64.Vec3] `cmd:"spawnPos"`
+}
+
+// Run ...
+func (s summon) Run(src cmd.Source, o *cmd.Output, tx *world.Tx) {
+	f, ok := summonable[s.EntityType]
+	if !ok {
+		o.Errort(messageSummonFailed)
+		return
+	}
+	pos := s.Position.LoadOr(src.Position())
+	if !withinWorld(pos) {
+		o.Errort(messageSummonOutOfWorld)
+		return
+	}
+	tx.AddEntity(f(world.EntitySpawnOpts{Position: pos}))
+	o.Printt(messageSummonSuccess)
+}
+
+// entityType is an Enum for the names of all entities that may be summoned.
+type entityType string
+
+// Type ...
+func (entityType) Type() string {
+	return "SummonableEntity"
+}
+
+// Options ...
+func (entityType) Options(cmd.Source) []string {
+	names := make([]string, 0, len(summonable))
+	for _, n := range slices.Sorted(maps.Keys(summonable)) {
+		names = append(names, string(n))
+	}
+	return names
+}
+
+// summonable maps the names of all entities that may be summoned to a function creating the entity.
+var summonable = map[entityType]func(opts world.EntitySpawnOpts) *world.EntityHandle{
+	"cow":            entity.NewCow,
+	"pig":            entity.NewPig,
+	"zombie":         entity.NewZombie,
+	"lightning_bolt": entity.NewLightning,
+	"tnt": func(opts world.EntitySpawnOpts) *world.EntityHandle {
+		return entity.NewTNT(opts, time.Second*4)
+	},
+	"xp_orb": func(opts world.EntitySpawnOpts) *world.EntityHandle {
+		return entity.NewExperienceOrb(opts, 1)
+	},
+}
@@ -0,0 +1,87 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"strings"
+)
+
+// targetsOrSelf returns the targets passed if they were specified. Otherwise, the Source is returned as the only
+// target if it is a player. If neither is the case, cmd.MessageNoTargets is added to the output and false is
+// returned.
+func targetsOrSelf(src cmd.Source, targets cmd.Optional[[]cmd.Target], o *cmd.Output) ([]cmd.Target, bool) {
+	if t, ok := targets.Load(); ok {
+		return t, true
+	}
+	if _, ok := src.(*player.Player); ok {
+		return []cmd.Target{src}, true
+	}
+	o.Errort(cmd.MessageNoTargets)
+	return nil, false
+}
+
+// singleTarget returns the only Target out of the targets passed. If more than one target was selected, an error
+// is added to the output and false is returned.
+func singleTarget(targets []cmd.Target, o *cmd.Output) (cmd.Target, bool) {
+	if len(targets) != 1 {
+		o.Errort(messageTooManyTargets)
+		return nil, false
+	}
+	return targets[0], true
+}
+
+// players returns all players out of the targets passed. If none of the targets is a player, cmd.MessageNoTargets
+// is added to the output.
+func players(targets []cmd.Target, o *cmd.Output) []*player.Player {
+	pl := make([]*player.Player, 0, len(targets))
+	for _, t := range targets {
+		if p, ok := t.(*player.Player); ok {
+			pl = append(pl, p)
+		}
+	}
+	if len(pl) == 0 {
+		o.Errort(cmd.MessageNoTargets)
+	}
+	return pl
+}
+
+// name returns a name for the Target passed to use in command output. For targets without a name, the entity type
+// is returned.
+func name(t cmd.Target) string {
+	if n, ok := t.(cmd.NamedTarget); ok {
+		return n.Name()
+	}
+	if n, ok := t.(interface{ NameTag() string }); ok && n.NameTag() != "" {
+		return n.NameTag()
+	}
+	if e, ok := t.(world.Entity); ok {
+		return strings.TrimPrefix(e.H().Type().EncodeEntity(), "minecraft:")
+	}
+	return "Server"
+}
+
+// names returns the names of all targets passed, separated by commas.
+func names[T cmd.Target](targets []T) string {
+	n := make([]string, len(targets))
+	for i, t := range targets {
+		n[i] = name(t)
+	}
+	return strings.Join(n, ", ")
+}
+
+// maxCoordinate is the highest absolute coordinate that entities may be teleported or summoned to, equal to the
+// size of the world border in vanilla.
+const maxCoordinate = 30_000_000
+
+// withinWorld checks if the position passed is finite and within maxCoordinate on every axis.
+func withinWorld(pos mgl64.Vec3) bool {
+	for _, v := range pos {
+		if math.IsNaN(v) || math.Abs(v) > maxCoordinate {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// teleportToTarget implements /tp <destination>, teleporting the Source to another target.
+type teleportToTarget struct {
+	Destination []cmd.Target `cmd:"destination"`
+}
+
+// teleportToPos implements /tp <x y z>, teleporting the Source to a position.
+type teleportToPos struct {
+	Destination mgl64.Vec3 `cmd:"destination"`
+}
+
+// teleportTargetsToTarget implements /tp <victim> <destination>, teleporting targets to another target.
+type teleportTargetsToTarget struct {
+	Victim      []cmd.Target `cmd:"victim"`
+	Destination []cmd.Target `cmd:"destination"`
+}
+
+// teleportTargetsToPos implements /tp <victim> <x y z>, teleporting targets to a position.
+type teleportTargetsToPos struct {
+	Victim      []cmd.Target `cmd:"victim"`
+	Destination mgl64.Vec3   `cmd:"destination"`
+}
+
+// Run ...
+func (t teleportToTarget) Run(src cmd.Source, o *cmd.Output, _ *world.Tx) {
+	teleportToEntity([]cmd.Target{src}, t.Destination, o)
+}
+
+// Run ...
+func (t teleportToPos) Run(src cmd.Source, o *cmd.Output, _ *world.Tx) {
+	teleport([]cmd.Target{src}, t.Destination, o)
+}
+
+// Run ...
+func (t teleportTargetsToTarget) Run(_ cmd.Source, o *cmd.Output, _ *world.Tx) {
+	teleportToEntity(t.Victim, t.Destination, o)
+}
+
+// Run ...
+func (t teleportTargetsToPos) Run(_ cmd.Source, o *cmd.Output, _ *world.Tx) {
+	teleport(t.Victim, t.Destination, o)
+}
+
+// Allow ...
+func (teleportToTarget) Allow(src cmd.Source) bool {
+	_, ok := src.(*player.Player)
+	return ok
+}
+
+// Allow ...
+func (teleportToPos) Allow(src cmd.Source) bool {
+	_, ok := src.(*player.Player)
+	return ok
+}
+
+// teleporter is a Target that can be teleported.
+type teleporter interface {
+	cmd.Target
+	Teleport(pos mgl64.Vec3)
+}
+
+// teleportToEntity teleports all targets to the position of the destination Target.
+func teleportToEntity(targets, destination []cmd.Target, o *cmd.Output) {
+	dest, ok := singleTarget(destination, o)
+	if !ok {
+		return
+	}
+	pos := dest.Position()
+	for _, t := range targets {
+		if tp, ok := t.(teleporter); ok {
+			tp.Teleport(pos)
+			o.Printt(messageTeleport, name(t), name(dest))
+		}
+	}
+}
+
+// teleport teleports all targets to the position passed.
+func teleport(targets []cmd.Target, pos mgl64.Vec3, o *cmd.Output) {
+	if !withinWorld(pos) {
+		o.Errort(messageTeleportOutOfWorld)
+		return
+	}
+	for _, t := range targets {
+		if tp, ok := t.(teleporter); ok {
+			tp.Teleport(pos)
+			o.Printt(messageTeleportCoordinates, name(t), pos[0], pos[1], pos[2])
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// timeSet implements /time set <amount>, setting the time of the world to a specific value.
+type timeSet struct {
+	Set    cmd.SubCommand `cmd:"set"`
+	Amount int            `cmd:"amount"`
+}
+
+// timeSetPreset implements /time set <time>, setting the time of the world to a preset time of day.
+type timeSetPreset struct {
+	Set  cmd.SubCommand `cmd:"set"`
+	Time timeSpec       `cmd:"time"`
+}
+
+// timeAdd implements /time add <amount>, adding time to the time of the world.
+type timeAdd struct {
+	Add    cmd.SubCommand `cmd:"add"`
+	Amount int            `cmd:"amount"`
+}
+
+// timeQuery implements /time query <time>, querying the time of the world.
+type timeQuery struct {
+	Query cmd.SubCommand `cmd:"query"`
+	Time  timeQuerySpec  `cmd:"time"`
+}
+
+// Run ...
+func (t timeSet) Run(_ cmd.Source, o *cmd.Output, tx *world.Tx) {
+	tx.World().SetTime(t.Amount)
+	o.Printt(messageTimeSet, t.Amount)
+}
+
+// Run ...
+func (t timeSetPreset) Run(_ cmd.Source, o *cmd.Output, tx *world.Tx) {
+	v := map[timeSpec]int{"day": 1000, "night": 13000, "noon": 6000, "midnight": 18000, "sunrise": 23000, "sunset": 12000}[t.Time]
+	tx.World().SetTime(v)
+	o.Printt(messageTimeSet, v)
+}
+
+// Run ...
+func (t timeAdd) Run(_ cmd.Source, o *cmd.Output, tx *world.Tx) {
+	tx.World().SetTime(tx.World().Time() + t.Amount)
+	o.Printt(messageTimeAdded, t.Amount)
+}
+
+// Run ...
+func (t timeQuery) Run(_ cmd.Source, o *cmd.Output, tx *world.Tx) {
+	v := tx.World().Time()
+	switch t.Time {
+	case "daytime":
+		v %= 24000
+	case "day":
+		v /= 24000
+	}
+	o.Printt(messageTimeQuery, v)
+}
+
+// timeSpec is an Enum for the preset times of day that may be passed to /time set.
+type timeSpec string
+
+// Type ...
+func (timeSpec) Type() string {
+	return "TimeSpec"
+}
+
+// Options ...
+func (timeSpec) Options(cmd.Source) []string {
+	return []string{"day", "night", "noon", "midnight", "sunrise", "sunset"}
+}
+
+// timeQuerySpec is an Enum for the times that may be queried using /time query.
+type timeQuerySpec string
+
+// Type ...
+func (timeQuerySpec) Type() string {
+	return "TimeQuery"
+}
+
+// Options ...
+func (timeQuerySpec) Options(cmd.Source) []string {
+	return []string{"daytime", "gametime", "day"}
+}
@@ -0,0 +1,46 @@
+package vanilla
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+	"time"
+)
+
+// weather implements the /weather command, which changes the weather of the world.
+type weather struct {
+	Type     weatherType       `cmd:"type"`
+	Duration cmd.Optional[int] `cmd:"duration"`
+}
+
+// Run ...
+func (w weather) Run(_ cmd.Source, o *cmd.Output, tx *world.Tx) {
+	// Without a duration, the weather lasts between 5 and 15 minutes.
+	dur := time.Duration(w.Duration.LoadOr(300+rand.IntN(600))) * time.Second
+	switch wo := tx.World(); w.Type {
+	case "clear":
+		wo.StopThundering()
+		wo.StopRaining()
+		o.Printt(messageWeatherClear)
+	case "rain":
+		wo.StopThundering()
+		wo.StartRaining(dur)
+		o.Printt(messageWeatherRain)
+	case "thunder":
+		wo.StartThundering(dur)
+		o.Printt(messageWeatherThunder)
+	}
+}
+
+// weatherType is an Enum for the types of weather that may be passed to /weather.
+type weatherType string
+
+// Type ...
+func (weatherType) Type() string {
+	return "WeatherType"
+}
+
+// Options ...
+func (weatherType) Options(cmd.Source) []string {
+	return []string{"clear", "rain", "thunder"}
+}
@@ -19,6 +19,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	_ "unsafe"
 )
 
@@ -51,6 +52,8 @@ type Config struct {
 	// Allower may be used to specify what players can join the server and what
 	// players cannot. By returning false in the Allow method, for example if
 	// the player has been banned, will prevent the player from joining.
+	// BanList, IPBanList and Whitelist implement Allower and may be combined
+	// using Allowers.
 	Allower Allower
 	// AuthDisabled specifies if XBOX Live authentication should be disabled.
 	// Note that this should generally only be done for testing purposes or for
@@ -63,6 +66,13 @@ type Config struct {
 	// MaxChunkRadius is the maximum view distance that each player may have,
 	// measured in chunks. A chunk radius generally leads to more memory usage.
 	MaxChunkRadius int
+	// ValidateMovement specifies if movement sent by players should be checked
+	// against a simulation of their movement by the server. Players moving in
+	// ways that are not possible, such as flying, moving too fast or moving
+	// through blocks, are corrected back to their previous position. Vehicles
+	// driven by players, such as boats, are checked for moving too fast. See
+	// player.Handler.HandleMovementViolation.
+	ValidateMovement bool
 	// JoinMessage, QuitMessage and ShutdownMessage are the messages to send for
 	// when a player joins or quits the server and when the server shuts down,
 	// kicking all online players. If set, JoinMessage and QuitMessage must have
@@ -98,6 +108,21 @@ type Config struct {
 	// left as 0, the RandomTickSpeed will default to a speed of 3 blocks per
 	// sub chunk per tick (normal ticking speed).
 	RandomTickSpeed int
+	// Difficulty is the world.Difficulty of the default worlds. If nil, the
+	// difficulty stored in the WorldProvider is used.
+	Difficulty world.Difficulty
+	// TickRange is the radius in chunks around each player in which blocks
+	// and entities of the default worlds are ticked. If 0, the tick range
+	// stored in the WorldProvider is used.
+	TickRange int
+	// MobSpawning specifies if mobs spawn naturally in the default worlds and
+	// in worlds loaded using Server.LoadWorld.
+	MobSpawning bool
+	// Operators is the OperatorList holding the XUIDs of players that are
+	// operators on the server. Operators are sent the operator permission
+	// level and have all permissions unless explicitly denied. If nil, an
+	// empty OperatorList is used that is not persisted.
+	Operators *OperatorList
 	// Entities is a world.EntityRegistry with all entity types registered that
 	// may be added to the Server's worlds. If no entity types are registered,
 	// Entities will be set to entity.DefaultRegistry.
@@ -126,6 +151,9 @@ func (conf Config) New() *Server {
 	if conf.Allower == nil {
 		conf.Allower = allower{}
 	}
+	if conf.Operators == nil {
+		conf.Operators = NewOperatorList()
+	}
 	if conf.WorldProvider == nil {
 		conf.WorldProvider = world.NopProvider{}
 	}
@@ -142,7 +170,7 @@ func (conf Config) New() *Server {
 		conf.Entities = entity.DefaultRegistry
 	}
 	if !conf.DisableResourceBuilding {
-		if pack, ok := packbuilder.BuildResourcePack(); ok {
+		if pack, ok := packbuilder.BuildResourcePack(conf.Entities.Types()); ok {
 			conf.Resources = append(conf.Resources, pack)
 		}
 	}
@@ -155,8 +183,12 @@ func (conf Config) New() *Server {
 		p:        make(map[uuid.UUID]*onlinePlayer),
 		world:    &world.World{}, nether: &world.World{}, end: &world.World{},
 	}
+	// Listeners are passed a StatusProvider that reflects changes made to the
+	// name and maximum player count of the Server while it is running.
+	lconf := conf
+	lconf.StatusProvider = serverStatusProvider{srv: srv}
 	for _, lf := range conf.Listeners {
-		l, err := lf(conf)
+		l, err := lf(lconf)
 		if err != nil {
 			conf.Log.Error("create listener: " + err.Error())
 		}
@@ -169,6 +201,7 @@ func (conf Config) New() *Server {
 	srv.world = srv.createWorld(world.Overworld, &srv.nether, &srv.end)
 	srv.nether = srv.createWorld(world.Nether, &srv.world, &srv.end)
 	srv.end = srv.createWorld(world.End, &srv.nether, &srv.world)
+	srv.worlds = map[string]*world.World{OverworldName: srv.world, NetherName: srv.nether, EndName: srv.end}
 
 	srv.checkNetIsolation()
 
@@ -195,6 +228,27 @@ type UserConfig struct {
 		// DisableJoinQuitMessages specifies if default join and quit messages
 		// for players should be disabled.
 		DisableJoinQuitMessages bool
+		// OperatorsFile is the JSON file that holds the XUIDs and names of
+		// players that are operators on the server. The file is created if it
+		// does not yet exist. If empty, the operators are not saved.
+		OperatorsFile string
+		// BansFile is the JSON file that holds the players that are banned
+		// from the server. The file is created if it does not yet exist and
+		// is reloaded when it is changed. If empty, no players are banned.
+		BansFile string
+		// IPBansFile is the JSON file that holds the IP addresses and
+		// networks that are banned from the server. The file is created if it
+		// does not yet exist and is reloaded when it is changed. If empty, no
+		// IP addresses are banned.
+		IPBansFile string
+		// WhitelistEnabled specifies if only players in the whitelist may
+		// join the server.
+		WhitelistEnabled bool
+		// WhitelistFile is the JSON file that holds the names and XUIDs of
+		// the players in the whitelist. The file is created if it does not
+		// yet exist and is reloaded when it is changed. It must be set if
+		// WhitelistEnabled is true.
+		WhitelistFile string
 	}
 	World struct {
 		// SaveData controls whether a world's data will be saved and loaded.
@@ -205,6 +259,25 @@ type UserConfig struct {
 		SaveData bool
 		// Folder is the folder that the data of the world resides in.
 		Folder string
+		// Generator is the generator used to generate new areas of the
+		// worlds. It is either "flat", which generates flat worlds, or
+		// "vanilla", which generates terrain resembling that of vanilla
+		// Minecraft.
+		Generator string
+		// Seed is the seed used by the "vanilla" generator. Worlds generated
+		// with the same seed always have the same terrain.
+		Seed int64
+		// Difficulty is the difficulty of the worlds. It is either
+		// "peaceful", "easy", "normal" or "hard". If empty, the difficulty
+		// saved in the world data is used.
+		Difficulty string
+		// TickRange is the radius in chunks around each player in which
+		// blocks and entities are ticked. If 0, the tick range saved in the
+		// world data is used.
+		TickRange int
+		// MobSpawning specifies if mobs such as zombies and cows spawn
+		// naturally around players in the worlds.
+		MobSpawning bool
 	}
 	Players struct {
 		// MaxCount is the maximum amount of players allowed to join the server
@@ -215,6 +288,10 @@ type UserConfig struct {
 		// in their settings. If they try to set it above this number, it will
 		// be capped and set to the max.
 		MaximumChunkRadius int
+		// ValidateMovement specifies if movement of players should be checked
+		// by the server, correcting players that move in ways that are not
+		// possible, such as flying or moving too fast.
+		ValidateMovement bool
 		// SaveData controls whether a player's data will be saved and loaded.
 		// If true, the server will use the default LevelDB data provider and if
 		// false, an empty provider will be used. To use your own provider, turn
@@ -250,6 +327,7 @@ func (uc UserConfig) Config(log *slog.Logger) (Config, error) {
 		AuthDisabled:            !uc.Server.AuthEnabled,
 		MaxPlayers:              uc.Players.MaxCount,
 		MaxChunkRadius:          uc.Players.MaximumChunkRadius,
+		ValidateMovement:        uc.Players.ValidateMovement,
 		DisableResourceBuilding: !uc.Resources.AutoBuildPack,
 	}
 	if !uc.Server.DisableJoinQuitMessages {
@@ -261,6 +339,27 @@ func (uc UserConfig) Config(log *slog.Logger) (Config, error) {
 			return conf, fmt.Errorf("create world provider: %w", err)
 		}
 	}
+	if uc.Server.OperatorsFile != "" {
+		conf.Operators, err = LoadOperatorList(uc.Server.OperatorsFile)
+		if err != nil {
+			return conf, fmt.Errorf("load operators: %w", err)
+		}
+	}
+	if conf.Difficulty, err = parseDifficulty(uc.World.Difficulty); err != nil {
+		return conf, err
+	}
+	conf.TickRange = uc.World.TickRange
+	conf.MobSpawning = uc.World.MobSpawning
+	if conf.Allower, err = uc.allower(); err != nil {
+		return conf, err
+	}
+	switch uc.World.Generator {
+	case "", "flat":
+	case "vanilla":
+		conf.Generator = vanillaGenerator(uc.World.Seed)
+	default:
+		return conf, fmt.Errorf("unknown world generator %q", uc.World.Generator)
+	}
 	conf.Resources, err = loadResources(uc.Resources.Folder)
 	if err != nil {
 		return conf, fmt.Errorf("load resources: %w", err)
@@ -275,6 +374,59 @@ func (uc UserConfig) Config(log *slog.Logger) (Config, error) {
 	return conf, nil
 }
 
+// allower creates an Allower from the ban list, IP ban list and whitelist
+// files in the UserConfig. A nil Allower is returned if none of them are
+// enabled.
+func (uc UserConfig) allower() (Allower, error) {
+	var a []Allower
+	if uc.Server.BansFile != "" {
+		bans, err := LoadBanList(uc.Server.BansFile)
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, bans)
+	}
+	if uc.Server.IPBansFile != "" {
+		bans, err := LoadIPBanList(uc.Server.IPBansFile)
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, bans)
+	}
+	if uc.Server.WhitelistEnabled {
+		if uc.Server.WhitelistFile == "" {
+			return nil, fmt.Errorf("whitelist is enabled but no whitelist file is set")
+		}
+		whitelist, err := LoadWhitelist(uc.Server.WhitelistFile)
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, whitelist)
+	}
+	if len(a) == 0 {
+		return nil, nil
+	}
+	return Allowers(a...), nil
+}
+
+// parseDifficulty parses the name of a world.Difficulty, such as "hard". A nil
+// world.Difficulty is returned if the name is empty.
+func parseDifficulty(name string) (world.Difficulty, error) {
+	switch strings.ToLower(name) {
+	case "":
+		return nil, nil
+	case "peaceful":
+		return world.DifficultyPeaceful, nil
+	case "easy":
+		return world.DifficultyEasy, nil
+	case "normal":
+		return world.DifficultyNormal, nil
+	case "hard":
+		return world.DifficultyHard, nil
+	}
+	return nil, fmt.Errorf("unknown difficulty %q", name)
+}
+
 // loadResources loads all resource packs found in a directory passed.
 func loadResources(dir string) ([]*resource.Pack, error) {
 	_ = os.MkdirAll(dir, 0777)
@@ -308,14 +460,36 @@ func loadGenerator(dim world.Dimension) world.Generator {
 	panic("should never happen")
 }
 
+// vanillaGenerator returns a function that loads a world.Generator for a
+// world.Dimension that generates terrain similar to vanilla using the seed
+// passed.
+func vanillaGenerator(seed int64) func(dim world.Dimension) world.Generator {
+	return func(dim world.Dimension) world.Generator {
+		switch dim {
+		case world.Overworld:
+			return generator.NewOverworld(seed)
+		case world.Nether:
+			return generator.NewNether(seed)
+		case world.End:
+			return generator.NewEnd(seed)
+		}
+		panic("should never happen")
+	}
+}
+
 // DefaultConfig returns a configuration with the default values filled out.
 func DefaultConfig() UserConfig {
 	c := UserConfig{}
 	c.Network.Address = ":19132"
 	c.Server.Name = "Dragonfly Server"
 	c.Server.AuthEnabled = true
+	c.Server.OperatorsFile = "operators.json"
+	c.Server.BansFile = "banned-players.json"
+	c.Server.IPBansFile = "banned-ips.json"
+	c.Server.WhitelistFile = "whitelist.json"
 	c.World.SaveData = true
 	c.World.Folder = "world"
+	c.World.Generator = "flat"
 	c.Players.MaximumChunkRadius = 32
 	c.Players.SaveData = true
 	c.Players.Folder = "players"
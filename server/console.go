@@ -0,0 +1,127 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Console is a cmd.Source that represents the terminal that the Server is run
+// from. Commands executed by the Console are run in the transaction of the
+// overworld and their output is written to the Log of the Server. The Console
+// has all permissions. A Console may be obtained by calling Server.Console.
+type Console struct {
+	srv *Server
+}
+
+// Console returns a Console for the Server. Lines may be read from os.Stdin
+// and executed as commands by calling Console.Run:
+//
+//	go srv.Console().Run(os.Stdin)
+func (srv *Server) Console() *Console {
+	return &Console{srv: srv}
+}
+
+// Run reads lines from the io.Reader passed, such as os.Stdin, and executes
+// each line as a command using ExecuteCommand. Empty lines are ignored. Run
+// blocks until reading from r fails or io.EOF is reached. A nil error is
+// returned in the latter case.
+func (c *Console) Run(r io.Reader) error {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); line != "" {
+			c.ExecuteCommand(line)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return fmt.Errorf("read console input: %w", err)
+	}
+	return nil
+}
+
+// ExecuteCommand executes the command line passed in the transaction of the
+// overworld. Unlike commands run by players, the line may, but does not have
+// to, start with a slash. ExecuteCommand blocks until the command has been
+// run.
+func (c *Console) ExecuteCommand(commandLine string) {
+	args := strings.Split(strings.TrimPrefix(commandLine, "/"), " ")
+
+	command, ok := cmd.ByAlias(strings.ToLower(args[0]))
+	if !ok {
+		o := &cmd.Output{}
+		o.Errort(cmd.MessageUnknown, args[0])
+		c.SendCommandOutput(o)
+		return
+	}
+	<-c.srv.world.Exec(func(tx *world.Tx) {
+		command.Execute(strings.Join(args[1:], " "), c, tx)
+	})
+}
+
+// Commands returns all commands that the Console can run, sorted by their
+// name.
+func (c *Console) Commands() []cmd.Command {
+	var commands []cmd.Command
+	for alias, command := range cmd.Commands() {
+		if command.Name() == alias && len(command.Runnables(c)) > 0 {
+			commands = append(commands, command)
+		}
+	}
+	slices.SortFunc(commands, func(a, b cmd.Command) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return commands
+}
+
+// Complete returns the names and aliases of all commands that the Console can
+// run that start with the prefix passed, sorted alphabetically. It may be used
+// to implement tab-completion in a terminal. A leading slash in the prefix is
+// ignored. Once the prefix holds a space, Complete returns no suggestions.
+func (c *Console) Complete(prefix string) []string {
+	prefix = strings.ToLower(strings.TrimPrefix(prefix, "/"))
+	if strings.Contains(prefix, " ") {
+		return nil
+	}
+	var suggestions []string
+	for _, command := range c.Commands() {
+		for _, alias := range append([]string{command.Name()}, command.Aliases()...) {
+			if strings.HasPrefix(alias, prefix) && !slices.Contains(suggestions, alias) {
+				suggestions = append(suggestions, alias)
+			}
+		}
+	}
+	slices.Sort(suggestions)
+	return suggestions
+}
+
+// Name returns "Console".
+func (c *Console) Name() string {
+	return "Console"
+}
+
+// Position returns the centre of the spawn of the overworld.
+func (c *Console) Position() mgl64.Vec3 {
+	return c.srv.world.Spawn().Vec3Centre()
+}
+
+// HasPermission always returns true: The Console has all permissions.
+func (c *Console) HasPermission(string) bool {
+	return true
+}
+
+// SendCommandOutput writes the messages of the cmd.Output passed to the Log
+// of the Server with the info level and its errors with the error level.
+func (c *Console) SendCommandOutput(o *cmd.Output) {
+	for _, m := range o.Messages() {
+		c.srv.conf.Log.Info(text.Clean(m.String()))
+	}
+	for _, err := range o.Errors() {
+		c.srv.conf.Log.Error(text.Clean(err.Error()))
+	}
+}
@@ -0,0 +1,211 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// NewBoat creates a new boat of the item.BoatType passed. If chest is true, a
+// chest boat is created, which has a single seat and an inventory.
+func NewBoat(opts world.EntitySpawnOpts, t item.BoatType, chest bool) *world.EntityHandle {
+	if chest {
+		return opts.New(ChestBoatType, BoatBehaviourConfig{Type: t})
+	}
+	return opts.New(BoatType, BoatBehaviourConfig{Type: t})
+}
+
+// Boat is a Vehicle that floats on water. The player in the front seat of a
+// Boat controls its movement.
+type Boat struct {
+	*Ent
+}
+
+// behaviour returns the BoatBehaviour of the Boat.
+func (b *Boat) behaviour() *BoatBehaviour {
+	return b.data.Data.(*BoatBehaviour)
+}
+
+// Type returns the type of wood that the Boat is made of.
+func (b *Boat) Type() item.BoatType {
+	return b.behaviour().conf.Type
+}
+
+// Chest checks if the Boat has a chest.
+func (b *Boat) Chest() bool {
+	return b.H().Type() == ChestBoatType
+}
+
+// Inventory returns the inventory of the chest of the Boat. False is returned
+// if the Boat does not have a chest.
+func (b *Boat) Inventory() (*inventory.Inventory, bool) {
+	return b.behaviour().inv, b.Chest()
+}
+
+// Seats returns the seats of the Boat. A Boat has two seats, or one if it has
+// a chest. A single rider sits in the middle of the Boat.
+func (b *Boat) Seats() []world.Seat {
+	if b.Chest() {
+		return []world.Seat{{Position: mgl64.Vec3{0.2, -0.225}, Driver: true}}
+	}
+	if len(b.H().Riders()) < 2 {
+		return []world.Seat{{Position: mgl64.Vec3{0, -0.225}, Driver: true}, {Position: mgl64.Vec3{-0.6, -0.225}}}
+	}
+	return []world.Seat{{Position: mgl64.Vec3{0.2, -0.225}, Driver: true}, {Position: mgl64.Vec3{-0.6, -0.225}}}
+}
+
+// Move moves the Boat. A Boat moved while it has riders is driven by the rider
+// in its front seat and does not move by itself for a short time.
+func (b *Boat) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
+	if len(b.H().Riders()) > 0 {
+		b.behaviour().drivenTicks = 10
+	}
+	b.Ent.Move(deltaPos, deltaYaw, deltaPitch)
+}
+
+// Hurt damages the Boat, breaking it if it is damaged enough. The Boat drops
+// itself, and the contents of its chest, when it breaks.
+func (b *Boat) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
+	return b.behaviour().damage.hurt(b.Ent, dmg, src, func(creative bool) {
+		if inv, ok := b.Inventory(); ok {
+			for _, it := range inv.Clear() {
+				dropVehicleItem(b.Ent, it)
+			}
+		}
+		if !creative {
+			dropVehicleItem(b.Ent, item.NewStack(item.Boat{Type: b.Type(), Chest: b.Chest()}, 1))
+		}
+	})
+}
+
+// BoatBehaviourConfig holds optional parameters for a BoatBehaviour.
+type BoatBehaviourConfig struct {
+	// Type is the type of wood that the boat is made of.
+	Type item.BoatType
+}
+
+// Apply ...
+func (conf BoatBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a BoatBehaviour using the parameters in conf. The BoatBehaviour
+// has an inventory for a chest, which is only used by chest boats.
+func (conf BoatBehaviourConfig) New() *BoatBehaviour {
+	return &BoatBehaviour{conf: conf, mc: &MovementComputer{}, inv: inventory.New(27, nil)}
+}
+
+// BoatBehaviour implements the behaviour of a Boat. A boat floats up to the
+// surface of water and falls down on land. It does not move by itself while
+// it is driven.
+type BoatBehaviour struct {
+	conf BoatBehaviourConfig
+	mc   *MovementComputer
+	inv  *inventory.Inventory
+
+	damage      vehicleDamage
+	drivenTicks int
+}
+
+// Explode damages the boat, breaking it if the explosion is strong enough.
+func (b *BoatBehaviour) Explode(e *Ent, _ mgl64.Vec3, impact float64, conf block.ExplosionConfig) {
+	(&Boat{Ent: e}).Hurt(math.Floor((impact*impact+impact)*3.5*conf.Size*2+1), ExplosionDamageSource{})
+}
+
+// Tick moves the boat. A boat in water floats up to its surface, while a boat
+// on land falls down.
+func (b *BoatBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	b.damage.tick()
+	if b.drivenTicks > 0 {
+		// The movement of the boat is controlled by its driver.
+		b.drivenTicks--
+		return nil
+	}
+	pos, vel := e.data.Pos, e.data.Vel
+	blockPos := cube.PosFromVec3(pos)
+	switch {
+	case water(tx, blockPos):
+		// The boat is below the surface, so it floats upwards.
+		vel[1] = min(vel[1]+0.04, 0.1)
+		vel[0], vel[2] = vel[0]*0.9, vel[2]*0.9
+	case water(tx, blockPos.Side(cube.FaceDown)) && pos[1]-float64(blockPos[1]) < 0.1:
+		// The boat is floating on the surface.
+		vel[1] = float64(blockPos[1]) - pos[1]
+		vel[0], vel[2] = vel[0]*0.9, vel[2]*0.9
+	default:
+		vel[1] -= 0.04
+		vel[0], vel[2] = vel[0]*0.98, vel[2]*0.98
+	}
+	m := b.mc.TickMovement(e, pos, vel, e.data.Rot, tx)
+	e.data.Pos, e.data.Vel = m.pos, m.vel
+	return m
+}
+
+// water checks if the block at the position passed contains water.
+func water(tx *world.Tx, pos cube.Pos) bool {
+	l, ok := tx.Liquid(pos)
+	return ok && l.LiquidType() == "water"
+}
+
+// BoatType is a world.EntityType implementation for boats.
+var BoatType boatType
+
+// ChestBoatType is a world.EntityType implementation for boats with a chest.
+var ChestBoatType chestBoatType
+
+type boatType struct{}
+
+func (boatType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Boat{Ent: Open(tx, handle, data)}
+}
+
+func (boatType) EncodeEntity() string   { return "minecraft:boat" }
+func (boatType) NetworkOffset() float64 { return 0.375 }
+func (boatType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.7, 0, -0.7, 0.7, 0.455, 0.7)
+}
+
+func (boatType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	data.Data = BoatBehaviourConfig{Type: boatTypeFromNBT(m)}.New()
+}
+
+func (boatType) EncodeNBT(data *world.EntityData) map[string]any {
+	return map[string]any{"Variant": int32(data.Data.(*BoatBehaviour).conf.Type.Uint8())}
+}
+
+type chestBoatType struct{}
+
+func (chestBoatType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Boat{Ent: Open(tx, handle, data)}
+}
+
+func (chestBoatType) EncodeEntity() string   { return "minecraft:chest_boat" }
+func (chestBoatType) NetworkOffset() float64 { return 0.375 }
+func (chestBoatType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.7, 0, -0.7, 0.7, 0.455, 0.7)
+}
+
+func (chestBoatType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	b := BoatBehaviourConfig{Type: boatTypeFromNBT(m)}.New()
+	nbtconv.InvFromNBT(b.inv, nbtconv.Slice(m, "ChestItems"))
+	data.Data = b
+}
+
+func (chestBoatType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*BoatBehaviour)
+	return map[string]any{"Variant": int32(b.conf.Type.Uint8()), "ChestItems": nbtconv.InvToNBT(b.inv)}
+}
+
+// boatTypeFromNBT reads the item.BoatType of a boat from the NBT data passed.
+func boatTypeFromNBT(m map[string]any) item.BoatType {
+	v := int(nbtconv.Int32(m, "Variant"))
+	if types := item.BoatTypes(); v >= 0 && v < len(types) {
+		return types[v]
+	}
+	return item.OakBoat()
+}
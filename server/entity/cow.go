@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+)
+
+// NewCow creates a new cow.
+func NewCow(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(CowType, cowConf)
+}
+
+var cowConf = MobBehaviourConfig{
+	MaxHealth:  10,
+	Speed:      0.1,
+	EyeHeight:  1.3,
+	Experience: 2,
+	Goals:      animalGoals,
+	Drops: func(m *Mob, _ world.DamageSource) []item.Stack {
+		drops := []item.Stack{item.NewStack(item.Beef{Cooked: m.OnFireDuration() > 0}, 1+rand.IntN(3))}
+		if n := rand.IntN(3); n > 0 {
+			drops = append(drops, item.NewStack(item.Leather{}, n))
+		}
+		return drops
+	},
+}
+
+// animalGoals adds the goals shared by passive animals, which wander around
+// and run away when attacked.
+func animalGoals(goals, _ *GoalSelector) {
+	goals.Add(0, FloatGoal{})
+	goals.Add(1, &PanicGoal{})
+	goals.Add(6, &WanderGoal{})
+	goals.Add(7, &LookAtEntityGoal{Distance: 6})
+}
+
+// CowType is a world.EntityType implementation for cows.
+var CowType cowType
+
+type cowType struct{}
+
+func (cowType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: Open(tx, handle, data)}
+}
+
+func (cowType) EncodeEntity() string { return "minecraft:cow" }
+func (cowType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.45, 0, -0.45, 0.45, 1.4, 0.45)
+}
+
+func (cowType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	b := cowConf.New()
+	b.DecodeNBT(m)
+	data.Data = b
+}
+
+func (cowType) EncodeNBT(data *world.EntityData) map[string]any {
+	return data.Data.(*MobBehaviour).EncodeNBT()
+}
@@ -66,6 +66,16 @@ func (e *Ent) SetVelocity(v mgl64.Vec3) {
 	e.data.Vel = v
 }
 
+// Move moves the entity from one position to another in the world, by adding the delta passed to the current
+// position of the entity. Move also rotates the entity, adding deltaYaw and deltaPitch to the respective values.
+func (e *Ent) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
+	pos, rot := e.data.Pos.Add(deltaPos), e.data.Rot.Add(cube.Rotation{deltaYaw, deltaPitch})
+	for _, v := range e.tx.Viewers(e.data.Pos) {
+		v.ViewEntityMovement(e, pos, rot, false)
+	}
+	e.data.Pos, e.data.Rot = pos, rot
+}
+
 // Rotation returns the rotation of the entity.
 func (e *Ent) Rotation() cube.Rotation {
 	return e.data.Rot
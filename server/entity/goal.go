@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"slices"
+)
+
+// Goal is an objective that a Mob may pursue, such as wandering around or attacking its target. Goals are added
+// to a GoalSelector, which decides which goals are running at any time.
+type Goal interface {
+	// Controls returns the controls of the Mob that the Goal uses while running. Goals that use the same
+	// controls cannot run at the same time.
+	Controls() GoalControl
+	// CanStart checks if the Goal can start running for the Mob passed.
+	CanStart(m *Mob, tx *world.Tx) bool
+	// CanContinue checks if the Goal should keep running after it was started.
+	CanContinue(m *Mob, tx *world.Tx) bool
+	// Start is called when the Goal starts running.
+	Start(m *Mob, tx *world.Tx)
+	// Tick is called every tick while the Goal is running.
+	Tick(m *Mob, tx *world.Tx)
+	// Stop is called when the Goal stops running, either because it could not continue or because a Goal with
+	// a higher priority that uses the same controls started running.
+	Stop(m *Mob, tx *world.Tx)
+}
+
+// GoalControl is a control of a Mob that a Goal may use. GoalControls may be combined using the | operator.
+type GoalControl uint8
+
+const (
+	// GoalControlMove is used by goals that move the Mob.
+	GoalControlMove GoalControl = 1 << iota
+	// GoalControlLook is used by goals that change the direction the Mob is looking in.
+	GoalControlLook
+	// GoalControlJump is used by goals that make the Mob jump.
+	GoalControlJump
+	// GoalControlTarget is used by goals that select the target of the Mob.
+	GoalControlTarget
+)
+
+// GoalSelector holds the goals of a Mob along with their priority. Every tick, the GoalSelector stops goals that
+// can no longer continue and starts goals that can start, preferring goals with a higher priority when several
+// goals use the same controls. A lower priority value means a higher priority.
+type GoalSelector struct {
+	goals []*prioritisedGoal
+}
+
+// prioritisedGoal is a Goal with a priority in a GoalSelector.
+type prioritisedGoal struct {
+	priority int
+	goal     Goal
+	running  bool
+}
+
+// NewGoalSelector creates an empty GoalSelector.
+func NewGoalSelector() *GoalSelector {
+	return &GoalSelector{}
+}
+
+// Add adds a Goal with a priority to the GoalSelector. A lower priority value means a higher priority.
+func (s *GoalSelector) Add(priority int, g Goal) {
+	s.goals = append(s.goals, &prioritisedGoal{priority: priority, goal: g})
+	slices.SortStableFunc(s.goals, func(a, b *prioritisedGoal) int {
+		return a.priority - b.priority
+	})
+}
+
+// Remove removes a Goal from the GoalSelector. If the Goal is running, it is stopped first.
+func (s *GoalSelector) Remove(m *Mob, tx *world.Tx, g Goal) {
+	s.goals = slices.DeleteFunc(s.goals, func(pg *prioritisedGoal) bool {
+		if pg.goal != g {
+			return false
+		}
+		if pg.running {
+			g.Stop(m, tx)
+		}
+		return true
+	})
+}
+
+// Running returns all goals that are currently running.
+func (s *GoalSelector) Running() []Goal {
+	running := make([]Goal, 0, len(s.goals))
+	for _, pg := range s.goals {
+		if pg.running {
+			running = append(running, pg.goal)
+		}
+	}
+	return running
+}
+
+// tick stops goals that can no longer continue, starts goals that can start and ticks all goals that are
+// running.
+func (s *GoalSelector) tick(m *Mob, tx *world.Tx) {
+	for _, pg := range s.goals {
+		if pg.running && !pg.goal.CanContinue(m, tx) {
+			pg.running = false
+			pg.goal.Stop(m, tx)
+		}
+	}
+	for _, pg := range s.goals {
+		if pg.running || !s.available(pg) || !pg.goal.CanStart(m, tx) {
+			continue
+		}
+		for _, other := range s.goals {
+			if other.running && other.goal.Controls()&pg.goal.Controls() != 0 {
+				other.running = false
+				other.goal.Stop(m, tx)
+			}
+		}
+		pg.running = true
+		pg.goal.Start(m, tx)
+	}
+	for _, pg := range s.goals {
+		if pg.running {
+			pg.goal.Tick(m, tx)
+		}
+	}
+}
+
+// stop stops all goals that are currently running.
+func (s *GoalSelector) stop(m *Mob, tx *world.Tx) {
+	for _, pg := range s.goals {
+		if pg.running {
+			pg.running = false
+			pg.goal.Stop(m, tx)
+		}
+	}
+}
+
+// available checks if the controls of a goal are either free or only used by running goals with a lower
+// priority.
+func (s *GoalSelector) available(pg *prioritisedGoal) bool {
+	for _, other := range s.goals {
+		if other.running && other.goal.Controls()&pg.goal.Controls() != 0 && other.priority <= pg.priority {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,206 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+	"time"
+)
+
+// MeleeAttackGoal is a Goal that makes a Mob walk towards its target and
+// attack it once it is in reach.
+type MeleeAttackGoal struct {
+	// Speed is the multiplier of the speed of the Mob while it is chasing its
+	// target. If 0, a multiplier of 1 is used.
+	Speed float64
+	// Cooldown is the amount of ticks between two attacks. If 0, the Mob
+	// attacks at most once every 20 ticks.
+	Cooldown int
+
+	cooldown int
+}
+
+// Controls ...
+func (*MeleeAttackGoal) Controls() GoalControl { return GoalControlMove | GoalControlLook }
+
+// CanStart ...
+func (g *MeleeAttackGoal) CanStart(m *Mob, _ *world.Tx) bool {
+	target, ok := m.Target()
+	return ok && validTarget(target)
+}
+
+// CanContinue ...
+func (g *MeleeAttackGoal) CanContinue(m *Mob, tx *world.Tx) bool {
+	return g.CanStart(m, tx)
+}
+
+// Start ...
+func (g *MeleeAttackGoal) Start(m *Mob, tx *world.Tx) {
+	if target, ok := m.Target(); ok {
+		m.Navigator().MoveToEntity(m, tx, target, g.speed())
+	}
+}
+
+// Tick ...
+func (g *MeleeAttackGoal) Tick(m *Mob, tx *world.Tx) {
+	target, ok := m.Target()
+	if !ok {
+		return
+	}
+	m.LookAt(EyePosition(target))
+	if m.Navigator().Idle() && rand.IntN(10) == 0 {
+		// The path to the target could not be found before, but the target or
+		// the world around it might have changed.
+		m.Navigator().MoveToEntity(m, tx, target, g.speed())
+	}
+	if g.cooldown = max(g.cooldown-1, 0); g.cooldown > 0 {
+		return
+	}
+	reach := m.H().Type().BBox(m).Width()*2 + target.H().Type().BBox(target).Width()
+	if diff := target.Position().Sub(m.Position()); diff.Dot(diff) <= reach*reach {
+		g.cooldown = g.Cooldown
+		if g.cooldown == 0 {
+			g.cooldown = 20
+		}
+		m.AttackEntity(target)
+	}
+}
+
+// Stop ...
+func (g *MeleeAttackGoal) Stop(m *Mob, _ *world.Tx) {
+	m.Navigator().Stop()
+}
+
+// speed returns the speed multiplier of the Mob while chasing its target.
+func (g *MeleeAttackGoal) speed() float64 {
+	if g.Speed == 0 {
+		return 1
+	}
+	return g.Speed
+}
+
+// NearestTargetGoal is a Goal that makes a Mob target the nearest entity that
+// matches a filter.
+type NearestTargetGoal struct {
+	// Distance is the maximum distance of the entity targeted. If 0, entities
+	// up to 16 blocks away are targeted.
+	Distance float64
+	// Filter returns true for entities that the Mob may target. If nil, only
+	// players are targeted.
+	Filter func(e world.Entity) bool
+
+	target *world.EntityHandle
+}
+
+// Controls ...
+func (*NearestTargetGoal) Controls() GoalControl { return GoalControlTarget }
+
+// CanStart ...
+func (g *NearestTargetGoal) CanStart(m *Mob, tx *world.Tx) bool {
+	if rand.IntN(10) != 0 {
+		return false
+	}
+	e, ok := nearestEntity(m, tx, g.distance(), func(e world.Entity) bool {
+		if !validTarget(e) {
+			return false
+		}
+		if g.Filter == nil {
+			_, ok := e.(interface{ GameMode() world.GameMode })
+			return ok
+		}
+		return g.Filter(e)
+	})
+	if ok {
+		g.target = e.H()
+	}
+	return ok
+}
+
+// CanContinue ...
+func (g *NearestTargetGoal) CanContinue(m *Mob, _ *world.Tx) bool {
+	target, ok := m.Target()
+	// Give the Mob some leeway before losing track of its target.
+	return ok && validTarget(target) && target.Position().Sub(m.Position()).Len() <= g.distance()*1.5
+}
+
+// Start ...
+func (g *NearestTargetGoal) Start(m *Mob, tx *world.Tx) {
+	if e, ok := g.target.Entity(tx); ok {
+		m.SetTarget(e)
+	}
+}
+
+// Tick ...
+func (*NearestTargetGoal) Tick(*Mob, *world.Tx) {}
+
+// Stop ...
+func (g *NearestTargetGoal) Stop(m *Mob, _ *world.Tx) {
+	g.target = nil
+	m.SetTarget(nil)
+}
+
+// distance returns the maximum distance of the entity targeted.
+func (g *NearestTargetGoal) distance() float64 {
+	if g.Distance == 0 {
+		return 16
+	}
+	return g.Distance
+}
+
+// HurtByTargetGoal is a Goal that makes a Mob target the entity that last
+// attacked it.
+type HurtByTargetGoal struct {
+	// Duration is the duration that the Mob keeps targeting its attacker after
+	// the last attack. If 0, the Mob targets its attacker for 10 seconds.
+	Duration time.Duration
+
+	// attackedAt is the age of the Mob when it was attacked by the attacker
+	// that it last started targeting.
+	attackedAt time.Duration
+}
+
+// Controls ...
+func (*HurtByTargetGoal) Controls() GoalControl { return GoalControlTarget }
+
+// CanStart ...
+func (g *HurtByTargetGoal) CanStart(m *Mob, _ *world.Tx) bool {
+	attacker, since, ok := m.LastAttacker()
+	return ok && m.Age()-since != g.attackedAt && validTarget(attacker)
+}
+
+// CanContinue ...
+func (g *HurtByTargetGoal) CanContinue(m *Mob, _ *world.Tx) bool {
+	target, ok := m.Target()
+	attacker, since, attacked := m.LastAttacker()
+	duration := g.Duration
+	if duration == 0 {
+		duration = time.Second * 10
+	}
+	return ok && attacked && validTarget(target) && target.H() == attacker.H() && since < duration
+}
+
+// Start ...
+func (g *HurtByTargetGoal) Start(m *Mob, _ *world.Tx) {
+	if attacker, since, ok := m.LastAttacker(); ok {
+		g.attackedAt = m.Age() - since
+		m.SetTarget(attacker)
+	}
+}
+
+// Tick ...
+func (*HurtByTargetGoal) Tick(*Mob, *world.Tx) {}
+
+// Stop ...
+func (*HurtByTargetGoal) Stop(m *Mob, _ *world.Tx) { m.SetTarget(nil) }
+
+// validTarget checks if the entity passed may be targeted by a Mob. Only
+// living entities that are alive and able to take damage are valid targets.
+func validTarget(e world.Entity) bool {
+	l, ok := e.(Living)
+	if !ok || l.Dead() {
+		return false
+	}
+	if g, ok := e.(interface{ GameMode() world.GameMode }); ok {
+		return g.GameMode().AllowsTakingDamage()
+	}
+	return true
+}
@@ -0,0 +1,231 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// FloatGoal is a Goal that makes a Mob swim upwards while it is in a liquid,
+// so that it does not drown.
+type FloatGoal struct{}
+
+// Controls ...
+func (FloatGoal) Controls() GoalControl { return GoalControlJump }
+
+// CanStart ...
+func (FloatGoal) CanStart(m *Mob, tx *world.Tx) bool {
+	_, ok := tx.Liquid(cube.PosFromVec3(m.Position()))
+	return ok
+}
+
+// CanContinue ...
+func (g FloatGoal) CanContinue(m *Mob, tx *world.Tx) bool { return g.CanStart(m, tx) }
+
+// Start ...
+func (FloatGoal) Start(*Mob, *world.Tx) {}
+
+// Stop ...
+func (FloatGoal) Stop(*Mob, *world.Tx) {}
+
+// Tick ...
+func (FloatGoal) Tick(m *Mob, _ *world.Tx) {
+	if rand.Float64() < 0.8 {
+		vel := m.Velocity()
+		vel[1] = max(vel[1], 0.12)
+		m.SetVelocity(vel)
+	}
+}
+
+// WanderGoal is a Goal that makes a Mob walk to random positions around it
+// every once in a while.
+type WanderGoal struct {
+	// Speed is the multiplier of the speed of the Mob while it is wandering.
+	// If 0, a multiplier of 1 is used.
+	Speed float64
+	// Interval is the average amount of ticks between the moments that the
+	// Mob starts wandering. If 0, the Mob starts wandering every 120 ticks on
+	// average.
+	Interval int
+	// Distance is the maximum horizontal distance that the Mob wanders away.
+	// If 0, a distance of 10 blocks is used.
+	Distance int
+
+	destination mgl64.Vec3
+}
+
+// Controls ...
+func (*WanderGoal) Controls() GoalControl { return GoalControlMove }
+
+// CanStart ...
+func (g *WanderGoal) CanStart(m *Mob, _ *world.Tx) bool {
+	interval, distance := g.Interval, g.Distance
+	if interval == 0 {
+		interval = 120
+	}
+	if distance == 0 {
+		distance = 10
+	}
+	if !m.Navigator().Idle() || rand.IntN(interval) != 0 {
+		return false
+	}
+	g.destination = m.Position().Add(mgl64.Vec3{
+		float64(rand.IntN(distance*2+1) - distance),
+		float64(rand.IntN(7) - 3),
+		float64(rand.IntN(distance*2+1) - distance),
+	})
+	return true
+}
+
+// CanContinue ...
+func (*WanderGoal) CanContinue(m *Mob, _ *world.Tx) bool { return !m.Navigator().Idle() }
+
+// Start ...
+func (g *WanderGoal) Start(m *Mob, tx *world.Tx) {
+	speed := g.Speed
+	if speed == 0 {
+		speed = 1
+	}
+	m.Navigator().MoveTo(m, tx, g.destination, speed)
+}
+
+// Tick ...
+func (*WanderGoal) Tick(*Mob, *world.Tx) {}
+
+// Stop ...
+func (*WanderGoal) Stop(m *Mob, _ *world.Tx) { m.Navigator().Stop() }
+
+// PanicGoal is a Goal that makes a Mob run away in a random direction after
+// being attacked or while it is on fire.
+type PanicGoal struct {
+	// Speed is the multiplier of the speed of the Mob while it is panicking.
+	// If 0, a multiplier of 1.25 is used.
+	Speed float64
+
+	attackedAt  time.Duration
+	destination mgl64.Vec3
+}
+
+// Controls ...
+func (*PanicGoal) Controls() GoalControl { return GoalControlMove }
+
+// CanStart ...
+func (g *PanicGoal) CanStart(m *Mob, _ *world.Tx) bool {
+	_, since, attacked := m.LastAttacker()
+	attacked = attacked && since < time.Second && m.Age()-since != g.attackedAt
+	if !attacked && m.OnFireDuration() <= 0 {
+		return false
+	}
+	if attacked {
+		g.attackedAt = m.Age() - since
+	}
+	g.destination = m.Position().Add(mgl64.Vec3{
+		float64(rand.IntN(11) - 5),
+		float64(rand.IntN(5) - 2),
+		float64(rand.IntN(11) - 5),
+	})
+	return true
+}
+
+// CanContinue ...
+func (*PanicGoal) CanContinue(m *Mob, _ *world.Tx) bool { return !m.Navigator().Idle() }
+
+// Start ...
+func (g *PanicGoal) Start(m *Mob, tx *world.Tx) {
+	speed := g.Speed
+	if speed == 0 {
+		speed = 1.25
+	}
+	m.Navigator().MoveTo(m, tx, g.destination, speed)
+}
+
+// Tick ...
+func (*PanicGoal) Tick(*Mob, *world.Tx) {}
+
+// Stop ...
+func (*PanicGoal) Stop(m *Mob, _ *world.Tx) { m.Navigator().Stop() }
+
+// LookAtEntityGoal is a Goal that makes a Mob look at a nearby entity for a
+// few seconds every once in a while.
+type LookAtEntityGoal struct {
+	// Distance is the maximum distance of the entity looked at. If 0, the Mob
+	// looks at entities up to 8 blocks away.
+	Distance float64
+	// Filter returns true for entities that the Mob may look at. If nil, the
+	// Mob only looks at players.
+	Filter func(e world.Entity) bool
+
+	target *world.EntityHandle
+	ticks  int
+}
+
+// Controls ...
+func (*LookAtEntityGoal) Controls() GoalControl { return GoalControlLook }
+
+// CanStart ...
+func (g *LookAtEntityGoal) CanStart(m *Mob, tx *world.Tx) bool {
+	if rand.Float64() >= 0.02 {
+		return false
+	}
+	filter := g.Filter
+	if filter == nil {
+		filter = func(e world.Entity) bool {
+			_, ok := e.(interface{ GameMode() world.GameMode })
+			return ok
+		}
+	}
+	e, ok := nearestEntity(m, tx, g.distance(), filter)
+	if ok {
+		g.target = e.H()
+	}
+	return ok
+}
+
+// CanContinue ...
+func (g *LookAtEntityGoal) CanContinue(m *Mob, tx *world.Tx) bool {
+	e, ok := g.target.Entity(tx)
+	return ok && g.ticks > 0 && e.Position().Sub(m.Position()).Len() <= g.distance()
+}
+
+// Start ...
+func (g *LookAtEntityGoal) Start(*Mob, *world.Tx) { g.ticks = 40 + rand.IntN(40) }
+
+// Tick ...
+func (g *LookAtEntityGoal) Tick(m *Mob, tx *world.Tx) {
+	g.ticks--
+	if e, ok := g.target.Entity(tx); ok {
+		m.LookAt(EyePosition(e))
+	}
+}
+
+// Stop ...
+func (g *LookAtEntityGoal) Stop(*Mob, *world.Tx) { g.target = nil }
+
+// distance returns the maximum distance of the entity looked at.
+func (g *LookAtEntityGoal) distance() float64 {
+	if g.Distance == 0 {
+		return 8
+	}
+	return g.Distance
+}
+
+// nearestEntity returns the entity closest to the Mob passed within a distance
+// that matches the filter passed.
+func nearestEntity(m *Mob, tx *world.Tx, distance float64, filter func(e world.Entity) bool) (world.Entity, bool) {
+	var (
+		nearest     world.Entity
+		nearestDist = distance
+		pos         = m.Position()
+	)
+	for e := range tx.EntitiesWithin(cube.Box(pos[0], pos[1], pos[2], pos[0], pos[1], pos[2]).Grow(distance)) {
+		if e.H() == m.H() || !filter(e) {
+			continue
+		}
+		if dist := e.Position().Sub(pos).Len(); dist <= nearestDist {
+			nearest, nearestDist = e, dist
+		}
+	}
+	return nearest, nearest != nil
+}
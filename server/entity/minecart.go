@@ -0,0 +1,275 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// NewMinecart creates a new minecart.
+func NewMinecart(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(MinecartType, MinecartBehaviourConfig{})
+}
+
+// Minecart is a Vehicle that moves along rails. Entities riding a Minecart do
+// not control its movement.
+type Minecart struct {
+	*Ent
+}
+
+// behaviour returns the MinecartBehaviour of the Minecart.
+func (m *Minecart) behaviour() *MinecartBehaviour {
+	return m.data.Data.(*MinecartBehaviour)
+}
+
+// Seats returns the single seat of the Minecart.
+func (m *Minecart) Seats() []world.Seat {
+	return []world.Seat{{Position: mgl64.Vec3{0, -0.35}}}
+}
+
+// Hurt damages the Minecart, breaking it if it is damaged enough. The
+// Minecart drops itself when it breaks.
+func (m *Minecart) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
+	return m.behaviour().damage.hurt(m.Ent, dmg, src, func(creative bool) {
+		if !creative {
+			dropVehicleItem(m.Ent, item.NewStack(item.Minecart{}, 1))
+		}
+	})
+}
+
+// MinecartBehaviourConfig holds optional parameters for a MinecartBehaviour.
+type MinecartBehaviourConfig struct {
+	// MaxSpeed is the maximum speed in blocks/tick of the minecart on rails.
+	// If 0, a maximum speed of 0.4 is used.
+	MaxSpeed float64
+}
+
+// Apply ...
+func (conf MinecartBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a MinecartBehaviour using the parameters in conf.
+func (conf MinecartBehaviourConfig) New() *MinecartBehaviour {
+	if conf.MaxSpeed == 0 {
+		conf.MaxSpeed = 0.4
+	}
+	return &MinecartBehaviour{conf: conf, mc: &MovementComputer{}}
+}
+
+// MinecartBehaviour implements the behaviour of a Minecart. A minecart on
+// rails follows the rails, speeding up on slopes and powered rails. Off rails,
+// a minecart falls down and slides over the ground.
+type MinecartBehaviour struct {
+	conf MinecartBehaviourConfig
+	mc   *MovementComputer
+
+	damage vehicleDamage
+}
+
+// Explode damages the minecart, breaking it if the explosion is strong enough.
+func (b *MinecartBehaviour) Explode(e *Ent, _ mgl64.Vec3, impact float64, conf block.ExplosionConfig) {
+	(&Minecart{Ent: e}).Hurt(math.Floor((impact*impact+impact)*3.5*conf.Size*2+1), ExplosionDamageSource{})
+}
+
+// Tick moves the minecart along the rails that it is on, or makes it fall if
+// it is not on rails.
+func (b *MinecartBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	b.damage.tick()
+	b.push(e, tx)
+
+	railPos := cube.PosFromVec3(e.data.Pos)
+	r, shape, ok := minecartRail(tx, railPos)
+	if !ok {
+		// The minecart may be slightly above the rail, for example at the top
+		// of a slope.
+		railPos = railPos.Side(cube.FaceDown)
+		r, shape, ok = minecartRail(tx, railPos)
+	}
+	if !ok {
+		vel := e.data.Vel
+		vel[1] -= 0.04
+		vel[0], vel[2] = vel[0]*0.95, vel[2]*0.95
+		m := b.mc.TickMovement(e, e.data.Pos, vel, e.data.Rot, tx)
+		e.data.Pos, e.data.Vel = m.pos, m.vel
+		return m
+	}
+	return b.tickRail(e, tx, railPos, r, shape)
+}
+
+// tickRail moves the minecart along the rail at the position passed.
+func (b *MinecartBehaviour) tickRail(e *Ent, tx *world.Tx, railPos cube.Pos, r world.Block, shape block.RailShape) *Movement {
+	pos, vel := e.data.Pos, e.data.Vel
+	vel[1] = 0
+
+	first, second := shape.Directions()
+	centre := railPos.Vec3Middle()
+	start, end := centre.Add(directionVec(first).Mul(0.5)), centre.Add(directionVec(second).Mul(0.5))
+	line := end.Sub(start).Normalize()
+
+	if up, ascending := shape.Ascending(); ascending {
+		// Minecarts slow down while going up a slope and speed up while going
+		// down.
+		vel = vel.Sub(directionVec(up).Mul(0.0078125))
+	}
+	dir := line
+	if vel.Dot(dir) < 0 {
+		dir = dir.Mul(-1)
+	}
+	speed := math.Hypot(vel[0], vel[2])
+
+	switch rail := r.(type) {
+	case block.PoweredRail:
+		if !rail.Powered {
+			if speed < 0.03 {
+				speed = 0
+			} else {
+				speed *= 0.5
+			}
+			break
+		}
+		if speed > 0.01 {
+			speed += 0.06
+		} else if minecartBlocked(tx, railPos, first) {
+			// A minecart standing still on a powered rail is pushed away
+			// from a solid block at one of the ends of the rail.
+			dir, speed = directionVec(second), 0.02
+		} else if minecartBlocked(tx, railPos, second) {
+			dir, speed = directionVec(first), 0.02
+		}
+	case block.ActivatorRail:
+		if rail.Powered {
+			for _, rider := range e.H().Riders() {
+				if ent, ok := rider.Entity(tx); ok {
+					tx.Dismount(ent)
+				}
+			}
+		}
+	case block.DetectorRail:
+		rail.EntityInside(railPos, tx, e)
+	}
+	friction := 0.96
+	if len(e.H().Riders()) > 0 {
+		friction = 0.997
+	}
+	vel = dir.Mul(min(speed*friction, b.conf.MaxSpeed))
+
+	// Move the minecart onto the line of the rail before moving it along the
+	// rail.
+	flat := mgl64.Vec3{pos[0], start[1], pos[2]}
+	onLine := start.Add(line.Mul(flat.Sub(start).Dot(line)))
+	newPos := onLine.Add(vel)
+	if height, ok := minecartRailHeight(tx, newPos, railPos[1]); ok {
+		newPos[1] = height
+	} else if next := cube.PosFromVec3(newPos.Add(mgl64.Vec3{0, 0.1})); len(tx.Block(next).Model().BBox(next, tx)) > 0 {
+		// The minecart reached the end of the rails and hit a block.
+		newPos, vel = onLine, mgl64.Vec3{}
+		newPos[1] = pos[1]
+	} else {
+		newPos[1] = pos[1]
+	}
+
+	rot := e.data.Rot
+	if speed > 0.001 {
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-vel[0], vel[2])) + 90, rot.Pitch()}
+	}
+	m := &Movement{v: tx.Viewers(pos), e: e, pos: newPos, vel: vel, dpos: newPos.Sub(pos), dvel: vel.Sub(e.data.Vel), rot: rot, onGround: true}
+	e.data.Pos, e.data.Vel, e.data.Rot = newPos, vel, rot
+	return m
+}
+
+// push pushes the minecart away from living entities that walk into it.
+func (b *MinecartBehaviour) push(e *Ent, tx *world.Tx) {
+	box := MinecartType.BBox(e).Translate(e.data.Pos)
+	for other := range tx.EntitiesWithin(box.Grow(1)) {
+		if _, living := other.(Living); !living || other.H() == e.H() {
+			continue
+		}
+		if v, riding := other.H().Vehicle(); riding && v == e.H() {
+			continue
+		}
+		if !other.H().Type().BBox(other).Translate(other.Position()).IntersectsWith(box) {
+			continue
+		}
+		diff := e.data.Pos.Sub(other.Position())
+		if diff[1] = 0; diff.Len() > 0.01 {
+			e.data.Vel = e.data.Vel.Add(diff.Normalize().Mul(0.05))
+		}
+	}
+}
+
+// minecartRail returns the rail at the position passed along with its shape.
+// False is returned if there is no rail at the position.
+func minecartRail(tx *world.Tx, pos cube.Pos) (world.Block, block.RailShape, bool) {
+	switch r := tx.Block(pos).(type) {
+	case block.Rail:
+		return r, r.Shape, true
+	case block.PoweredRail:
+		return r, r.Shape, true
+	case block.DetectorRail:
+		return r, r.Shape, true
+	case block.ActivatorRail:
+		return r, r.Shape, true
+	}
+	return nil, block.RailShape{}, false
+}
+
+// minecartRailHeight returns the height of a minecart on the rail at the X and
+// Z of the position passed. Rails at the Y passed and one block above and
+// below are checked. False is returned if there is no rail.
+func minecartRailHeight(tx *world.Tx, pos mgl64.Vec3, y int) (float64, bool) {
+	x, z := int(math.Floor(pos[0])), int(math.Floor(pos[2]))
+	for _, railY := range [...]int{y, y + 1, y - 1} {
+		railPos := cube.Pos{x, railY, z}
+		_, shape, ok := minecartRail(tx, railPos)
+		if !ok {
+			continue
+		}
+		height := float64(railY) + 0.0625
+		if up, ascending := shape.Ascending(); ascending {
+			d := directionVec(up)
+			bottom := railPos.Vec3Middle().Sub(d.Mul(0.5))
+			height += max(min(pos.Sub(bottom).Dot(d), 1), 0)
+		}
+		return height, true
+	}
+	return 0, false
+}
+
+// minecartBlocked checks if the block next to the rail at the position passed
+// in the direction passed is solid.
+func minecartBlocked(tx *world.Tx, railPos cube.Pos, d cube.Direction) bool {
+	side := railPos.Side(d.Face())
+	return tx.Block(side).Model().FaceSolid(side, d.Opposite().Face(), tx)
+}
+
+// directionVec returns a Vec3 of length 1 pointing in the direction passed.
+func directionVec(d cube.Direction) mgl64.Vec3 {
+	return cube.Pos{}.Side(d.Face()).Vec3()
+}
+
+// MinecartType is a world.EntityType implementation for minecarts.
+var MinecartType minecartType
+
+type minecartType struct{}
+
+func (minecartType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Minecart{Ent: Open(tx, handle, data)}
+}
+
+func (minecartType) EncodeEntity() string   { return "minecraft:minecart" }
+func (minecartType) NetworkOffset() float64 { return 0.35 }
+func (minecartType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.49, 0, -0.49, 0.49, 0.7, 0.49)
+}
+
+func (minecartType) DecodeNBT(_ map[string]any, data *world.EntityData) {
+	data.Data = MinecartBehaviourConfig{}.New()
+}
+
+func (minecartType) EncodeNBT(*world.EntityData) map[string]any {
+	return map[string]any{}
+}
@@ -0,0 +1,398 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Mob is a living entity that is controlled by the server, such as an NPC or a
+// vanilla mob. Its behaviour is implemented by a MobBehaviour, which ticks its
+// goals, navigation and movement. A world.EntityType for a mob should return a
+// Mob from its Open method, for example:
+//
+//	func (zombieType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+//		return &entity.Mob{Ent: entity.Open(tx, handle, data)}
+//	}
+type Mob struct {
+	*Ent
+}
+
+// behaviour returns the MobBehaviour of the Mob.
+func (m *Mob) behaviour() *MobBehaviour {
+	return m.data.Data.(*MobBehaviour)
+}
+
+// MobBehaviour returns the MobBehaviour of the Mob.
+func (m *Mob) MobBehaviour() *MobBehaviour {
+	return m.behaviour()
+}
+
+// Health returns the current health of the Mob.
+func (m *Mob) Health() float64 {
+	return m.behaviour().health.Health()
+}
+
+// MaxHealth returns the maximum health of the Mob.
+func (m *Mob) MaxHealth() float64 {
+	return m.behaviour().health.MaxHealth()
+}
+
+// SetMaxHealth changes the maximum health of the Mob. If the current health of
+// the Mob is higher than the new maximum health, the health is set to the new
+// maximum.
+func (m *Mob) SetMaxHealth(v float64) {
+	m.behaviour().health.SetMaxHealth(v)
+}
+
+// Dead checks if the Mob is dead.
+func (m *Mob) Dead() bool {
+	return m.Health() <= mgl64.Epsilon
+}
+
+// Hurt hurts the Mob for a given amount of damage. The damage is reduced by
+// the armour of the Mob and its Resistance effect. If the Mob was hurt less
+// than half a second ago, only the damage exceeding the previous damage is
+// dealt. If the Mob runs out of health, it is killed.
+func (m *Mob) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
+	b := m.behaviour()
+	if _, ok := m.Effect(effect.FireResistance); (ok && src.Fire()) || m.Dead() || dmg < 0 {
+		return 0, false
+	}
+	totalDamage := m.FinalDamageFrom(dmg, src)
+	damageLeft := totalDamage
+
+	if m.Age() < b.immuneUntil {
+		if damageLeft = damageLeft - b.lastDamage; damageLeft <= 0 {
+			return 0, false
+		}
+	}
+	b.immuneUntil, b.lastDamage = m.Age()+time.Second/2, totalDamage
+	b.health.AddHealth(-damageLeft)
+
+	if src.ReducedByArmour() {
+		b.armour.Damage(dmg, m.damageItem)
+	}
+	if attacker := damageOrigin(src); attacker != nil && attacker.H() != m.H() {
+		b.lastAttacker, b.lastAttackedAt = attacker.H(), m.Age()
+	}
+	for _, v := range m.tx.Viewers(m.Position()) {
+		v.ViewEntityAction(m, HurtAction{})
+	}
+	if m.Dead() {
+		m.kill(src)
+	}
+	return totalDamage, true
+}
+
+// FinalDamageFrom resolves the final damage received by the Mob if it is
+// attacked by the source passed with the damage passed. FinalDamageFrom takes
+// into account things such as the armour worn and the Resistance effect.
+func (m *Mob) FinalDamageFrom(dmg float64, src world.DamageSource) float64 {
+	dmg = max(dmg, 0)
+
+	dmg -= m.Armour().DamageReduction(dmg, src)
+	if res, ok := m.Effect(effect.Resistance); ok {
+		dmg *= effect.Resistance.Multiplier(src, res.Level())
+	}
+	return dmg
+}
+
+// damageOrigin returns the entity that caused the damage source passed, or nil
+// if no entity caused it.
+func damageOrigin(src world.DamageSource) world.Entity {
+	switch s := src.(type) {
+	case AttackDamageSource:
+		return s.Attacker
+	case ProjectileDamageSource:
+		return s.Owner
+	}
+	return nil
+}
+
+// damageItem damages the item stack passed with the damage passed and returns
+// the new stack.
+func (m *Mob) damageItem(s item.Stack, d int) item.Stack {
+	if d == 0 || s.MaxDurability() == -1 {
+		return s
+	}
+	if e, ok := s.Enchantment(enchantment.Unbreaking); ok {
+		d = enchantment.Unbreaking.Reduce(s.Item(), e.Level(), d)
+	}
+	if s = s.Damage(d); s.Empty() {
+		m.tx.PlaySound(m.Position(), sound.ItemBreak{})
+	}
+	return s
+}
+
+// Heal heals the Mob for a given amount of health. Heal does nothing if the Mob
+// is dead or if the health passed is negative.
+func (m *Mob) Heal(health float64, _ world.HealingSource) {
+	if m.Dead() || health < 0 {
+		return
+	}
+	m.behaviour().health.AddHealth(health)
+}
+
+// kill kills the Mob, dropping its items and experience.
+func (m *Mob) kill(src world.DamageSource) {
+	b := m.behaviour()
+	for _, v := range m.tx.Viewers(m.Position()) {
+		v.ViewEntityAction(m, DeathAction{})
+	}
+	b.goals.stop(m, m.tx)
+	b.targets.stop(m, m.tx)
+	b.nav.Stop()
+	b.target = nil
+
+	pos := m.Position()
+	if b.conf.Drops != nil {
+		for _, it := range b.conf.Drops(m, src) {
+			opts := world.EntitySpawnOpts{Position: pos, Velocity: mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1}}
+			m.tx.AddEntity(NewItem(opts, it))
+		}
+	}
+	if b.conf.Experience > 0 && b.lastAttacker != nil && m.Age()-b.lastAttackedAt < time.Second*5 {
+		for _, orb := range NewExperienceOrbs(pos, b.conf.Experience) {
+			m.tx.AddEntity(orb)
+		}
+	}
+	for _, e := range m.Effects() {
+		m.RemoveEffect(e.Type())
+	}
+	if b.conf.Death != nil {
+		b.conf.Death(m, src)
+	}
+}
+
+// fall is called when the Mob hits the ground after falling.
+func (m *Mob) fall(distance float64) {
+	pos := cube.PosFromVec3(m.Position())
+	b := m.tx.Block(pos)
+	if len(b.Model().BBox(pos, m.tx)) == 0 {
+		pos = pos.Sub(cube.Pos{0, 1})
+		b = m.tx.Block(pos)
+	}
+	if h, ok := b.(block.EntityLander); ok {
+		h.EntityLand(pos, m.tx, m, &distance)
+	}
+	dmg := distance - 3
+	if boost, ok := m.Effect(effect.JumpBoost); ok {
+		dmg -= float64(boost.Level())
+	}
+	if dmg < 0.5 {
+		return
+	}
+	m.Hurt(math.Ceil(dmg), FallDamageSource{})
+}
+
+// KnockBack knocks the Mob back with a given force and height. The knock back
+// is reduced by the knock back resistance of the armour of the Mob.
+func (m *Mob) KnockBack(src mgl64.Vec3, force, height float64) {
+	if m.Dead() {
+		return
+	}
+	velocity := m.Position().Sub(src)
+	velocity[1] = 0
+
+	if velocity.Len() != 0 {
+		velocity = velocity.Normalize().Mul(force)
+	}
+	velocity[1] = height
+
+	m.SetVelocity(velocity.Mul(1 - m.Armour().KnockBackResistance()))
+}
+
+// AddEffect adds an effect.Effect to the Mob. If the effect is instant, it is
+// applied immediately. If not, it is applied every tick until it expires.
+func (m *Mob) AddEffect(e effect.Effect) {
+	m.behaviour().effects.Add(e, m)
+	m.updateState()
+}
+
+// RemoveEffect removes any effect that might currently be active on the Mob.
+func (m *Mob) RemoveEffect(e effect.Type) {
+	m.behaviour().effects.Remove(e, m)
+	m.updateState()
+}
+
+// Effect returns the effect instance and true if the Mob has the effect. If
+// not found, it will return an empty effect instance and false.
+func (m *Mob) Effect(e effect.Type) (effect.Effect, bool) {
+	return m.behaviour().effects.Effect(e)
+}
+
+// Effects returns any effect currently applied to the Mob.
+func (m *Mob) Effects() []effect.Effect {
+	return m.behaviour().effects.Effects()
+}
+
+// updateState updates the state of the Mob for all of its viewers.
+func (m *Mob) updateState() {
+	for _, v := range m.tx.Viewers(m.Position()) {
+		v.ViewEntityState(m)
+	}
+}
+
+// Speed returns the speed of the Mob, which is the horizontal velocity that it
+// moves at while walking.
+func (m *Mob) Speed() float64 {
+	return m.behaviour().speed
+}
+
+// SetSpeed changes the speed of the Mob.
+func (m *Mob) SetSpeed(v float64) {
+	m.behaviour().speed = v
+}
+
+// HeldItems returns the items held by the Mob in its main hand and off-hand.
+func (m *Mob) HeldItems() (mainHand, offHand item.Stack) {
+	b := m.behaviour()
+	return b.mainHand, b.offHand
+}
+
+// SetHeldItems changes the items held by the Mob in its main hand and
+// off-hand.
+func (m *Mob) SetHeldItems(mainHand, offHand item.Stack) {
+	b := m.behaviour()
+	b.mainHand, b.offHand = mainHand, offHand
+	for _, v := range m.tx.Viewers(m.Position()) {
+		v.ViewEntityItems(m)
+	}
+}
+
+// Armour returns the armour inventory of the Mob. Changes to the armour are
+// shown to viewers of the Mob.
+func (m *Mob) Armour() *inventory.Armour {
+	return m.behaviour().armour
+}
+
+// EyeHeight returns the height of the eyes of the Mob relative to its
+// position.
+func (m *Mob) EyeHeight() float64 {
+	if h := m.behaviour().conf.EyeHeight; h != 0 {
+		return h
+	}
+	return m.H().Type().BBox(m).Height() * 0.85
+}
+
+// OnGround checks if the Mob is currently standing on the ground.
+func (m *Mob) OnGround() bool {
+	return m.behaviour().mc.OnGround()
+}
+
+// Jump makes the Mob jump if it is currently on the ground.
+func (m *Mob) Jump() {
+	if !m.OnGround() {
+		return
+	}
+	jumpVel := 0.42
+	if e, ok := m.Effect(effect.JumpBoost); ok {
+		jumpVel += float64(e.Level()) / 10
+	}
+	// Gravity is applied before the Mob moves, so it is added to the jump
+	// velocity to reach the same height as a player.
+	m.data.Vel[1] = jumpVel + m.behaviour().conf.Gravity
+}
+
+// Navigator returns the Navigator that moves the Mob along paths.
+func (m *Mob) Navigator() *Navigator {
+	return m.behaviour().nav
+}
+
+// PathFinder returns a PathFinder that finds paths for the Mob.
+func (m *Mob) PathFinder() PathFinder {
+	return PathFinder{Box: m.H().Type().BBox(m)}
+}
+
+// Target returns the entity that the Mob is currently targeting, such as the
+// entity it is attacking. False is returned if the Mob has no target or if the
+// target is no longer in the same world.
+func (m *Mob) Target() (world.Entity, bool) {
+	b := m.behaviour()
+	if b.target == nil {
+		return nil, false
+	}
+	return b.target.Entity(m.tx)
+}
+
+// SetTarget changes the target of the Mob. Passing nil clears the target.
+func (m *Mob) SetTarget(e world.Entity) {
+	if e == nil {
+		m.behaviour().target = nil
+		return
+	}
+	m.behaviour().target = e.H()
+}
+
+// LastAttacker returns the entity that last attacked the Mob along with the
+// time that passed since the attack. False is returned if the Mob was never
+// attacked or if the attacker is no longer in the same world.
+func (m *Mob) LastAttacker() (world.Entity, time.Duration, bool) {
+	b := m.behaviour()
+	if b.lastAttacker == nil {
+		return nil, 0, false
+	}
+	e, ok := b.lastAttacker.Entity(m.tx)
+	return e, m.Age() - b.lastAttackedAt, ok
+}
+
+// LookAt rotates the Mob so that it looks at the position passed.
+func (m *Mob) LookAt(pos mgl64.Vec3) {
+	diff := pos.Sub(EyePosition(m))
+	horizontal := math.Hypot(diff[0], diff[2])
+	if horizontal < mgl64.Epsilon && math.Abs(diff[1]) < mgl64.Epsilon {
+		return
+	}
+	m.data.Rot = cube.Rotation{
+		mgl64.RadToDeg(math.Atan2(-diff[0], diff[2])),
+		mgl64.RadToDeg(math.Atan2(-diff[1], horizontal)),
+	}
+}
+
+// AttackEntity makes the Mob attack the entity passed. The damage dealt
+// depends on the attack damage of the Mob, the item held in its main hand and
+// its effects. AttackEntity returns false if the entity could not be attacked.
+func (m *Mob) AttackEntity(e world.Entity) bool {
+	living, ok := e.(Living)
+	if !ok || living.Dead() || m.Dead() {
+		return false
+	}
+	for _, v := range m.tx.Viewers(m.Position()) {
+		v.ViewEntityAction(m, SwingArmAction{})
+	}
+	held, _ := m.HeldItems()
+
+	dmg := m.behaviour().conf.AttackDamage + held.AttackDamage() - 1
+	if strength, ok := m.Effect(effect.Strength); ok {
+		dmg += dmg * effect.Strength.Multiplier(strength.Level())
+	}
+	if weakness, ok := m.Effect(effect.Weakness); ok {
+		dmg -= dmg * effect.Weakness.Multiplier(weakness.Level())
+	}
+	if s, ok := held.Enchantment(enchantment.Sharpness); ok {
+		dmg += enchantment.Sharpness.Addend(s.Level())
+	}
+	if _, vulnerable := living.Hurt(dmg, AttackDamageSource{Attacker: m}); !vulnerable {
+		return true
+	}
+	living.KnockBack(m.Position(), 0.4, 0.4)
+	if f, ok := held.Enchantment(enchantment.FireAspect); ok {
+		if flammable, ok := living.(Flammable); ok {
+			flammable.SetOnFire(enchantment.FireAspect.Duration(f.Level()))
+		}
+	}
+	if !held.Empty() {
+		m.SetHeldItems(m.damageItem(held, 1), m.behaviour().offHand)
+	}
+	return true
+}
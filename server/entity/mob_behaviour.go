@@ -0,0 +1,225 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"time"
+)
+
+// MobBehaviourConfig holds optional parameters for a MobBehaviour.
+type MobBehaviourConfig struct {
+	// MaxHealth is the maximum health of the mob. If 0, the mob has a maximum
+	// health of 20.
+	MaxHealth float64
+	// Speed is the horizontal velocity of the mob while it is walking. If 0,
+	// a speed of 0.25 is used.
+	Speed float64
+	// AttackDamage is the damage that the mob deals when attacking another
+	// entity without holding a weapon. If 0, the mob deals 2 damage.
+	AttackDamage float64
+	// EyeHeight is the height of the eyes of the mob, relative to its
+	// position. If 0, the eyes are at 85% of the height of the bounding box of
+	// the mob.
+	EyeHeight float64
+	// Gravity is the amount of Y velocity subtracted every tick. If 0, a
+	// gravity of 0.08 is used.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick. Velocity is
+	// multiplied with (1-Drag) every tick. If 0, a drag of 0.02 is used.
+	Drag float64
+	// Goals is called when the mob is created to add goals to its goal
+	// selector and target selector. Goals are stateful, so Goals should create
+	// new goals every time it is called.
+	Goals func(goals, targets *GoalSelector)
+	// Drops returns the items dropped by the mob when it dies.
+	Drops func(m *Mob, src world.DamageSource) []item.Stack
+	// Experience is the amount of experience dropped by the mob when it is
+	// killed by a player.
+	Experience int
+	// Tick is called for every tick that the mob is alive. Tick is called
+	// after the goals of the mob are ticked and before the mob moves.
+	Tick func(m *Mob, tx *world.Tx)
+	// Death is called when the mob dies.
+	Death func(m *Mob, src world.DamageSource)
+}
+
+// Apply ...
+func (conf MobBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a MobBehaviour using the parameters in conf.
+func (conf MobBehaviourConfig) New() *MobBehaviour {
+	if conf.MaxHealth == 0 {
+		conf.MaxHealth = 20
+	}
+	if conf.Speed == 0 {
+		conf.Speed = 0.25
+	}
+	if conf.AttackDamage == 0 {
+		conf.AttackDamage = 2
+	}
+	if conf.Gravity == 0 {
+		conf.Gravity = 0.08
+	}
+	if conf.Drag == 0 {
+		conf.Drag = 0.02
+	}
+	b := &MobBehaviour{
+		conf:    conf,
+		mc:      &MovementComputer{Gravity: conf.Gravity, Drag: conf.Drag},
+		health:  NewHealthManager(conf.MaxHealth, conf.MaxHealth),
+		effects: NewEffectManager(),
+		speed:   conf.Speed,
+		goals:   NewGoalSelector(),
+		targets: NewGoalSelector(),
+		nav:     &Navigator{},
+	}
+	b.armour = inventory.NewArmour(func(_ int, _, _ item.Stack) {
+		b.armourChanged = true
+	})
+	if conf.Goals != nil {
+		conf.Goals(b.goals, b.targets)
+	}
+	return b
+}
+
+// MobBehaviour implements the behaviour of a living Mob. It manages the
+// health, equipment and effects of the mob and ticks its goals, navigation and
+// movement.
+type MobBehaviour struct {
+	conf MobBehaviourConfig
+	mc   *MovementComputer
+
+	health  *HealthManager
+	effects *EffectManager
+	speed   float64
+	rot     cube.Rotation
+
+	armour                  *inventory.Armour
+	mainHand, offHand       item.Stack
+	armourChanged           bool
+	goals, targets          *GoalSelector
+	nav                     *Navigator
+	target, lastAttacker    *world.EntityHandle
+	lastAttackedAt          time.Duration
+	immuneUntil             time.Duration
+	lastDamage              float64
+	fallDistance            float64
+	deathTicks, attackTicks int
+}
+
+// Goals returns the GoalSelector holding the goals of the mob, such as
+// wandering around or attacking its target.
+func (b *MobBehaviour) Goals() *GoalSelector {
+	return b.goals
+}
+
+// Targets returns the GoalSelector holding the goals that select the target
+// of the mob.
+func (b *MobBehaviour) Targets() *GoalSelector {
+	return b.targets
+}
+
+// Explode hurts the mob and knocks it away from the explosion's source.
+func (b *MobBehaviour) Explode(e *Ent, src mgl64.Vec3, impact float64, conf block.ExplosionConfig) {
+	m := &Mob{Ent: e}
+	diff := e.Position().Sub(src)
+	m.Hurt(math.Floor((impact*impact+impact)*3.5*conf.Size*2+1), ExplosionDamageSource{})
+	m.KnockBack(src, impact, diff[1]/diff.Len()*impact)
+}
+
+// Tick implements the behaviour of a mob. Its goals and navigation are
+// ticked, after which the mob moves.
+func (b *MobBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	m := &Mob{Ent: e}
+	if m.Dead() {
+		if b.deathTicks++; b.deathTicks >= 20 {
+			// Give viewers enough time to display the death animation of the
+			// mob before removing it.
+			_ = e.Close()
+		}
+		return nil
+	}
+	b.effects.Tick(m, tx)
+	if e.OnFireDuration() > 0 && e.Age()%time.Second == 0 {
+		m.Hurt(1, block.FireDamageSource{})
+	}
+	if b.armourChanged {
+		b.armourChanged = false
+		for _, v := range tx.Viewers(e.data.Pos) {
+			v.ViewEntityArmour(m)
+		}
+	}
+	if m.Dead() {
+		return nil
+	}
+	b.attackTicks = max(b.attackTicks-1, 0)
+
+	b.targets.tick(m, tx)
+	b.goals.tick(m, tx)
+	b.nav.tick(m, tx)
+	if b.conf.Tick != nil {
+		b.conf.Tick(m, tx)
+	}
+
+	mv := b.mc.TickMovement(m, e.data.Pos, e.data.Vel, e.data.Rot, tx)
+	e.data.Pos, e.data.Vel = mv.pos, mv.vel
+
+	if b.mc.OnGround() {
+		if b.fallDistance > 0 {
+			m.fall(b.fallDistance)
+			b.fallDistance = 0
+		}
+	} else {
+		b.fallDistance = math.Max(b.fallDistance-mv.dpos[1], 0)
+	}
+	if mv.dpos.ApproxEqualThreshold(zeroVec3, epsilon) && mv.rot != b.rot {
+		// The Movement is not sent if the mob did not move, but viewers should
+		// still see the mob turn its head.
+		for _, v := range mv.v {
+			v.ViewEntityMovement(m, mv.pos, mv.rot, mv.onGround)
+		}
+	}
+	b.rot = mv.rot
+	return mv
+}
+
+// EncodeNBT encodes the health and equipment of the mob to a map that may be
+// stored as NBT. It may be used by custom world.EntityType implementations.
+func (b *MobBehaviour) EncodeNBT() map[string]any {
+	m := map[string]any{
+		"Health": float32(b.health.Health()),
+		"Armor":  nbtconv.InvToNBT(b.armour.Inventory()),
+	}
+	if !b.mainHand.Empty() {
+		m["Mainhand"] = []map[string]any{nbtconv.WriteItem(b.mainHand, true)}
+	}
+	if !b.offHand.Empty() {
+		m["Offhand"] = []map[string]any{nbtconv.WriteItem(b.offHand, true)}
+	}
+	return m
+}
+
+// DecodeNBT decodes the health and equipment of the mob from a map obtained by
+// decoding NBT. It may be used by custom world.EntityType implementations.
+func (b *MobBehaviour) DecodeNBT(m map[string]any) {
+	if _, ok := m["Health"]; ok {
+		b.health.AddHealth(float64(nbtconv.Float32(m, "Health")) - b.health.Health())
+	}
+	nbtconv.InvFromNBT(b.armour.Inventory(), nbtconv.Slice(m, "Armor"))
+	if items := nbtconv.Slice(m, "Mainhand"); len(items) > 0 {
+		data, _ := items[0].(map[string]any)
+		b.mainHand = nbtconv.Item(data, nil)
+	}
+	if items := nbtconv.Slice(m, "Offhand"); len(items) > 0 {
+		data, _ := items[0].(map[string]any)
+		b.offHand = nbtconv.Item(data, nil)
+	}
+}
@@ -0,0 +1,141 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// Navigator moves a Mob along a Path found by a PathFinder. A Navigator is
+// obtained by calling Mob.Navigator.
+type Navigator struct {
+	path  *Path
+	speed float64
+
+	target    *world.EntityHandle
+	targetPos mgl64.Vec3
+	repath    int
+
+	lastPos    mgl64.Vec3
+	stuckTicks int
+}
+
+// MoveTo finds a path to the position passed and starts moving the Mob along
+// it. The speed passed is multiplied with the speed of the Mob. False is
+// returned if no path could be found, in which case the Mob does not move.
+func (n *Navigator) MoveTo(m *Mob, tx *world.Tx, pos mgl64.Vec3, speed float64) bool {
+	n.target = nil
+	return n.moveTo(m, tx, pos, speed)
+}
+
+// MoveToEntity finds a path to the entity passed and starts moving the Mob
+// along it. The path is updated if the entity moves. The speed passed is
+// multiplied with the speed of the Mob. False is returned if no path could be
+// found, in which case the Mob does not move.
+func (n *Navigator) MoveToEntity(m *Mob, tx *world.Tx, e world.Entity, speed float64) bool {
+	if !n.moveTo(m, tx, e.Position(), speed) {
+		return false
+	}
+	n.target, n.targetPos, n.repath = e.H(), e.Position(), 0
+	return true
+}
+
+// moveTo finds a path to the position passed and sets it as the path of the
+// Navigator.
+func (n *Navigator) moveTo(m *Mob, tx *world.Tx, pos mgl64.Vec3, speed float64) bool {
+	path, ok := m.PathFinder().FindPath(tx, m.Position(), pos)
+	if !ok {
+		n.Stop()
+		return false
+	}
+	n.path, n.speed, n.stuckTicks = path, speed, 0
+	return true
+}
+
+// Path returns the Path that the Navigator is currently following, or nil if
+// it is not following a path.
+func (n *Navigator) Path() *Path {
+	return n.path
+}
+
+// Idle checks if the Navigator is currently not following a path.
+func (n *Navigator) Idle() bool {
+	return n.path == nil
+}
+
+// Stop stops following the current path.
+func (n *Navigator) Stop() {
+	n.path, n.target = nil, nil
+}
+
+// tick moves the Mob passed towards the next point of the path followed.
+func (n *Navigator) tick(m *Mob, tx *world.Tx) {
+	if n.target != nil {
+		n.followTarget(m, tx)
+	}
+	if n.path == nil {
+		return
+	}
+	pos, width := m.Position(), m.H().Type().BBox(m).Width()
+	for !n.path.Finished() {
+		diff := n.path.Current().Sub(pos)
+		if math.Hypot(diff[0], diff[2]) > math.Max(width/2, 0.35) || math.Abs(diff[1]) >= 1 {
+			break
+		}
+		n.path.Advance()
+	}
+	if n.path.Finished() {
+		if n.target == nil {
+			n.Stop()
+		}
+		return
+	}
+	if n.stuck(pos) {
+		n.Stop()
+		return
+	}
+
+	next := n.path.Current()
+	diff := next.Sub(pos)
+	diff[1] = 0
+	dir := diff.Normalize()
+	vel := m.Velocity()
+	vel[0], vel[2] = dir[0]*m.Speed()*n.speed, dir[2]*m.Speed()*n.speed
+	m.SetVelocity(vel)
+	m.data.Rot[0] = mgl64.RadToDeg(math.Atan2(-dir[0], dir[2]))
+
+	if next[1]-pos[1] > 0.05 && diff.Len() < width/2+1 {
+		m.Jump()
+	}
+}
+
+// followTarget updates the path of the Navigator if the entity it is moving to
+// has moved away from the end of the path.
+func (n *Navigator) followTarget(m *Mob, tx *world.Tx) {
+	e, ok := n.target.Entity(tx)
+	if !ok {
+		n.Stop()
+		return
+	}
+	if n.repath--; n.repath > 0 {
+		return
+	}
+	n.repath = 10
+	if e.Position().Sub(n.targetPos).Len() > 1 || n.path == nil || n.path.Finished() {
+		target := n.target
+		if n.moveTo(m, tx, e.Position(), n.speed) {
+			n.target, n.targetPos = target, e.Position()
+		}
+	}
+}
+
+// stuck checks if the Mob has not moved for some time while following its
+// path.
+func (n *Navigator) stuck(pos mgl64.Vec3) bool {
+	if pos.Sub(n.lastPos).Len() > 0.01 {
+		n.lastPos, n.stuckTicks = pos, 0
+		return false
+	}
+	n.stuckTicks++
+	return n.stuckTicks > 40
+}
@@ -0,0 +1,268 @@
+package entity
+
+import (
+	"container/heap"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// PathFinder finds paths that an entity with a specific bounding box is able
+// to walk along. Paths are found using A* search over the collision boxes of
+// the blocks in a world.Tx.
+type PathFinder struct {
+	// Box is the bounding box of the entity, relative to its position, that
+	// the path is found for.
+	Box cube.BBox
+	// MaxNodes is the maximum amount of positions visited while searching for
+	// a path. If 0, at most 512 positions are visited.
+	MaxNodes int
+	// MaxFall is the maximum amount of blocks that the entity is allowed to
+	// drop down at once. If 0, the entity drops at most 3 blocks.
+	MaxFall int
+}
+
+// maxStepHeight is the maximum height that an entity is able to jump onto.
+const maxStepHeight = 1.25
+
+// Path is a path found by a PathFinder. It consists of a list of points that
+// are followed in order.
+type Path struct {
+	points   []mgl64.Vec3
+	index    int
+	complete bool
+}
+
+// Points returns all points of the Path.
+func (p *Path) Points() []mgl64.Vec3 {
+	return p.points
+}
+
+// Current returns the point of the Path that is currently being moved to.
+func (p *Path) Current() mgl64.Vec3 {
+	return p.points[min(p.index, len(p.points)-1)]
+}
+
+// Destination returns the last point of the Path.
+func (p *Path) Destination() mgl64.Vec3 {
+	return p.points[len(p.points)-1]
+}
+
+// Advance moves on to the next point of the Path.
+func (p *Path) Advance() {
+	p.index++
+}
+
+// Finished checks if all points of the Path have been reached.
+func (p *Path) Finished() bool {
+	return p.index >= len(p.points)
+}
+
+// Complete checks if the Path leads to the destination that was requested. If
+// the destination could not be reached, the Path leads to the reachable
+// position closest to it and Complete returns false.
+func (p *Path) Complete() bool {
+	return p.complete
+}
+
+// pathNode is a position visited by the PathFinder.
+type pathNode struct {
+	pos cube.Pos
+	// y is the exact height that an entity stands at in this node.
+	y          float64
+	cost, heur float64
+	parent     *pathNode
+	index      int
+	closed     bool
+}
+
+// FindPath finds a path from the start position to the end position. If no
+// path to the end position exists, a path to the reachable position closest to
+// it is returned. False is returned if no path could be found at all.
+func (f PathFinder) FindPath(tx *world.Tx, start, end mgl64.Vec3) (*Path, bool) {
+	if f.MaxNodes == 0 {
+		f.MaxNodes = 512
+	}
+	if f.MaxFall == 0 {
+		f.MaxFall = 3
+	}
+	endPos := cube.PosFromVec3(end)
+	startPos := cube.PosFromVec3(start)
+	first := &pathNode{pos: startPos, y: start[1]}
+	first.heur = f.heuristic(first.pos, endPos)
+
+	nodes := map[cube.Pos]*pathNode{startPos: first}
+	open := &pathQueue{first}
+	closest := first
+
+	for visited := 0; open.Len() > 0 && visited < f.MaxNodes; visited++ {
+		n := heap.Pop(open).(*pathNode)
+		n.closed = true
+		if n.heur < closest.heur {
+			closest = n
+		}
+		if n.pos == endPos {
+			return f.path(n, true), true
+		}
+		for _, next := range f.neighbours(tx, n) {
+			existing, ok := nodes[next.pos]
+			if ok && (existing.closed || existing.cost <= next.cost) {
+				continue
+			}
+			next.parent, next.heur = n, f.heuristic(next.pos, endPos)
+			if ok {
+				existing.cost, existing.y, existing.parent = next.cost, next.y, n
+				heap.Fix(open, existing.index)
+				continue
+			}
+			nodes[next.pos] = next
+			heap.Push(open, next)
+		}
+	}
+	if closest == first {
+		return nil, false
+	}
+	return f.path(closest, false), true
+}
+
+// heuristic returns the estimated cost of moving from a position to another.
+func (f PathFinder) heuristic(a, b cube.Pos) float64 {
+	return a.Vec3().Sub(b.Vec3()).Len()
+}
+
+// path creates a Path that ends at the node passed.
+func (f PathFinder) path(n *pathNode, complete bool) *Path {
+	var points []mgl64.Vec3
+	for ; n.parent != nil; n = n.parent {
+		points = append(points, mgl64.Vec3{float64(n.pos[0]) + 0.5, n.y, float64(n.pos[2]) + 0.5})
+	}
+	for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+		points[i], points[j] = points[j], points[i]
+	}
+	return &Path{points: points, complete: complete}
+}
+
+// neighbours returns all nodes that may be reached by walking, jumping or
+// falling from the node passed.
+func (f PathFinder) neighbours(tx *world.Tx, n *pathNode) []*pathNode {
+	neighbours := make([]*pathNode, 0, 8)
+	var passable [4]bool
+	for i, dir := range [...][2]int{{1, 0}, {0, 1}, {-1, 0}, {0, -1}, {1, 1}, {-1, 1}, {-1, -1}, {1, -1}} {
+		if i >= 4 && (!passable[i-4] || !passable[(i-3)%4]) {
+			// Only walk diagonally if both adjacent sides are passable, so that
+			// the entity does not cut corners.
+			continue
+		}
+		next, ok := f.column(tx, n, dir[0], dir[1])
+		if i < 4 {
+			passable[i] = ok
+		}
+		if !ok {
+			continue
+		}
+		next.cost = n.cost + math.Hypot(float64(dir[0]), float64(dir[1]))
+		if next.y > n.y+0.5 {
+			next.cost += 0.5
+		} else if next.y < n.y-0.5 {
+			next.cost += (n.y - next.y) * 0.5
+		}
+		neighbours = append(neighbours, next)
+	}
+	return neighbours
+}
+
+// column finds the node that an entity at the node passed ends up at when
+// moving one block towards the x and z direction passed. False is returned if
+// the entity cannot move in that direction.
+func (f PathFinder) column(tx *world.Tx, n *pathNode, dx, dz int) (*pathNode, bool) {
+	for y := n.pos[1] + 1; y >= n.pos[1]-f.MaxFall; y-- {
+		pos := cube.Pos{n.pos[0] + dx, y, n.pos[2] + dz}
+		if pos.OutOfBounds(tx.Range()) {
+			return nil, false
+		}
+		standY, ok := f.support(tx, pos)
+		if !ok {
+			continue
+		}
+		if standY-n.y > maxStepHeight || f.avoid(tx, pos) {
+			return nil, false
+		}
+		// The entity must fit at the highest point of the movement, both at the
+		// new and at the current position.
+		highest := math.Max(standY, n.y)
+		if !f.fits(tx, pos, highest) || !f.fits(tx, n.pos, highest) {
+			return nil, false
+		}
+		return &pathNode{pos: pos, y: standY}, true
+	}
+	return nil, false
+}
+
+// support returns the height that an entity stands at if its feet are in the
+// block at the position passed. False is returned if there is nothing to stand
+// on in that block.
+func (f PathFinder) support(tx *world.Tx, pos cube.Pos) (float64, bool) {
+	y, ok := 0.0, false
+	for _, p := range [...]cube.Pos{pos.Side(cube.FaceDown), pos} {
+		for _, box := range tx.Block(p).Model().BBox(p, tx) {
+			top := float64(p[1]) + box.Max()[1]
+			if top >= float64(pos[1]) && top < float64(pos[1]+1) && (!ok || top > y) {
+				y, ok = top, true
+			}
+		}
+	}
+	return y, ok
+}
+
+// fits checks if the Box of the PathFinder fits in the column of the position
+// passed at a specific height without colliding with any blocks.
+func (f PathFinder) fits(tx *world.Tx, pos cube.Pos, y float64) bool {
+	box := f.Box.Translate(mgl64.Vec3{float64(pos[0]) + 0.5, y, float64(pos[2]) + 0.5})
+	for _, blockBox := range blockBBoxsAround(tx, box) {
+		if box.IntersectsWith(blockBox) {
+			return false
+		}
+	}
+	return true
+}
+
+// avoid checks if an entity should avoid standing in the block at the
+// position passed, for example because it would be hurt or drown.
+func (f PathFinder) avoid(tx *world.Tx, pos cube.Pos) bool {
+	for _, p := range [...]cube.Pos{pos, pos.Side(cube.FaceDown)} {
+		if _, ok := tx.Liquid(p); ok {
+			return true
+		}
+		switch tx.Block(p).(type) {
+		case block.Fire, block.Lava, block.Cactus, block.Campfire:
+			return true
+		}
+	}
+	return false
+}
+
+// pathQueue is a priority queue of nodes, implementing heap.Interface. Nodes
+// with the lowest estimated total cost are popped first.
+type pathQueue []*pathNode
+
+func (q pathQueue) Len() int { return len(q) }
+func (q pathQueue) Less(i, j int) bool {
+	return q[i].cost+q[i].heur < q[j].cost+q[j].heur
+}
+func (q pathQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index, q[j].index = i, j
+}
+func (q *pathQueue) Push(x any) {
+	n := x.(*pathNode)
+	n.index = len(*q)
+	*q = append(*q, n)
+}
+func (q *pathQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
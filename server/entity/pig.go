@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand/v2"
+)
+
+// NewPig creates a new pig.
+func NewPig(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(PigType, pigConf)
+}
+
+var pigConf = MobBehaviourConfig{
+	MaxHealth:  10,
+	Speed:      0.1,
+	EyeHeight:  0.6,
+	Experience: 2,
+	Goals:      animalGoals,
+	Drops: func(m *Mob, _ world.DamageSource) []item.Stack {
+		return []item.Stack{item.NewStack(item.Porkchop{Cooked: m.OnFireDuration() > 0}, 1+rand.IntN(3))}
+	},
+}
+
+// PigType is a world.EntityType implementation for pigs.
+var PigType pigType
+
+type pigType struct{}
+
+func (pigType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: Open(tx, handle, data)}
+}
+
+func (pigType) EncodeEntity() string { return "minecraft:pig" }
+func (pigType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.45, 0, -0.45, 0.45, 0.9, 0.45)
+}
+
+func (pigType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	b := pigConf.New()
+	b.DecodeNBT(m)
+	data.Data = b
+}
+
+func (pigType) EncodeNBT(data *world.EntityData) map[string]any {
+	return data.Data.(*MobBehaviour).EncodeNBT()
+}
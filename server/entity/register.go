@@ -12,7 +12,10 @@ import (
 var DefaultRegistry = conf.New([]world.EntityType{
 	AreaEffectCloudType,
 	ArrowType,
+	BoatType,
 	BottleOfEnchantingType,
+	ChestBoatType,
+	CowType,
 	EggType,
 	EnderPearlType,
 	ExperienceOrbType,
@@ -21,10 +24,13 @@ var DefaultRegistry = conf.New([]world.EntityType{
 	ItemType,
 	LightningType,
 	LingeringPotionType,
+	MinecartType,
+	PigType,
 	SnowballType,
 	SplashPotionType,
 	TNTType,
 	TextType,
+	ZombieType,
 })
 
 var conf = world.EntityRegistryConfig{
@@ -35,9 +41,15 @@ var conf = world.EntityRegistryConfig{
 	EnderPearl:         NewEnderPearl,
 	FallingBlock:       NewFallingBlock,
 	Lightning:          NewLightning,
+	Minecart:           NewMinecart,
+	Spawns:             spawns,
 	Firework: func(opts world.EntitySpawnOpts, firework world.Item, owner world.Entity, sidewaysVelocityMultiplier, upwardsAcceleration float64, attached bool) *world.EntityHandle {
 		return newFirework(opts, firework.(item.Firework), owner, sidewaysVelocityMultiplier, upwardsAcceleration, attached)
 	},
+	Boat: func(opts world.EntitySpawnOpts, boat world.Item) *world.EntityHandle {
+		b := boat.(item.Boat)
+		return NewBoat(opts, b.Type, b.Chest)
+	},
 	Item: func(opts world.EntitySpawnOpts, it any) *world.EntityHandle {
 		return NewItem(opts, it.(item.Stack))
 	},
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+)
+
+// monsterSpawns holds the hostile mobs that spawn naturally in most biomes of
+// the Overworld.
+var monsterSpawns = []world.SpawnEntry{
+	{Type: ZombieType, Category: world.SpawnCategoryMonster, Weight: 100, MinGroup: 4, MaxGroup: 4, New: NewZombie},
+}
+
+// creatureSpawns holds the animals that spawn naturally in grassy biomes of
+// the Overworld.
+var creatureSpawns = []world.SpawnEntry{
+	{Type: PigType, Category: world.SpawnCategoryCreature, Weight: 10, MinGroup: 4, MaxGroup: 4, New: NewPig, Condition: onGrass},
+	{Type: CowType, Category: world.SpawnCategoryCreature, Weight: 8, MinGroup: 4, MaxGroup: 4, New: NewCow, Condition: onGrass},
+}
+
+// landSpawns holds the mobs that spawn naturally in grassy biomes of the
+// Overworld.
+var landSpawns = append(append([]world.SpawnEntry(nil), creatureSpawns...), monsterSpawns...)
+
+// spawns returns the entities that spawn naturally in the world.Biome passed.
+func spawns(b world.Biome) []world.SpawnEntry {
+	switch b.(type) {
+	case biome.NetherWastes, biome.CrimsonForest, biome.WarpedForest, biome.SoulSandValley, biome.BasaltDeltas,
+		biome.End, biome.MushroomFields, biome.MushroomFieldShore, biome.DeepDark:
+		return nil
+	case biome.Ocean, biome.DeepOcean, biome.ColdOcean, biome.DeepColdOcean, biome.FrozenOcean, biome.DeepFrozenOcean,
+		biome.LegacyFrozenOcean, biome.LukewarmOcean, biome.DeepLukewarmOcean, biome.WarmOcean, biome.DeepWarmOcean,
+		biome.River, biome.FrozenRiver, biome.Beach, biome.SnowyBeach, biome.StonyShore, biome.Desert, biome.DesertHills,
+		biome.DesertLakes, biome.Badlands, biome.BadlandsPlateau, biome.ErodedBadlands, biome.ModifiedBadlandsPlateau,
+		biome.WoodedBadlandsPlateau, biome.ModifiedWoodedBadlandsPlateau, biome.FrozenPeaks, biome.JaggedPeaks,
+		biome.StonyPeaks, biome.SnowySlopes, biome.DripstoneCaves, biome.LushCaves:
+		return monsterSpawns
+	}
+	return landSpawns
+}
+
+// onGrass checks if the block below a position is grass, which animals need to
+// spawn.
+func onGrass(tx *world.Tx, pos cube.Pos) bool {
+	_, ok := tx.Block(pos.Side(cube.FaceDown)).(block.Grass)
+	return ok
+}
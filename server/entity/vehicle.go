@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+)
+
+// Vehicle is a world.Rideable entity that breaks when it is damaged enough,
+// such as a Boat or a Minecart.
+type Vehicle interface {
+	world.Rideable
+	// Hurt damages the Vehicle. The Vehicle breaks if it took enough damage
+	// in a short time, dropping itself as an item. A Vehicle damaged by a
+	// player in creative mode breaks immediately without dropping an item.
+	Hurt(damage float64, src world.DamageSource) (n float64, vulnerable bool)
+}
+
+// vehicleDamage keeps track of the damage taken by a Vehicle. The damage wears
+// off over time, so that a Vehicle only breaks if it is damaged repeatedly.
+type vehicleDamage struct {
+	damage float64
+}
+
+// hurt damages the vehicle e. If the vehicle breaks as a result, broken is
+// called, after which the vehicle is closed. creative is true if the vehicle
+// was broken by a player in creative mode, in which case it should not drop
+// itself.
+func (v *vehicleDamage) hurt(e *Ent, dmg float64, src world.DamageSource, broken func(creative bool)) (float64, bool) {
+	if dmg < 0 {
+		return 0, false
+	}
+	for _, viewer := range e.tx.Viewers(e.Position()) {
+		viewer.ViewEntityAction(e, HurtAction{})
+	}
+	creative := false
+	if attacker, ok := damageOrigin(src).(interface{ GameMode() world.GameMode }); ok {
+		creative = attacker.GameMode().CreativeInventory()
+	}
+	if v.damage += dmg * 10; v.damage > 40 || creative {
+		broken(creative)
+		_ = e.Close()
+	}
+	return dmg, true
+}
+
+// dropVehicleItem drops the item stack passed at the position of the vehicle
+// e.
+func dropVehicleItem(e *Ent, it item.Stack) {
+	opts := world.EntitySpawnOpts{Position: e.Position(), Velocity: mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1}}
+	e.tx.AddEntity(NewItem(opts, it))
+}
+
+// tick makes the damage taken by the vehicle wear off.
+func (v *vehicleDamage) tick() {
+	v.damage = max(v.damage-1, 0)
+}
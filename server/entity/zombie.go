@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand/v2"
+	"time"
+)
+
+// NewZombie creates a new zombie. Zombies attack players nearby and burn in
+// daylight.
+func NewZombie(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(ZombieType, zombieConf)
+}
+
+var zombieConf = MobBehaviourConfig{
+	Speed:        0.115,
+	AttackDamage: 3,
+	EyeHeight:    1.74,
+	Experience:   5,
+	Goals: func(goals, targets *GoalSelector) {
+		goals.Add(0, FloatGoal{})
+		goals.Add(2, &MeleeAttackGoal{})
+		goals.Add(7, &WanderGoal{})
+		goals.Add(8, &LookAtEntityGoal{})
+
+		targets.Add(1, &HurtByTargetGoal{})
+		targets.Add(2, &NearestTargetGoal{Distance: 35})
+	},
+	Drops: func(*Mob, world.DamageSource) []item.Stack {
+		if n := rand.IntN(3); n > 0 {
+			return []item.Stack{item.NewStack(item.RottenFlesh{}, n)}
+		}
+		return nil
+	},
+	Tick: burnInDaylight,
+}
+
+// burnInDaylight sets a Mob on fire if it is exposed to daylight and is not
+// wearing a helmet.
+func burnInDaylight(m *Mob, tx *world.Tx) {
+	if !tx.World().Dimension().TimeCycle() || tx.World().Time()%24000 >= 12000 {
+		return
+	}
+	pos := cube.PosFromVec3(m.Position().Add(mgl64.Vec3{0, m.EyeHeight()}))
+	if tx.SkyLight(pos) < 15 || tx.RainingAt(pos) || !m.Armour().Helmet().Empty() {
+		return
+	}
+	if _, ok := tx.Liquid(pos); ok || m.OnFireDuration() > 0 {
+		return
+	}
+	if rand.Float64() < 0.1 {
+		m.SetOnFire(time.Second * 8)
+	}
+}
+
+// ZombieType is a world.EntityType implementation for zombies.
+var ZombieType zombieType
+
+type zombieType struct{}
+
+func (zombieType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Mob{Ent: Open(tx, handle, data)}
+}
+
+func (zombieType) EncodeEntity() string { return "minecraft:zombie" }
+func (zombieType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.9, 0.3)
+}
+
+func (zombieType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	b := zombieConf.New()
+	b.DecodeNBT(m)
+	data.Data = b
+}
+
+func (zombieType) EncodeNBT(data *world.EntityData) map[string]any {
+	return data.Data.(*MobBehaviour).EncodeNBT()
+}
@@ -0,0 +1,184 @@
+package packbuilder
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"image"
+	"image/png"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// buildEntities builds all the entity-related files for the resource pack. This includes client entity definitions,
+// geometries, textures, render controllers, animations and language entries.
+func buildEntities(dir string, types []world.EntityType) (count int, lang []string) {
+	for _, sub := range []string{"entity", "models/entity", "render_controllers", "animations"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), os.ModePerm); err != nil {
+			panic(err)
+		}
+	}
+	for _, t := range types {
+		e, ok := t.(world.CustomEntityType)
+		if !ok {
+			continue
+		}
+		identifier := e.EncodeEntity()
+		_, name, ok := strings.Cut(identifier, ":")
+		if !ok {
+			panic(fmt.Errorf("custom entity type identifier %v has no namespace", identifier))
+		}
+		lang = append(lang, fmt.Sprintf("entity.%s.name=%s", identifier, e.Name()))
+
+		description := map[string]any{
+			"identifier": identifier,
+			"materials":  map[string]string{"default": "entity_alphatest"},
+		}
+		geometry := make(map[string]string)
+		for i, id := range geometryIdentifiers(e.Geometry()) {
+			if i == 0 {
+				geometry["default"] = id
+			}
+			geometry[id[strings.LastIndex(id, ".")+1:]] = id
+		}
+		description["geometry"] = geometry
+		writeEntityFile(dir, fmt.Sprintf("models/entity/%s.geo.json", name), e.Geometry())
+
+		textures := make(map[string]string)
+		for textureName, texture := range e.Textures() {
+			path := fmt.Sprintf("textures/entity/%s/%s", name, textureName)
+			textures[textureName] = path
+			buildEntityTexture(dir, path, texture)
+		}
+		description["textures"] = textures
+
+		controllers := e.RenderControllers()
+		if controllers == nil {
+			controllers = defaultRenderController(identifier)
+		}
+		description["render_controllers"] = definitionIdentifiers(controllers, "render_controllers")
+		writeEntityFile(dir, fmt.Sprintf("render_controllers/%s.render_controllers.json", name), controllers)
+
+		if animations := e.Animations(); animations != nil {
+			ids := definitionIdentifiers(animations, "animations")
+			short := make(map[string]string, len(ids))
+			for _, id := range ids {
+				short[id[strings.LastIndex(id, ".")+1:]] = id
+			}
+			description["animations"] = short
+			description["scripts"] = map[string]any{"animate": slices.Sorted(maps.Keys(short))}
+			writeEntityFile(dir, fmt.Sprintf("animations/%s.animation.json", name), animations)
+		}
+
+		b, err := json.Marshal(map[string]any{
+			"format_version":          "1.10.0",
+			"minecraft:client_entity": map[string]any{"description": description},
+		})
+		if err != nil {
+			panic(err)
+		}
+		writeEntityFile(dir, fmt.Sprintf("entity/%s.entity.json", name), b)
+		count++
+	}
+	return
+}
+
+// geometryIdentifiers returns the identifiers of all geometries in the geometry file passed, in the order in which
+// they are defined. Both the current format and the legacy format, in which geometries are keyed by their
+// identifier, are supported.
+func geometryIdentifiers(data []byte) []string {
+	var m struct {
+		Geometry []struct {
+			Description struct {
+				Identifier string `json:"identifier"`
+			} `json:"description"`
+		} `json:"minecraft:geometry"`
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		panic(fmt.Errorf("decode entity geometry: %w", err))
+	}
+	var ids []string
+	for _, g := range m.Geometry {
+		ids = append(ids, g.Description.Identifier)
+	}
+	if len(ids) == 0 {
+		// The legacy format has geometries keyed by their identifier, optionally followed by the identifier of the
+		// geometry that they inherit from, such as 'geometry.boss:geometry.humanoid'.
+		var legacy map[string]json.RawMessage
+		_ = json.Unmarshal(data, &legacy)
+		for key := range legacy {
+			if id, _, _ := strings.Cut(key, ":"); strings.HasPrefix(id, "geometry.") {
+				ids = append(ids, id)
+			}
+		}
+		slices.Sort(ids)
+	}
+	return ids
+}
+
+// definitionIdentifiers returns the sorted identifiers of all definitions under the key passed in a render
+// controllers or animations file.
+func definitionIdentifiers(data []byte, key string) []string {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		panic(fmt.Errorf("decode entity %v: %w", key, err))
+	}
+	var definitions map[string]json.RawMessage
+	if err := json.Unmarshal(m[key], &definitions); err != nil {
+		panic(fmt.Errorf("decode entity %v: %w", key, err))
+	}
+	ids := make([]string, 0, len(definitions))
+	for id := range definitions {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// defaultRenderController returns a render controllers file for an entity type with the identifier passed, which
+// renders the default geometry of the entity with its default texture.
+func defaultRenderController(identifier string) []byte {
+	b, err := json.Marshal(map[string]any{
+		"format_version": "1.8.0",
+		"render_controllers": map[string]any{
+			"controller.render." + strings.ReplaceAll(identifier, ":", "."): map[string]any{
+				"geometry":  "Geometry.default",
+				"materials": []map[string]string{{"*": "Material.default"}},
+				"textures":  []string{"Texture.default"},
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// buildEntityTexture creates a PNG file at the path passed, without extension, from the provided image and writes
+// it to the pack.
+func buildEntityTexture(dir, path string, img image.Image) {
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), os.ModePerm); err != nil {
+		panic(err)
+	}
+	texture, err := os.Create(filepath.Join(dir, path+".png"))
+	if err != nil {
+		panic(err)
+	}
+	if err := png.Encode(texture, img); err != nil {
+		_ = texture.Close()
+		panic(err)
+	}
+	if err := texture.Close(); err != nil {
+		panic(err)
+	}
+}
+
+// writeEntityFile writes the data passed to a file at the path passed relative to the root of the pack.
+func writeEntityFile(dir, path string, data []byte) {
+	if err := os.WriteFile(filepath.Join(dir, path), data, 0666); err != nil {
+		panic(err)
+	}
+}
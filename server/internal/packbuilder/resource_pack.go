@@ -2,6 +2,7 @@ package packbuilder
 
 import (
 	_ "embed"
+	"github.com/df-mc/dragonfly/server/world"
 	"github.com/sandertv/gophertunnel/minecraft/resource"
 	"golang.org/x/mod/sumdb/dirhash"
 	"os"
@@ -10,10 +11,10 @@ import (
 //go:embed pack_icon.png
 var packIcon []byte
 
-// BuildResourcePack builds a resource pack based on custom features that have been registered to the server.
-// It creates a UUID based on the hash of the directory so the client will only be prompted to download it
+// BuildResourcePack builds a resource pack based on custom features that have been registered to the server,
+// including the custom entity types out of the entity types passed. It creates a UUID based on the hash of the directory so the client will only be prompted to download it
 // once it is changed.
-func BuildResourcePack() (*resource.Pack, bool) {
+func BuildResourcePack(entities []world.EntityType) (*resource.Pack, bool) {
 	dir, err := os.MkdirTemp("", "dragonfly_resource_pack-")
 	if err != nil {
 		panic(err)
@@ -31,6 +32,10 @@ func BuildResourcePack() (*resource.Pack, bool) {
 	assets += blockCount
 	lang = append(lang, blockLang...)
 
+	entityCount, entityLang := buildEntities(dir, entities)
+	assets += entityCount
+	lang = append(lang, entityLang...)
+
 	if assets > 0 {
 		buildLanguageFile(dir, lang)
 		if err := os.WriteFile(dir+"/pack_icon.png", packIcon, 0666); err != nil {
@@ -0,0 +1,89 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+// Boat is an item that places a boat on water or on the ground, which
+// entities may ride in.
+type Boat struct {
+	// Type is the type of wood of the boat.
+	Type BoatType
+	// Chest specifies if the boat has a chest, in which items may be stored.
+	// A boat with a chest only has one seat.
+	Chest bool
+}
+
+// MaxCount ...
+func (b Boat) MaxCount() int {
+	return 1
+}
+
+// FuelInfo ...
+func (b Boat) FuelInfo() FuelInfo {
+	if b.Chest {
+		return FuelInfo{}
+	}
+	return newFuelInfo(time.Second * 60)
+}
+
+// Use ...
+func (b Boat) Use(tx *world.Tx, user User, ctx *UseContext) bool {
+	pos, ok := boatPosition(tx, user)
+	if !ok {
+		return false
+	}
+	create := tx.World().EntityRegistry().Config().Boat
+	opts := world.EntitySpawnOpts{Position: pos, Rotation: cube.Rotation{user.Rotation().Yaw() + 90}}
+	tx.AddEntity(create(opts, b))
+
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// UseOnBlock ...
+func (b Boat) UseOnBlock(_ cube.Pos, _ cube.Face, _ mgl64.Vec3, tx *world.Tx, user User, ctx *UseContext) bool {
+	// The block clicked may be behind water, on which the boat should be
+	// placed instead, so the position is looked up the same way as for Use.
+	return b.Use(tx, user, ctx)
+}
+
+// boatPosition returns the position that a boat placed by the user passed is
+// placed at. This is the surface of the first water or the first block that
+// the user is looking at within 5 blocks. False is returned if the user is not
+// looking at either.
+func boatPosition(tx *world.Tx, user User) (pos mgl64.Vec3, ok bool) {
+	start := eyePosition(user)
+	end := start.Add(user.Rotation().Vec3().Mul(5))
+	trace.TraverseBlocks(start, end, func(p cube.Pos) bool {
+		if l, water := tx.Liquid(p); water && l.LiquidType() == "water" {
+			if res, hit := trace.BBoxIntercept(cube.Box(0, 0, 0, 1, 1, 1).Translate(p.Vec3()), start, end); hit {
+				pos, ok = mgl64.Vec3{res.Position()[0], float64(p[1] + 1), res.Position()[2]}, true
+			}
+			return !ok
+		}
+		if res, hit := trace.BlockIntercept(p, tx, tx.Block(p), start, end); hit {
+			pos, ok = res.Position(), true
+		}
+		return !ok
+	})
+	return pos, ok
+}
+
+// EncodeItem ...
+func (b Boat) EncodeItem() (name string, meta int16) {
+	if b.Type == BambooRaft() {
+		if b.Chest {
+			return "minecraft:bamboo_chest_raft", 0
+		}
+		return "minecraft:bamboo_raft", 0
+	}
+	if b.Chest {
+		return "minecraft:" + b.Type.String() + "_chest_boat", 0
+	}
+	return "minecraft:" + b.Type.String() + "_boat", 0
+}
@@ -0,0 +1,36 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"math"
+)
+
+// EmptyMap is an item that is turned into a FilledMap when used, showing the area around the user.
+type EmptyMap struct{}
+
+// Use ...
+func (EmptyMap) Use(tx *world.Tx, user User, ctx *UseContext) bool {
+	pos := user.Position()
+	m := tx.NewMap(mapCentre(pos[0], pos[2], 0), 0)
+	m.Update(tx, user)
+
+	ctx.SubtractFromCount(1)
+	ctx.NewItem = NewStack(FilledMap{ID: m.ID()}, 1)
+	return true
+}
+
+// mapCentre returns the centre of a map with the scale passed that shows the position passed. Like vanilla, maps
+// are aligned to a grid, so that maps created close to each other show the same area.
+func mapCentre(x, z float64, scale int) cube.Pos {
+	size := float64(int(128) << scale)
+	centre := func(v float64) int {
+		return int(math.Floor((v+64)/size)*size + size/2 - 64)
+	}
+	return cube.Pos{centre(x), 0, centre(z)}
+}
+
+// EncodeItem ...
+func (EmptyMap) EncodeItem() (name string, meta int16) {
+	return "minecraft:empty_map", 0
+}
@@ -0,0 +1,37 @@
+package item
+
+import "github.com/df-mc/dragonfly/server/world"
+
+// FilledMap is an item that shows a world.Map. The map shows the area of the world around the player holding it,
+// or an image drawn onto it. Filled maps may be placed in item frames to display them.
+type FilledMap struct {
+	// ID is the ID of the world.Map shown by the item. The world.Map may be obtained using world.Tx.Map.
+	ID int64
+}
+
+// MaxCount always returns 1.
+func (FilledMap) MaxCount() int {
+	return 1
+}
+
+// Map returns the world.Map shown by the FilledMap in the world of the transaction passed. False is returned if the
+// map does not exist.
+func (m FilledMap) Map(tx *world.Tx) (*world.Map, bool) {
+	return tx.Map(m.ID)
+}
+
+// DecodeNBT ...
+func (m FilledMap) DecodeNBT(data map[string]any) any {
+	m.ID, _ = data["map_uuid"].(int64)
+	return m
+}
+
+// EncodeNBT ...
+func (m FilledMap) EncodeNBT() map[string]any {
+	return map[string]any{"map_uuid": m.ID}
+}
+
+// EncodeItem ...
+func (FilledMap) EncodeItem() (name string, meta int16) {
+	return "minecraft:filled_map", 0
+}
@@ -40,6 +40,10 @@ func (f FlintAndSteel) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx
 		tx.PlaySound(s.Vec3Centre(), sound.Ignite{})
 
 		flame := fire()
+		if l, ok := flame.(ignitable); ok && l.Ignite(s, tx, user) {
+			// The fire lit a portal instead of being placed.
+			return true
+		}
 		tx.SetBlock(s, flame, nil)
 		tx.ScheduleBlockUpdate(s, flame, time.Duration(30+rand.IntN(10))*time.Second/20)
 		return true
@@ -0,0 +1,45 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Rail represents a block that minecarts may be placed on and move along.
+type Rail interface {
+	world.Block
+	// Ascending returns true if the rail slopes upwards.
+	Ascending() bool
+}
+
+// Minecart is an item that places a minecart on rails, which entities may
+// ride in.
+type Minecart struct{}
+
+// MaxCount ...
+func (Minecart) MaxCount() int {
+	return 1
+}
+
+// UseOnBlock ...
+func (Minecart) UseOnBlock(pos cube.Pos, _ cube.Face, _ mgl64.Vec3, tx *world.Tx, _ User, ctx *UseContext) bool {
+	r, ok := tx.Block(pos).(Rail)
+	if !ok {
+		return false
+	}
+	spawnPos := pos.Vec3Middle().Add(mgl64.Vec3{0, 0.0625})
+	if r.Ascending() {
+		spawnPos[1] += 0.5
+	}
+	create := tx.World().EntityRegistry().Config().Minecart
+	tx.AddEntity(create(world.EntitySpawnOpts{Position: spawnPos}))
+
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// EncodeItem ...
+func (Minecart) EncodeItem() (name string, meta int16) {
+	return "minecraft:minecart", 0
+}
@@ -49,11 +49,14 @@ func init() {
 	world.RegisterItem(Egg{})
 	world.RegisterItem(Elytra{})
 	world.RegisterItem(Emerald{})
+	world.RegisterItem(EmptyMap{})
 	world.RegisterItem(EnchantedApple{})
 	world.RegisterItem(EnchantedBook{})
+	world.RegisterItem(EnderEye{})
 	world.RegisterItem(EnderPearl{})
 	world.RegisterItem(Feather{})
 	world.RegisterItem(FermentedSpiderEye{})
+	world.RegisterItem(FilledMap{})
 	world.RegisterItem(FireCharge{})
 	world.RegisterItem(Firework{})
 	world.RegisterItem(FlintAndSteel{})
@@ -77,6 +80,7 @@ func init() {
 	world.RegisterItem(Leather{})
 	world.RegisterItem(MagmaCream{})
 	world.RegisterItem(MelonSlice{})
+	world.RegisterItem(Minecart{})
 	world.RegisterItem(MushroomStew{})
 	world.RegisterItem(Mutton{Cooked: true})
 	world.RegisterItem(Mutton{})
@@ -130,6 +134,10 @@ func init() {
 		world.RegisterItem(Leggings{Tier: t})
 		world.RegisterItem(Boots{Tier: t})
 	}
+	for _, t := range BoatTypes() {
+		world.RegisterItem(Boat{Type: t})
+		world.RegisterItem(Boat{Type: t, Chest: true})
+	}
 	for _, t := range SmithingTemplates() {
 		world.RegisterItem(SmithingTemplate{Template: t})
 	}
@@ -23,8 +23,9 @@ type Listener interface {
 // listenerFunc may be used to return a *minecraft.Listener using a Config. It
 // is the standard listener used when UserConfig.Config() is called.
 func (uc UserConfig) listenerFunc(conf Config) (Listener, error) {
+	// MaximumPlayers is left at 0: The Server enforces its maximum player
+	// count itself, so that it may be changed while the Server is running.
 	cfg := minecraft.ListenConfig{
-		MaximumPlayers:         conf.MaxPlayers,
 		StatusProvider:         conf.StatusProvider,
 		AuthenticationDisabled: conf.AuthDisabled,
 		ResourcePacks:          conf.Resources,
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// listFile is a JSON file holding a value of the type T, such as a list of
+// bans. listFile keeps track of the last time the file was modified, so that
+// changes made to the file by others may be picked up by calling load again.
+// A listFile with an empty path does not persist anything.
+type listFile[T any] struct {
+	path string
+	mod  time.Time
+}
+
+// load reads the value in the file into v if the file was changed since it
+// was last loaded or saved. True is returned if v was changed. If the file
+// does not yet exist, it is created by saving v.
+func (f *listFile[T]) load(v *T) (bool, error) {
+	if f.path == "" {
+		return false, nil
+	}
+	stat, err := os.Stat(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, f.save(*v)
+	} else if err != nil {
+		return false, fmt.Errorf("stat %v: %w", f.path, err)
+	}
+	if stat.ModTime().Equal(f.mod) {
+		return false, nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return false, fmt.Errorf("read %v: %w", f.path, err)
+	}
+	var val T
+	if err := json.Unmarshal(data, &val); err != nil {
+		return false, fmt.Errorf("decode %v: %w", f.path, err)
+	}
+	*v, f.mod = val, stat.ModTime()
+	return true, nil
+}
+
+// save writes the value passed to the file.
+func (f *listFile[T]) save(v T) error {
+	if f.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode %v: %w", f.path, err)
+	}
+	if err := os.WriteFile(f.path, data, 0644); err != nil {
+		return fmt.Errorf("write %v: %w", f.path, err)
+	}
+	if stat, err := os.Stat(f.path); err == nil {
+		f.mod = stat.ModTime()
+	}
+	return nil
+}
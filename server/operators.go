@@ -0,0 +1,162 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Operator is an entry in an OperatorList. Operators are identified by their
+// XUID, so that a player cannot become an operator by changing their name to
+// that of one. The name is kept only to display the operator.
+type Operator struct {
+	// XUID is the XUID of the player that is an operator.
+	XUID string `json:"xuid"`
+	// Name is the name of the player at the time they were made an operator.
+	Name string `json:"name"`
+}
+
+// OperatorList is a list of players that are operators on a Server, keyed by
+// their XUID. If the OperatorList was loaded from a file, every change to the
+// list is written back to that file, so that operators remain operators after
+// a restart. Players without an XUID, such as players joining a server with
+// authentication disabled, cannot be operators. OperatorList is safe for
+// concurrent use.
+type OperatorList struct {
+	file string
+
+	mu  sync.Mutex
+	ops map[string]Operator
+}
+
+// NewOperatorList returns an OperatorList holding the operators passed.
+// Changes to the OperatorList returned are not persisted.
+func NewOperatorList(ops ...Operator) *OperatorList {
+	l := &OperatorList{ops: make(map[string]Operator, len(ops))}
+	for _, o := range ops {
+		if o.XUID != "" {
+			l.ops[o.XUID] = o
+		}
+	}
+	return l
+}
+
+// LoadOperatorList loads an OperatorList from the JSON file passed. The file
+// holds an array of objects with the XUID and name of each operator and is
+// created if it does not yet exist. Any change made to the OperatorList
+// returned is written back to the file.
+func LoadOperatorList(file string) (*OperatorList, error) {
+	l := &OperatorList{file: file, ops: make(map[string]Operator)}
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, l.save()
+	} else if err != nil {
+		return nil, fmt.Errorf("read operator list: %w", err)
+	}
+	var ops []Operator
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, fmt.Errorf("decode operator list: %w", err)
+	}
+	for _, o := range ops {
+		if o.XUID != "" {
+			l.ops[o.XUID] = o
+		}
+	}
+	return l, nil
+}
+
+// Add adds the player with the XUID and name passed to the OperatorList. If
+// the player is already an operator, its name is updated. An error is returned
+// if the XUID is empty or if the list could not be written to its file.
+func (l *OperatorList) Add(xuid, name string) error {
+	if xuid == "" {
+		return fmt.Errorf("add operator %v: player has no XUID", name)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ops[xuid] = Operator{XUID: xuid, Name: name}
+	return l.save()
+}
+
+// Remove removes the player with the XUID passed from the OperatorList. An
+// error is returned if the list could not be written to its file.
+func (l *OperatorList) Remove(xuid string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.ops, xuid)
+	return l.save()
+}
+
+// Contains checks if the player with the XUID passed is in the OperatorList.
+func (l *OperatorList) Contains(xuid string) bool {
+	if xuid == "" {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, ok := l.ops[xuid]
+	return ok
+}
+
+// Lookup looks up an operator by the name it was last added with. The name is
+// case-insensitive. If no operator with the name was found, false is returned.
+func (l *OperatorList) Lookup(name string) (Operator, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, o := range l.ops {
+		if strings.EqualFold(o.Name, name) {
+			return o, true
+		}
+	}
+	return Operator{}, false
+}
+
+// Operators returns all operators in the OperatorList, sorted alphabetically
+// by name.
+func (l *OperatorList) Operators() []Operator {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.sorted()
+}
+
+// Names returns the names of all operators in the OperatorList, sorted
+// alphabetically.
+func (l *OperatorList) Names() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ops := l.sorted()
+	names := make([]string, len(ops))
+	for i, o := range ops {
+		names[i] = o.Name
+	}
+	return names
+}
+
+// sorted returns the operators in the OperatorList sorted by name. sorted must
+// only be called while l.mu is locked.
+func (l *OperatorList) sorted() []Operator {
+	return slices.SortedFunc(maps.Values(l.ops), func(a, b Operator) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+}
+
+// save writes the OperatorList to its file, if it has one. save must only be
+// called while l.mu is locked.
+func (l *OperatorList) save() error {
+	if l.file == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(l.sorted(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode operator list: %w", err)
+	}
+	if err := os.WriteFile(l.file, data, 0644); err != nil {
+		return fmt.Errorf("write operator list: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package camera
+
+// Easing is an easing function that is used to move the camera from one
+// position and rotation to another over time. See https://easings.net for a
+// visual overview of the easing functions.
+type Easing uint8
+
+const (
+	EaseLinear Easing = iota
+	EaseSpring
+	EaseInQuad
+	EaseOutQuad
+	EaseInOutQuad
+	EaseInCubic
+	EaseOutCubic
+	EaseInOutCubic
+	EaseInQuart
+	EaseOutQuart
+	EaseInOutQuart
+	EaseInQuint
+	EaseOutQuint
+	EaseInOutQuint
+	EaseInSine
+	EaseOutSine
+	EaseInOutSine
+	EaseInExpo
+	EaseOutExpo
+	EaseInOutExpo
+	EaseInCirc
+	EaseOutCirc
+	EaseInOutCirc
+	EaseInBounce
+	EaseOutBounce
+	EaseInOutBounce
+	EaseInBack
+	EaseOutBack
+	EaseInOutBack
+	EaseInElastic
+	EaseOutElastic
+	EaseInOutElastic
+)
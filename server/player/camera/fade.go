@@ -0,0 +1,73 @@
+package camera
+
+import (
+	"image/color"
+	"time"
+)
+
+// Fade represents a fade of the screen of a player to a colour. The screen
+// fades in to the colour, stays that colour for a duration and fades out
+// again afterwards.
+type Fade struct {
+	colour                                    color.RGBA
+	fadeInDuration, fadeOutDuration, duration time.Duration
+}
+
+// NewFade returns a new Fade to the colour passed. Only the red, green and
+// blue components of the colour are used.
+// The Fade has default durations set, which will generally suffice.
+func NewFade(colour color.RGBA) Fade {
+	return Fade{
+		colour:          colour,
+		fadeInDuration:  time.Second / 2,
+		fadeOutDuration: time.Second / 2,
+		duration:        time.Second,
+	}
+}
+
+// Colour returns the colour that the screen fades to, as passed to NewFade.
+func (f Fade) Colour() color.RGBA {
+	return f.colour
+}
+
+// WithFadeInDuration sets the duration that the screen takes to fade to the
+// colour.
+// The new Fade with the fade-in duration is returned.
+func (f Fade) WithFadeInDuration(d time.Duration) Fade {
+	f.fadeInDuration = d
+	return f
+}
+
+// FadeInDuration returns the duration that the screen takes to fade to the
+// colour. By default, this is half a second.
+func (f Fade) FadeInDuration() time.Duration {
+	return f.fadeInDuration
+}
+
+// WithDuration sets the duration that the screen stays fully coloured in
+// between fading in and fading out.
+// The new Fade with the duration is returned.
+func (f Fade) WithDuration(d time.Duration) Fade {
+	f.duration = d
+	return f
+}
+
+// Duration returns the duration that the screen stays fully coloured. By
+// default, this is one second.
+func (f Fade) Duration() time.Duration {
+	return f.duration
+}
+
+// WithFadeOutDuration sets the duration that the colour takes to fade out of
+// the screen.
+// The new Fade with the fade-out duration is returned.
+func (f Fade) WithFadeOutDuration(d time.Duration) Fade {
+	f.fadeOutDuration = d
+	return f
+}
+
+// FadeOutDuration returns the duration that the colour takes to fade out of
+// the screen. By default, this is half a second.
+func (f Fade) FadeOutDuration() time.Duration {
+	return f.fadeOutDuration
+}
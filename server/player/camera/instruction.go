@@ -0,0 +1,119 @@
+package camera
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+// Instruction represents an instruction that sets the camera of a player to a
+// Preset. An Instruction may move the camera to a specific position and
+// rotation and may ease the camera there over time.
+type Instruction struct {
+	preset Preset
+
+	pos, facing, entityOffset mgl64.Vec3
+	rot                       cube.Rotation
+	viewOffset                mgl64.Vec2
+
+	easing   Easing
+	easeTime time.Duration
+
+	hasPos, hasRot, hasFacing, hasEntityOffset, hasViewOffset, hasEase bool
+}
+
+// NewInstruction returns a new Instruction that sets the camera of a player to
+// the Preset passed. The properties of the Preset are used unless changed
+// using the methods of the Instruction returned.
+func NewInstruction(p Preset) Instruction {
+	return Instruction{preset: p}
+}
+
+// Preset returns the Preset that the Instruction sets the camera to, as
+// passed to NewInstruction.
+func (i Instruction) Preset() Preset {
+	return i.preset
+}
+
+// WithPosition sets the position that the camera is moved to.
+// The new Instruction with the position is returned.
+func (i Instruction) WithPosition(pos mgl64.Vec3) Instruction {
+	i.pos, i.hasPos = pos, true
+	return i
+}
+
+// Position returns the position that the camera is moved to. False is returned
+// if no position was set using WithPosition.
+func (i Instruction) Position() (mgl64.Vec3, bool) {
+	return i.pos, i.hasPos
+}
+
+// WithRotation sets the rotation that the camera is rotated to.
+// The new Instruction with the rotation is returned.
+func (i Instruction) WithRotation(rot cube.Rotation) Instruction {
+	i.rot, i.hasRot = rot, true
+	return i
+}
+
+// Rotation returns the rotation that the camera is rotated to. False is
+// returned if no rotation was set using WithRotation.
+func (i Instruction) Rotation() (cube.Rotation, bool) {
+	return i.rot, i.hasRot
+}
+
+// WithFacing sets a position that the camera keeps facing towards, regardless
+// of its own position.
+// The new Instruction with the facing position is returned.
+func (i Instruction) WithFacing(pos mgl64.Vec3) Instruction {
+	i.facing, i.hasFacing = pos, true
+	return i
+}
+
+// Facing returns the position that the camera keeps facing towards. False is
+// returned if no position was set using WithFacing.
+func (i Instruction) Facing() (mgl64.Vec3, bool) {
+	return i.facing, i.hasFacing
+}
+
+// WithEntityOffset sets the offset from the player at which the camera is
+// rendered.
+// The new Instruction with the entity offset is returned.
+func (i Instruction) WithEntityOffset(off mgl64.Vec3) Instruction {
+	i.entityOffset, i.hasEntityOffset = off, true
+	return i
+}
+
+// EntityOffset returns the offset from the player at which the camera is
+// rendered. False is returned if no offset was set using WithEntityOffset.
+func (i Instruction) EntityOffset() (mgl64.Vec3, bool) {
+	return i.entityOffset, i.hasEntityOffset
+}
+
+// WithViewOffset sets the offset of the camera from the pivot point at the
+// player. The view offset is only used by presets extending FollowOrbit.
+// The new Instruction with the view offset is returned.
+func (i Instruction) WithViewOffset(off mgl64.Vec2) Instruction {
+	i.viewOffset, i.hasViewOffset = off, true
+	return i
+}
+
+// ViewOffset returns the offset of the camera from the pivot point at the
+// player. False is returned if no offset was set using WithViewOffset.
+func (i Instruction) ViewOffset() (mgl64.Vec2, bool) {
+	return i.viewOffset, i.hasViewOffset
+}
+
+// WithEase makes the camera move from its current position and rotation to
+// the new ones over the duration passed, using the Easing function passed. By
+// default, the camera is moved instantly.
+// The new Instruction with the ease is returned.
+func (i Instruction) WithEase(e Easing, d time.Duration) Instruction {
+	i.easing, i.easeTime, i.hasEase = e, d, true
+	return i
+}
+
+// Ease returns the Easing function and the duration of the transition of the
+// camera. False is returned if no ease was set using WithEase.
+func (i Instruction) Ease() (Easing, time.Duration, bool) {
+	return i.easing, i.easeTime, i.hasEase
+}
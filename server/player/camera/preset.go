@@ -0,0 +1,139 @@
+package camera
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Preset represents a camera preset that the camera of a player may be set to
+// using an Instruction. Presets either are one of the built-in presets, such
+// as Free or ThirdPerson, or extend one of them using NewPreset.
+type Preset struct {
+	name, parent string
+
+	pos, entityOffset mgl64.Vec3
+	rot               cube.Rotation
+	viewOffset        mgl64.Vec2
+	radius            float64
+
+	hasPos, hasRot, hasEntityOffset, hasViewOffset, hasRadius bool
+}
+
+// Free returns the built-in free camera preset. A free camera is not attached
+// to the player and stays in place unless moved by an Instruction.
+func Free() Preset {
+	return Preset{name: "minecraft:free"}
+}
+
+// FirstPerson returns the built-in first person camera preset, which is the
+// default camera of a player.
+func FirstPerson() Preset {
+	return Preset{name: "minecraft:first_person"}
+}
+
+// ThirdPerson returns the built-in third person camera preset, which shows the
+// player from behind.
+func ThirdPerson() Preset {
+	return Preset{name: "minecraft:third_person"}
+}
+
+// ThirdPersonFront returns the built-in third person camera preset that shows
+// the player from the front.
+func ThirdPersonFront() Preset {
+	return Preset{name: "minecraft:third_person_front"}
+}
+
+// FollowOrbit returns the built-in camera preset that orbits around the
+// player at a distance, which may be changed using Preset.WithRadius.
+func FollowOrbit() Preset {
+	return Preset{name: "minecraft:follow_orbit"}
+}
+
+// NewPreset creates a new custom Preset with the name passed, such as
+// "dragonfly:cutscene", that extends the parent Preset passed. Each preset
+// must have a unique name. The properties of the parent are used unless
+// changed using the methods of the Preset returned.
+func NewPreset(name string, parent Preset) Preset {
+	return Preset{name: name, parent: parent.name}
+}
+
+// Name returns the name of the Preset, such as "minecraft:free".
+func (p Preset) Name() string {
+	return p.name
+}
+
+// Parent returns the name of the Preset that the Preset extends. Parent
+// returns an empty string for built-in presets.
+func (p Preset) Parent() string {
+	return p.parent
+}
+
+// WithPosition sets the default position of the camera. By default, the
+// camera is positioned at the player.
+// The new Preset with the position is returned.
+func (p Preset) WithPosition(pos mgl64.Vec3) Preset {
+	p.pos, p.hasPos = pos, true
+	return p
+}
+
+// Position returns the default position of the camera. False is returned if
+// no position was set using WithPosition.
+func (p Preset) Position() (mgl64.Vec3, bool) {
+	return p.pos, p.hasPos
+}
+
+// WithRotation sets the default rotation of the camera. By default, the camera
+// has the rotation of the player.
+// The new Preset with the rotation is returned.
+func (p Preset) WithRotation(rot cube.Rotation) Preset {
+	p.rot, p.hasRot = rot, true
+	return p
+}
+
+// Rotation returns the default rotation of the camera. False is returned if no
+// rotation was set using WithRotation.
+func (p Preset) Rotation() (cube.Rotation, bool) {
+	return p.rot, p.hasRot
+}
+
+// WithEntityOffset sets the offset from the player at which the camera is
+// rendered.
+// The new Preset with the entity offset is returned.
+func (p Preset) WithEntityOffset(off mgl64.Vec3) Preset {
+	p.entityOffset, p.hasEntityOffset = off, true
+	return p
+}
+
+// EntityOffset returns the offset from the player at which the camera is
+// rendered. False is returned if no offset was set using WithEntityOffset.
+func (p Preset) EntityOffset() (mgl64.Vec3, bool) {
+	return p.entityOffset, p.hasEntityOffset
+}
+
+// WithViewOffset sets the offset of the camera from the pivot point at the
+// player. The view offset is only used by presets extending FollowOrbit.
+// The new Preset with the view offset is returned.
+func (p Preset) WithViewOffset(off mgl64.Vec2) Preset {
+	p.viewOffset, p.hasViewOffset = off, true
+	return p
+}
+
+// ViewOffset returns the offset of the camera from the pivot point at the
+// player. False is returned if no offset was set using WithViewOffset.
+func (p Preset) ViewOffset() (mgl64.Vec2, bool) {
+	return p.viewOffset, p.hasViewOffset
+}
+
+// WithRadius sets the distance between the camera and the player. The radius
+// is only used by presets extending FollowOrbit.
+// The new Preset with the radius is returned.
+func (p Preset) WithRadius(radius float64) Preset {
+	p.radius, p.hasRadius = radius, true
+	return p
+}
+
+// Radius returns the distance between the camera and the player. False is
+// returned if no radius was set using WithRadius.
+func (p Preset) Radius() (float64, bool) {
+	return p.radius, p.hasRadius
+}
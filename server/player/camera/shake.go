@@ -0,0 +1,43 @@
+package camera
+
+import "time"
+
+// ShakeType is the type of Shake, which affects how the shaking of the camera
+// looks.
+type ShakeType uint8
+
+const (
+	// ShakePositional shakes the camera by moving its position.
+	ShakePositional ShakeType = iota
+	// ShakeRotational shakes the camera by changing its rotation.
+	ShakeRotational
+)
+
+// Shake represents the shaking of the camera of a player, such as the shaking
+// caused by an explosion.
+type Shake struct {
+	t         ShakeType
+	intensity float64
+	duration  time.Duration
+}
+
+// NewShake returns a new Shake of the ShakeType passed with an intensity and
+// duration. The intensity is limited to 4 by the client.
+func NewShake(t ShakeType, intensity float64, duration time.Duration) Shake {
+	return Shake{t: t, intensity: intensity, duration: duration}
+}
+
+// Type returns the ShakeType of the Shake.
+func (s Shake) Type() ShakeType {
+	return s.t
+}
+
+// Intensity returns the intensity of the Shake.
+func (s Shake) Intensity() float64 {
+	return s.intensity
+}
+
+// Duration returns the duration that the camera shakes for.
+func (s Shake) Duration() time.Duration {
+	return s.duration
+}
@@ -10,7 +10,14 @@ import (
 
 var MessageJoin = Translate(str("%multiplayer.player.joined"), 1, `%v joined the game`).Enc("<yellow>%v</yellow>")
 var MessageQuit = Translate(str("%multiplayer.player.left"), 1, `%v left the game`).Enc("<yellow>%v</yellow>")
+var MessageServerFull = Translate(str("%disconnectionScreen.serverFull"), 0, `Server is full!`)
 var MessageServerDisconnect = Translate(str("%disconnect.disconnected"), 0, `Disconnected by Server`).Enc("<yellow>%v</yellow>")
+var MessageBedRespawnSet = Translate(str("%tile.bed.respawnSet"), 0, `Respawn point set`)
+var MessageBedNoSleep = Translate(str("%tile.bed.noSleep"), 0, `You can only sleep at night and during thunderstorms`)
+var MessageBedOccupied = Translate(str("%tile.bed.occupied"), 0, `This bed is occupied`)
+var MessageBedTooFar = Translate(str("%tile.bed.tooFar"), 0, `Bed is too far away`)
+var MessageBedNotValid = Translate(str("%tile.bed.notValid"), 0, `Your home bed was missing or obstructed`)
+var MessageBedNotSafe = Translate(str("%tile.bed.notSafe"), 0, `You may not rest now; there are monsters nearby`)
 
 type str string
 
@@ -5,12 +5,14 @@ import (
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player/hud"
 	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
 	"golang.org/x/text/language"
+	"maps"
 	"math/rand/v2"
 	"time"
 )
@@ -24,8 +26,13 @@ type Config struct {
 	Name     string
 	Locale   language.Tag
 	GameMode world.GameMode
+	Operator bool
+
+	Permissions map[string]bool
 
 	Position               mgl64.Vec3
+	BedSpawn               cube.Pos
+	HasBedSpawn            bool
 	Rotation               cube.Rotation
 	Velocity               mgl64.Vec3
 	Health                 float64
@@ -67,9 +74,12 @@ func (cfg Config) Apply(data *world.EntityData) {
 		effects:           entity.NewEffectManager(conf.Effects...),
 		locale:            conf.Locale,
 		cooldowns:         make(map[string]time.Time),
+		hiddenHud:         make(map[hud.Element]struct{}),
 		mc:                &entity.MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
 		heldSlot:          &slot,
 		gameMode:          conf.GameMode,
+		operator:          conf.Operator,
+		permissions:       maps.Clone(conf.Permissions),
 		skin:              conf.Skin,
 		enchantSeed:       conf.EnchantmentSeed,
 		s:                 conf.Session,
@@ -83,6 +93,8 @@ func (cfg Config) Apply(data *world.EntityData) {
 		nameTag:           conf.Name,
 		fireTicks:         conf.FireTicks,
 		fallDistance:      conf.FallDistance,
+		bedSpawn:          conf.BedSpawn,
+		hasBedSpawn:       conf.HasBedSpawn,
 	}
 	pdata.hunger.foodLevel, pdata.hunger.foodTick, pdata.hunger.exhaustionLevel, pdata.hunger.saturationLevel = conf.Food, conf.FoodTick, conf.Exhaustion, conf.Saturation
 	pdata.experience.Add(conf.Experience)
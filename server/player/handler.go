@@ -21,10 +21,21 @@ type Handler interface {
 	// HandleMove handles the movement of a player. ctx.Cancel() may be called to cancel the movement event.
 	// The new position, yaw and pitch are passed.
 	HandleMove(ctx *Context, newPos mgl64.Vec3, newRot cube.Rotation)
+	// HandleMovementViolation handles movement sent by the client of a player, or of the vehicle that it
+	// drives, that was found not to be possible when movement validation is enabled, such as flying or moving
+	// too fast. By default, the client is corrected back to the position of the player or vehicle before the
+	// movement. ctx.Cancel() may be called to
+	// accept the movement anyway.
+	HandleMovementViolation(ctx *Context, v MovementViolation)
 	// HandleJump handles the player jumping.
 	HandleJump(p *Player)
 	// HandleTeleport handles the teleportation of a player. ctx.Cancel() may be called to cancel it.
 	HandleTeleport(ctx *Context, pos mgl64.Vec3)
+	// HandleMount handles a player starting to ride a vehicle, such as a boat or a minecart. ctx.Cancel() may
+	// be called to prevent the player from riding the vehicle.
+	HandleMount(ctx *Context, vehicle world.Rideable)
+	// HandleDismount handles a player that stops riding the vehicle passed.
+	HandleDismount(p *Player, vehicle world.Entity)
 	// HandleChangeWorld handles when the player is added to a new world. before may be nil.
 	HandleChangeWorld(p *Player, before, after *world.World)
 	// HandleToggleSprint handles when the player starts or stops sprinting.
@@ -33,6 +44,16 @@ type Handler interface {
 	// HandleToggleSneak handles when the player starts or stops sneaking.
 	// After is true if the player is sneaking after toggling (changing their sneaking state).
 	HandleToggleSneak(ctx *Context, after bool)
+	// HandleSleep handles the player going to sleep in the bed at the position passed. ctx.Cancel() may be called
+	// to prevent the player from sleeping.
+	HandleSleep(ctx *Context, pos cube.Pos)
+	// HandleWake handles the player waking up from sleeping in the bed at the position passed, either because it
+	// left the bed or because the night was skipped.
+	HandleWake(p *Player, pos cube.Pos)
+	// HandlePortalTravel handles the player travelling through a portal. portal is world.Nether for nether portals
+	// and world.End for end portals. ctx.Cancel() may be called to prevent the player from travelling.
+	// The World that the player travels to may be changed by assigning to *w.
+	HandlePortalTravel(ctx *Context, portal world.Dimension, w **world.World)
 	// HandleChat handles a message sent in the chat by a player. ctx.Cancel() may be called to cancel the
 	// message being sent in chat.
 	// The message may be changed by assigning to *message.
@@ -164,11 +185,17 @@ var _ Handler = NopHandler{}
 func (NopHandler) HandleItemDrop(*Context, item.Stack)                                     {}
 func (NopHandler) HandleHeldSlotChange(*Context, int, int)                                 {}
 func (NopHandler) HandleMove(*Context, mgl64.Vec3, cube.Rotation)                          {}
+func (NopHandler) HandleMovementViolation(*Context, MovementViolation)                     {}
 func (NopHandler) HandleJump(*Player)                                                      {}
 func (NopHandler) HandleTeleport(*Context, mgl64.Vec3)                                     {}
+func (NopHandler) HandleMount(*Context, world.Rideable)                                    {}
+func (NopHandler) HandleDismount(*Player, world.Entity)                                    {}
 func (NopHandler) HandleChangeWorld(*Player, *world.World, *world.World)                   {}
 func (NopHandler) HandleToggleSprint(*Context, bool)                                       {}
 func (NopHandler) HandleToggleSneak(*Context, bool)                                        {}
+func (NopHandler) HandleSleep(*Context, cube.Pos)                                          {}
+func (NopHandler) HandleWake(*Player, cube.Pos)                                            {}
+func (NopHandler) HandlePortalTravel(*Context, world.Dimension, **world.World)             {}
 func (NopHandler) HandleCommandExecution(*Context, cmd.Command, []string)                  {}
 func (NopHandler) HandleTransfer(*Context, *net.UDPAddr)                                   {}
 func (NopHandler) HandleChat(*Context, *string)                                            {}
@@ -0,0 +1,38 @@
+package hud
+
+// Element is an element of the HUD of a player that may be hidden using
+// Player.HideHudElements.
+type Element uint8
+
+const (
+	// PaperDoll is the model of the player shown in the top left of the screen
+	// while sneaking, sprinting or flying.
+	PaperDoll Element = iota
+	// Armour is the armour bar shown above the hot bar.
+	Armour
+	// ToolTips are the tips shown above the hot bar, such as the name of the
+	// held item.
+	ToolTips
+	// TouchControls are the controls shown on touch screen devices.
+	TouchControls
+	// Crosshair is the crosshair in the middle of the screen.
+	Crosshair
+	// HotBar is the hot bar at the bottom of the screen.
+	HotBar
+	// Health is the health bar shown above the hot bar.
+	Health
+	// ProgressBar is the experience bar shown above the hot bar.
+	ProgressBar
+	// Hunger is the hunger bar shown above the hot bar.
+	Hunger
+	// AirBubbles is the air supply bar shown above the hot bar while under
+	// water.
+	AirBubbles
+	// HorseHealth is the health bar of a ridden horse.
+	HorseHealth
+)
+
+// All returns all HUD elements.
+func All() []Element {
+	return []Element{PaperDoll, Armour, ToolTips, TouchControls, Crosshair, HotBar, Health, ProgressBar, Hunger, AirBubbles, HorseHealth}
+}
@@ -0,0 +1,377 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// ViolationType is the type of a MovementViolation, describing which check the
+// movement of a player failed.
+type ViolationType int
+
+const (
+	// ViolationSpeed is the ViolationType of movement that is horizontally
+	// faster than the player is able to move.
+	ViolationSpeed ViolationType = iota
+	// ViolationFly is the ViolationType of movement that is higher up than the
+	// player is able to move, such as flying or hovering without being allowed
+	// to fly.
+	ViolationFly
+	// ViolationNoClip is the ViolationType of movement that passes through or
+	// ends inside a block that the player is not able to move through.
+	ViolationNoClip
+)
+
+// String returns the ViolationType as a string, such as "speed".
+func (t ViolationType) String() string {
+	switch t {
+	case ViolationSpeed:
+		return "speed"
+	case ViolationFly:
+		return "fly"
+	case ViolationNoClip:
+		return "noclip"
+	}
+	panic("should never happen")
+}
+
+// MovementViolation holds information on movement sent by the client of a
+// Player, or of the vehicle that it drives, that was found not to be possible
+// by Player.ValidateMovement or Player.ValidateVehicleMovement.
+type MovementViolation struct {
+	// Type is the check that the movement failed.
+	Type ViolationType
+	// From is the position of the player before the movement and To is the
+	// position that the player attempted to move to.
+	From, To mgl64.Vec3
+	// Excess is the distance in blocks by which the movement exceeded the
+	// movement that the player is able to make. It is 0 for ViolationNoClip.
+	Excess float64
+}
+
+// movementValidator holds the state of the simulation of the movement of a
+// player, used by Player.ValidateMovement.
+type movementValidator struct {
+	// vel is the velocity of the player as a result of the last movement
+	// that was accepted.
+	vel mgl64.Vec3
+	// external is velocity given to the player by the server, for example by
+	// knockback, that was not yet accounted for in movement.
+	external mgl64.Vec3
+	// vehicleDistance is the horizontal distance in blocks that the vehicle
+	// driven by the player may still move. It increases every tick by the
+	// distance that the vehicle is able to move in a tick.
+	vehicleDistance float64
+}
+
+const (
+	// stepHeight is the height of blocks that a player moves up on without
+	// jumping.
+	stepHeight = 0.6
+	// jumpVelocity is the upward velocity of a player when jumping, without
+	// the jump boost effect.
+	jumpVelocity = 0.42
+	// horizontalTolerance and verticalTolerance are the distances in blocks
+	// that movement may exceed the simulated movement by before it is
+	// considered a violation. They account for imprecision in the simulation.
+	horizontalTolerance, verticalTolerance = 0.05, 0.03
+	// sweepStep is the largest distance in blocks that the bounding box of a
+	// player is moved by at once when checking if movement passes through
+	// blocks.
+	sweepStep = 0.25
+	// vehicleAcceleration is the horizontal distance in blocks that a driven
+	// vehicle, such as a boat, accelerates by every tick.
+	vehicleAcceleration = 0.04
+	// maxMovementTicks is the largest number of ticks of movement that a
+	// lagging client may build up.
+	maxMovementTicks = 20
+)
+
+// ValidateMovement checks if the Player is able to move by deltaPos, based on
+// a simulation of its movement by the server. ticks is the number of ticks
+// that the movement took, which is usually 1. It must be determined by the
+// server rather than taken from the client, as a client could otherwise claim
+// any number of ticks to move further. The simulation takes into account
+// physics, collision with blocks, effects such as speed and jump boost,
+// velocity given to the Player, and abilities such as flying. Movement that is not possible is passed to
+// Handler.HandleMovementViolation, after which the client of the Player is
+// corrected back to its current position and false is returned, unless the
+// violation was cancelled.
+// ValidateMovement is called by the session of the Player for movement sent
+// by its client if movement validation is enabled, before the movement is
+// applied using Move.
+func (p *Player) ValidateMovement(deltaPos mgl64.Vec3, ticks int) bool {
+	// Limit the number of ticks so that lagging clients cannot build up
+	// movement indefinitely.
+	ticks = min(max(ticks, 1), maxMovementTicks)
+	v, ok := p.checkMovement(deltaPos, ticks)
+	if !ok {
+		ctx := event.C(p)
+		if p.Handler().HandleMovementViolation(ctx, v); !ctx.Cancelled() {
+			p.mv = movementValidator{}
+			p.session().CorrectMovement(p.Position(), p.OnGround())
+			return false
+		}
+	}
+	p.mv.vel, p.mv.external = deltaPos.Mul(1/float64(ticks)), mgl64.Vec3{}
+	return true
+}
+
+// ValidateVehicleMovement checks if the vehicle driven by the Player is able
+// to move to pos, based on the highest speed that the vehicle is able to move
+// at on the blocks below it. Movement that is not possible is passed to
+// Handler.HandleMovementViolation, after which the vehicle is moved back to
+// its current position for the client and false is returned, unless the
+// violation was cancelled.
+// ValidateVehicleMovement is called by the session of the Player for movement
+// of vehicles sent by its client if movement validation is enabled, before
+// the movement is applied using MoveVehicle.
+func (p *Player) ValidateVehicleMovement(pos mgl64.Vec3) bool {
+	vehicle, ok := p.Vehicle()
+	if !ok {
+		return false
+	}
+	from := vehicle.Position()
+	h := math.Hypot(pos[0]-from[0], pos[2]-from[2])
+	if h <= p.mv.vehicleDistance+horizontalTolerance {
+		p.mv.vehicleDistance = max(p.mv.vehicleDistance-h, 0)
+		return true
+	}
+	ctx := event.C(p)
+	v := MovementViolation{Type: ViolationSpeed, From: from, To: pos, Excess: h - p.mv.vehicleDistance}
+	if p.Handler().HandleMovementViolation(ctx, v); ctx.Cancelled() {
+		p.mv.vehicleDistance = 0
+		return true
+	}
+	p.session().ViewEntityTeleport(vehicle, from)
+	return false
+}
+
+// tickVehicleMovement increases the distance that the vehicle driven by the
+// Player may move by the distance that it is able to move in a tick, so that
+// the vehicle does not move faster than it is able to over time.
+func (p *Player) tickVehicleMovement() {
+	seat, _, seated := p.H().Seat()
+	vehicle, ok := p.Vehicle()
+	if !seated || !ok || !seat.Driver {
+		p.mv.vehicleDistance = 0
+		return
+	}
+	friction := 0.9
+	if f, ok := p.tx.Block(cube.PosFromVec3(vehicle.Position().Sub(mgl64.Vec3{0, 0.2}))).(block.Frictional); ok {
+		friction = max(friction, min(f.Friction(), 0.99))
+	}
+	// A vehicle does not move faster than the speed at which friction slows
+	// it down by as much as it accelerates.
+	speed := vehicleAcceleration / (1 - friction)
+	p.mv.vehicleDistance = min(p.mv.vehicleDistance+speed, speed*maxMovementTicks)
+}
+
+// checkMovement checks if the Player is able to move by deltaPos in the number
+// of ticks passed. If not, a MovementViolation describing the reason is
+// returned along with false.
+func (p *Player) checkMovement(deltaPos mgl64.Vec3, ticks int) (MovementViolation, bool) {
+	pos := p.Position()
+	v := MovementViolation{From: pos, To: pos.Add(deltaPos)}
+
+	box := Type.BBox(p)
+	from, to := box.Translate(pos), box.Translate(v.To)
+	if p.GameMode().HasCollision() && !p.collidesWithBlocks(from) && p.noClips(from.Grow(-0.05), to.Grow(-0.05), deltaPos, ticks) {
+		v.Type = ViolationNoClip
+		return v, false
+	}
+	if p.Gliding() {
+		// Gliding players may be boosted by fireworks, so their movement is not
+		// simulated.
+		return v, true
+	}
+	flying := p.Flying() && p.GameMode().AllowsFlying()
+	inLiquid, climbing := p.movementSurroundings(from.Extend(deltaPos))
+
+	if h, limit := math.Hypot(deltaPos[0], deltaPos[2]), p.maxHorizontalMovement(deltaPos, ticks, flying, inLiquid); h > limit+horizontalTolerance {
+		v.Type, v.Excess = ViolationSpeed, h-limit
+		return v, false
+	}
+	if flying || inLiquid || climbing {
+		return v, true
+	}
+	if _, ok := p.Effect(effect.Levitation); ok {
+		return v, true
+	}
+	if limit := p.maxVerticalMovement(from, ticks); deltaPos[1] > limit+verticalTolerance {
+		v.Type, v.Excess = ViolationFly, deltaPos[1]-limit
+		return v, false
+	}
+	return v, true
+}
+
+// noClips checks if a player moving from the box from to the box to by
+// deltaPos moves through blocks. Movement of a single tick is swept along the
+// Y, X and Z axes in that order, like the client moves, so that moving through
+// thin blocks such as glass panes is detected. For movement over multiple
+// ticks the path taken is not known, so only the box that the movement ends
+// in is checked.
+func (p *Player) noClips(from, to cube.BBox, deltaPos mgl64.Vec3, ticks int) bool {
+	if ticks > 1 {
+		return p.collidesWithBlocks(to)
+	}
+	boxes := p.blockBBoxesAround(from.Extend(deltaPos))
+	box := from
+	for _, axis := range [...]int{1, 0, 2} {
+		var move mgl64.Vec3
+		move[axis] = deltaPos[axis]
+		// The bounding box of a player is larger than sweepStep in every
+		// direction, so a block cannot be passed through between two steps.
+		steps := int(math.Ceil(math.Abs(move[axis]) / sweepStep))
+		for i := 1; i <= steps; i++ {
+			stepped := box.Translate(move.Mul(float64(i) / float64(steps)))
+			for _, bb := range boxes {
+				if bb.IntersectsWith(stepped) {
+					return true
+				}
+			}
+		}
+		box = box.Translate(move)
+	}
+	return false
+}
+
+// maxHorizontalMovement returns the largest horizontal distance that the
+// Player is able to move in the number of ticks passed when moving by
+// deltaPos.
+func (p *Player) maxHorizontalMovement(deltaPos mgl64.Vec3, ticks int, flying, inLiquid bool) float64 {
+	speed := p.Speed()
+	// Sprinting is started by the client in the same tick that it moves, so
+	// sprinting speed is allowed as long as the player is able to sprint.
+	canSprint := !p.sprinting && !p.crawling && (p.hunger.canSprint() || !p.GameMode().AllowsTakingDamage())
+	if canSprint {
+		speed *= 1.3
+	}
+
+	friction, accel, boost := 0.91, 0.02, 0.0
+	switch {
+	case flying:
+		accel = p.FlightSpeed() * 2
+	case inLiquid:
+		friction, accel = 0.8, speed
+	case p.OnGround():
+		blockFriction := 0.6
+		if f, ok := p.tx.Block(cube.PosFromVec3(p.Position()).Side(cube.FaceDown)).(interface {
+			Friction() float64
+		}); ok {
+			blockFriction = f.Friction()
+		}
+		friction, accel = blockFriction*0.91, speed*0.16277136/math.Pow(blockFriction, 3)
+		if (canSprint || p.sprinting) && deltaPos[1] > 0 {
+			// Jumping while sprinting gives the player an additional boost.
+			boost = 0.2
+		}
+	case canSprint || p.sprinting:
+		accel = 0.026
+	}
+	h := math.Hypot(p.mv.vel[0], p.mv.vel[2])*friction + accel + boost + math.Hypot(p.mv.external[0], p.mv.external[2])
+
+	limit := h
+	for range ticks - 1 {
+		h = h*friction + accel
+		limit += h
+	}
+	return limit
+}
+
+// maxVerticalMovement returns the highest that the Player, with its bounding
+// box at box, is able to move up in the number of ticks passed when not
+// flying, swimming or climbing.
+func (p *Player) maxVerticalMovement(box cube.BBox, ticks int) float64 {
+	gravity := 0.08
+	if _, ok := p.Effect(effect.SlowFalling); ok && p.mv.vel[1] <= 0 {
+		gravity = 0.01
+	}
+	vel := (p.mv.vel[1] - gravity) * 0.98
+	if p.OnGround() {
+		jump := jumpVelocity
+		if e, ok := p.Effect(effect.JumpBoost); ok {
+			jump += float64(e.Level()) * 0.1
+		}
+		// Players on the ground may jump or bounce off blocks such as slime
+		// blocks after landing.
+		vel = max(vel, jump, -p.mv.vel[1])
+	}
+	vel += max(p.mv.external[1], 0)
+
+	limit := vel
+	for range ticks - 1 {
+		vel = (vel - gravity) * 0.98
+		limit += vel
+	}
+	if p.OnGround() {
+		// Players on the ground also move up on blocks without jumping.
+		limit = max(limit, stepHeight)
+	}
+	if limit < 0 {
+		// Falling players stop moving down when they hit the ground, so the
+		// lowest they can end up is where the ground is.
+		for _, bb := range p.blockBBoxesAround(box.Extend(mgl64.Vec3{0, limit})) {
+			limit = box.YOffset(bb, limit)
+		}
+	}
+	return limit
+}
+
+// movementSurroundings checks if the box passed is in a liquid and if it is
+// next to a block that may be climbed, such as a ladder or vines.
+func (p *Player) movementSurroundings(box cube.BBox) (inLiquid, climbing bool) {
+	box = box.Grow(0.1)
+	low, high := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+	for x := low[0]; x <= high[0]; x++ {
+		for y := low[1]; y <= high[1]; y++ {
+			for z := low[2]; z <= high[2]; z++ {
+				pos := cube.Pos{x, y, z}
+				if _, ok := p.tx.Liquid(pos); ok {
+					inLiquid = true
+				}
+				switch p.tx.Block(pos).(type) {
+				case block.Ladder, block.Vines:
+					climbing = true
+				}
+			}
+		}
+	}
+	return inLiquid, climbing
+}
+
+// collidesWithBlocks checks if the box passed intersects with the bounding box
+// of any block.
+func (p *Player) collidesWithBlocks(box cube.BBox) bool {
+	for _, bb := range p.blockBBoxesAround(box) {
+		if bb.IntersectsWith(box) {
+			return true
+		}
+	}
+	return false
+}
+
+// blockBBoxesAround returns the bounding boxes of all blocks that the box
+// passed may intersect with, translated to the positions of the blocks.
+func (p *Player) blockBBoxesAround(box cube.BBox) []cube.BBox {
+	low, high := cube.PosFromVec3(box.Min().Sub(mgl64.Vec3{1, 1, 1})), cube.PosFromVec3(box.Max().Add(mgl64.Vec3{1, 1, 1}))
+	var boxes []cube.BBox
+	for x := low[0]; x <= high[0]; x++ {
+		for y := low[1]; y <= high[1]; y++ {
+			for z := low[2]; z <= high[2]; z++ {
+				pos := cube.Pos{x, y, z}
+				if pos.OutOfBounds(p.tx.Range()) {
+					continue
+				}
+				for _, bb := range p.tx.Block(pos).Model().BBox(pos, p.tx) {
+					boxes = append(boxes, bb.Translate(pos.Vec3()))
+				}
+			}
+		}
+	}
+	return boxes
+}
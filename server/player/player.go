@@ -2,6 +2,7 @@ package player
 
 import (
 	"fmt"
+	"maps"
 	"math"
 	"math/rand/v2"
 	"net"
@@ -21,9 +22,11 @@ import (
 	"github.com/df-mc/dragonfly/server/item/enchantment"
 	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/player/bossbar"
+	"github.com/df-mc/dragonfly/server/player/camera"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/dialogue"
 	"github.com/df-mc/dragonfly/server/player/form"
+	"github.com/df-mc/dragonfly/server/player/hud"
 	"github.com/df-mc/dragonfly/server/player/scoreboard"
 	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/player/title"
@@ -40,6 +43,10 @@ type playerData struct {
 	xuid              string
 	locale            language.Tag
 	nameTag, scoreTag string
+	tags              []string
+	scores            map[string]int
+	operator          bool
+	permissions       map[string]bool
 	absorptionHealth  float64
 	scale             float64
 
@@ -56,6 +63,16 @@ type playerData struct {
 	invisible, immobile, onGround, usingItem bool
 	usingSince time.Time
 
+	sleeping bool
+	sleepPos cube.Pos
+
+	bedSpawn    cube.Pos
+	hasBedSpawn bool
+
+	inPortal, portalCooldown bool
+	portalDimension          world.Dimension
+	portalTicks              int
+
 	glideTicks   int64
 	fireTicks    int64
 	fallDistance float64
@@ -84,6 +101,7 @@ type playerData struct {
 	enchantSeed int64
 
 	mc *entity.MovementComputer
+	mv movementValidator
 
 	collidedVertically, collidedHorizontally bool
 
@@ -94,6 +112,9 @@ type playerData struct {
 
 	breakCounter uint32
 
+	hiddenHud map[hud.Element]struct{}
+	fog       []string
+
 	hunger *hungerManager
 
 	once sync.Once
@@ -252,6 +273,23 @@ func (p *Player) SendJukeboxPopup(a ...any) {
 	p.session().SendJukeboxPopup(format(a))
 }
 
+// SendBedMessage sends the translated message of a block.BedMessage to the
+// player, such as the reason that it was not able to sleep in a bed.
+func (p *Player) SendBedMessage(m block.BedMessage) {
+	switch m {
+	case block.BedRespawnSet:
+		p.Messaget(chat.MessageBedRespawnSet)
+	case block.BedNoSleep:
+		p.Messaget(chat.MessageBedNoSleep)
+	case block.BedOccupied:
+		p.Messaget(chat.MessageBedOccupied)
+	case block.BedTooFar:
+		p.Messaget(chat.MessageBedTooFar)
+	case block.BedNotSafe:
+		p.Messaget(chat.MessageBedNotSafe)
+	}
+}
+
 // SendToast sends a toast to the player. This toast is shown at the top of the screen, similar to achievements or pack
 // loading.
 func (p *Player) SendToast(title, message string) {
@@ -421,6 +459,77 @@ func (p *Player) DisableInstantRespawn() {
 	p.session().EnableInstantRespawn(false)
 }
 
+// SetCamera sets the camera of the player to the camera.Preset of the
+// camera.Instruction passed, optionally moving and easing the camera to a
+// specific position and rotation. The camera may be returned to normal using
+// Player.ResetCamera.
+func (p *Player) SetCamera(i camera.Instruction) {
+	p.session().SetCamera(i)
+}
+
+// FadeCamera fades the screen of the player to the colour of the camera.Fade
+// passed and back.
+func (p *Player) FadeCamera(f camera.Fade) {
+	p.session().FadeCamera(f)
+}
+
+// ResetCamera clears all camera instructions sent to the player using
+// Player.SetCamera and Player.FadeCamera, returning the camera of the player
+// to normal.
+func (p *Player) ResetCamera() {
+	p.session().ClearCamera()
+}
+
+// ShakeCamera makes the camera of the player shake as specified by the
+// camera.Shake passed. Shaking may be stopped early using
+// Player.StopCameraShake.
+func (p *Player) ShakeCamera(s camera.Shake) {
+	p.session().ShakeCamera(s)
+}
+
+// StopCameraShake stops any shaking of the camera of the player.
+func (p *Player) StopCameraShake() {
+	p.session().StopCameraShake()
+}
+
+// HideHudElements hides the hud.Element values passed from the HUD of the
+// player. The elements may be shown again using Player.ShowHudElements.
+func (p *Player) HideHudElements(elements ...hud.Element) {
+	for _, e := range elements {
+		p.hiddenHud[e] = struct{}{}
+	}
+	p.session().SetHudElementsVisible(elements, false)
+}
+
+// ShowHudElements shows the hud.Element values passed on the HUD of the
+// player again after they were hidden using Player.HideHudElements.
+func (p *Player) ShowHudElements(elements ...hud.Element) {
+	for _, e := range elements {
+		delete(p.hiddenHud, e)
+	}
+	p.session().SetHudElementsVisible(elements, true)
+}
+
+// HudElementHidden checks if the hud.Element passed is currently hidden from
+// the HUD of the player using Player.HideHudElements.
+func (p *Player) HudElementHidden(e hud.Element) bool {
+	_, ok := p.hiddenHud[e]
+	return ok
+}
+
+// SetFog sets the fog stack of the player to the fog identifiers passed, such
+// as "minecraft:fog_hell". Fogs later in the stack are applied on top of the
+// fogs before them. Calling SetFog without identifiers removes all fog set.
+func (p *Player) SetFog(stack ...string) {
+	p.fog = slices.Clone(stack)
+	p.session().SendFog(p.fog)
+}
+
+// Fog returns the fog stack of the player, as last set using Player.SetFog.
+func (p *Player) Fog() []string {
+	return slices.Clone(p.fog)
+}
+
 // SetNameTag changes the name tag displayed over the player in-game. Changing the name tag does not change
 // the player's name in, for example, the player list or the chat.
 func (p *Player) SetNameTag(name string) {
@@ -433,6 +542,95 @@ func (p *Player) NameTag() string {
 	return p.nameTag
 }
 
+// AddTag adds a tag to the player. Tags are not shown in-game, but may be used to select players using the tag
+// argument of a command target selector, such as @a[tag=red]. Adding a tag that the player already has does nothing.
+func (p *Player) AddTag(tag string) {
+	if !slices.Contains(p.tags, tag) {
+		p.tags = append(p.tags, tag)
+	}
+}
+
+// RemoveTag removes a tag previously added using AddTag from the player.
+func (p *Player) RemoveTag(tag string) {
+	p.tags = slices.DeleteFunc(p.tags, func(t string) bool {
+		return t == tag
+	})
+}
+
+// Tags returns all tags that were added to the player using AddTag.
+func (p *Player) Tags() []string {
+	return slices.Clone(p.tags)
+}
+
+// SetScore sets the score of the player for the objective passed. Scores are not shown in-game, but may be used to
+// select players using the scores argument of a command target selector, such as @a[scores={kills=5..}].
+func (p *Player) SetScore(objective string, score int) {
+	if p.scores == nil {
+		p.scores = make(map[string]int)
+	}
+	p.scores[objective] = score
+}
+
+// RemoveScore removes the score of the player for the objective passed, previously set using SetScore.
+func (p *Player) RemoveScore(objective string) {
+	delete(p.scores, objective)
+}
+
+// Score returns the score of the player for the objective passed, as set using SetScore. If the player has no
+// score for the objective, false is returned.
+func (p *Player) Score(objective string) (int, bool) {
+	score, ok := p.scores[objective]
+	return score, ok
+}
+
+// SetOperator changes the operator status of the player. Operators have all permissions that were not explicitly
+// denied using SetPermission and are able to use operator features of the client, such as the cheats settings.
+// Note that SetOperator does not change the operator list of the server: Use Server.SetOperator to make the operator
+// status of a player persistent.
+func (p *Player) SetOperator(op bool) {
+	p.operator = op
+	p.session().SendAbilities(p)
+}
+
+// Operator checks if the player is an operator. See SetOperator for more information.
+func (p *Player) Operator() bool {
+	return p.operator
+}
+
+// SetPermission grants or denies the permission passed to the player. A permission ending in ".*", such as
+// "dragonfly.command.*", applies to all permissions starting with it, and "*" applies to all permissions. Permissions
+// set with SetPermission take precedence over the operator status of the player.
+func (p *Player) SetPermission(perm string, allowed bool) {
+	if p.permissions == nil {
+		p.permissions = make(map[string]bool)
+	}
+	p.permissions[perm] = allowed
+}
+
+// UnsetPermission removes a permission previously set using SetPermission, so that the operator status of the player
+// decides if the player has it again.
+func (p *Player) UnsetPermission(perm string) {
+	delete(p.permissions, perm)
+}
+
+// HasPermission checks if the player has the permission passed. The most specific permission set using
+// SetPermission that applies to perm decides if the player has it. If none applies, only operators have the
+// permission.
+func (p *Player) HasPermission(perm string) bool {
+	if allowed, ok := p.permissions[perm]; ok {
+		return allowed
+	}
+	for i := strings.LastIndexByte(perm, '.'); i != -1; i = strings.LastIndexByte(perm[:i], '.') {
+		if allowed, ok := p.permissions[perm[:i]+".*"]; ok {
+			return allowed
+		}
+	}
+	if allowed, ok := p.permissions["*"]; ok {
+		return allowed
+	}
+	return p.operator
+}
+
 // SetScoreTag changes the score tag displayed over the player in-game. The score tag is displayed under the player's
 // name tag.
 func (p *Player) SetScoreTag(a ...any) {
@@ -516,6 +714,11 @@ func (p *Player) Heal(health float64, source world.HealingSource) {
 
 // updateFallState is called to update the entities falling state.
 func (p *Player) updateFallState(distanceThisTick float64) {
+	if _, riding := p.H().Vehicle(); riding {
+		// Players riding an entity do not take fall damage themselves.
+		p.ResetFallDistance()
+		return
+	}
 	if p.OnGround() {
 		if p.fallDistance > 0 {
 			p.fall(p.fallDistance)
@@ -578,6 +781,7 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 		return 0, false
 	}
 	p.setAttackImmunity(immunity, totalDamage)
+	p.Wake()
 
 	if a := p.Absorption(); a > 0 {
 		p.SetAbsorption(a - damageLeft)
@@ -836,6 +1040,8 @@ func (p *Player) kill(src world.DamageSource) {
 	p.Handler().HandleDeath(p, src, &keepInv)
 	p.StopSneaking()
 	p.StopSprinting()
+	p.Wake()
+	p.Dismount()
 
 	pos := p.Position()
 	if !keepInv {
@@ -921,7 +1127,8 @@ func (p *Player) respawn(f func(p *Player)) {
 	// We can use the principle here that returning through a portal of a specific dimension inside that dimension will
 	// always bring us back to the overworld.
 	w := p.tx.World().PortalDestination(p.tx.World().Dimension())
-	pos := w.PlayerSpawn(p.UUID()).Vec3Middle()
+	spawn := w.PlayerSpawn(p.UUID())
+	pos := spawn.Vec3Middle()
 
 	p.addHealth(p.MaxHealth())
 	p.hunger.Reset()
@@ -929,11 +1136,27 @@ func (p *Player) respawn(f func(p *Player)) {
 	p.Extinguish()
 	p.ResetFallDistance()
 
+	spawnWorld := w
 	p.Handler().HandleRespawn(p, &pos, &w)
 
 	handle := p.tx.RemoveEntity(p)
 	w.Exec(func(tx *world.Tx) {
 		np := tx.AddEntity(handle).(*Player)
+		if pos == spawn.Vec3Middle() && w == spawnWorld {
+			// The spawn position was not changed by the handler. If the spawn
+			// is the bed of the player, spawn it next to the bed.
+			if bedPos, ok := bedRespawnPosition(tx, spawn); ok {
+				pos = bedPos
+			} else if np.hasBedSpawn && np.bedSpawn == spawn {
+				// The bed that the spawn point was set to is gone, so the
+				// spawn point of the player is removed and the player spawns
+				// at the spawn of the world from now on.
+				np.hasBedSpawn = false
+				w.RemovePlayerSpawn(np.UUID())
+				pos = w.Spawn().Vec3Middle()
+				np.Messaget(chat.MessageBedNotValid)
+			}
+		}
 		np.Teleport(pos)
 		np.session().SendRespawn(pos, p)
 		np.SetVisible()
@@ -943,6 +1166,32 @@ func (p *Player) respawn(f func(p *Player)) {
 	})
 }
 
+// bedRespawnPosition returns the position next to the bed with its head at
+// the position passed that a player should respawn at. False is returned if
+// there is no bed at the position.
+func bedRespawnPosition(tx *world.Tx, head cube.Pos) (mgl64.Vec3, bool) {
+	b, ok := tx.Block(head).(block.Bed)
+	if !ok || !b.Head {
+		return mgl64.Vec3{}, false
+	}
+	free := func(pos cube.Pos) bool {
+		return len(tx.Block(pos).Model().BBox(pos, tx)) == 0 && len(tx.Block(pos.Side(cube.FaceUp)).Model().BBox(pos.Side(cube.FaceUp), tx)) == 0
+	}
+	foot := head.Side(b.Facing.Opposite().Face())
+	for _, bed := range []cube.Pos{head, foot} {
+		for _, d := range cube.Directions() {
+			pos := bed.Side(d.Face())
+			below := pos.Side(cube.FaceDown)
+			if free(pos) && tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+				return pos.Vec3Middle(), true
+			}
+		}
+	}
+	// No free position was found around the bed, so we spawn the player on
+	// top of the bed instead.
+	return head.Vec3Middle().Add(mgl64.Vec3{0, 0.5625}), true
+}
+
 // StartSprinting makes a player start sprinting, increasing the speed of the player by 30% and making
 // particles show up under the feet. The player will only start sprinting if its food level is high enough.
 // If the player is sneaking when calling StartSprinting, it is stopped from sneaking.
@@ -1107,6 +1356,187 @@ func (p *Player) StopGliding() {
 	p.updateState()
 }
 
+// Sleep makes the player sleep in the bed at the position passed. The player is moved onto the bed and shown in
+// the sleeping pose. Sleep does not check if the player is able to sleep at the current time: block.Bed performs
+// these checks when activated. If the player is already sleeping, Sleep does nothing.
+func (p *Player) Sleep(pos cube.Pos) {
+	if p.sleeping || p.Dead() {
+		return
+	}
+	ctx := event.C(p)
+	if p.Handler().HandleSleep(ctx, pos); ctx.Cancelled() {
+		return
+	}
+	p.StopSneaking()
+	p.StopSprinting()
+	p.StopSwimming()
+	p.StopCrawling()
+	p.StopGliding()
+
+	p.sleeping, p.sleepPos = true, pos
+	block.SetBedOccupied(pos, true, p.tx)
+	p.teleport(pos.Vec3Middle().Add(mgl64.Vec3{0, 0.5625}))
+	p.updateState()
+}
+
+// SetBedSpawn sets the spawn point of the player to the bed with its head at
+// the position passed. If the bed is gone once the player respawns, its spawn
+// point is reset to the spawn of the world.
+func (p *Player) SetBedSpawn(head cube.Pos) {
+	p.tx.World().SetPlayerSpawn(p.UUID(), head)
+	p.bedSpawn, p.hasBedSpawn = head, true
+}
+
+// Sleeping returns the position of the bed that the player is sleeping in and true if the player is currently
+// sleeping.
+func (p *Player) Sleeping() (cube.Pos, bool) {
+	return p.sleepPos, p.sleeping
+}
+
+// Wake wakes the player up if it is currently sleeping, making it leave its bed.
+func (p *Player) Wake() {
+	if !p.sleeping {
+		return
+	}
+	p.sleeping = false
+	block.SetBedOccupied(p.sleepPos, false, p.tx)
+	p.Handler().HandleWake(p, p.sleepPos)
+
+	for _, v := range p.viewers() {
+		v.ViewEntityAction(p, entity.WakeUpAction{})
+	}
+	p.updateState()
+}
+
+// Mount makes the player ride the Rideable vehicle passed, such as a boat or a
+// minecart, in the first seat that is free. False is returned if the vehicle
+// has no free seats or if the player is already riding an entity.
+func (p *Player) Mount(vehicle world.Rideable) bool {
+	if p.Dead() {
+		return false
+	}
+	if _, riding := p.H().Vehicle(); riding {
+		return false
+	}
+	ctx := event.C(p)
+	if p.Handler().HandleMount(ctx, vehicle); ctx.Cancelled() {
+		return false
+	}
+	p.StopSneaking()
+	p.StopSprinting()
+	return p.tx.Mount(p, vehicle)
+}
+
+// Dismount makes the player stop riding the entity that it is currently
+// riding. Dismount does nothing if the player is not riding an entity.
+func (p *Player) Dismount() {
+	v, ok := p.H().Vehicle()
+	if !ok {
+		return
+	}
+	if vehicle, ok := v.Entity(p.tx); ok {
+		p.Handler().HandleDismount(p, vehicle)
+	}
+	p.tx.Dismount(p)
+}
+
+// Vehicle returns the entity that the player is currently riding. False is
+// returned if the player is not riding an entity.
+func (p *Player) Vehicle() (world.Entity, bool) {
+	if v, ok := p.H().Vehicle(); ok {
+		return v.Entity(p.tx)
+	}
+	return nil, false
+}
+
+// MoveVehicle moves the vehicle that the player is driving to the position
+// passed and changes its rotation to the rotation passed. MoveVehicle is
+// called by the session of the player for movement of a vehicle sent by its
+// client, which controls the movement of vehicles such as boats itself.
+// MoveVehicle does nothing if the player is not riding in a world.Seat that
+// has Driver set to true.
+func (p *Player) MoveVehicle(pos mgl64.Vec3, rot cube.Rotation) {
+	seat, _, ok := p.H().Seat()
+	if !ok || !seat.Driver {
+		return
+	}
+	vehicle, _ := p.Vehicle()
+	v, ok := vehicle.(interface {
+		Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64)
+	})
+	if !ok || vehicle.Position().Sub(pos).Len() > 10 {
+		// Don't allow the client to move the vehicle too far in one go.
+		return
+	}
+	v.Move(pos.Sub(vehicle.Position()), rot.Yaw()-vehicle.Rotation().Yaw(), rot.Pitch()-vehicle.Rotation().Pitch())
+}
+
+// EnterPortal marks the player as being inside a portal of the Dimension passed for the current tick. A player
+// standing in a nether portal travels after 4 seconds, or immediately if it cannot take damage. A player entering an
+// end portal travels immediately. After travelling, the player must leave the portal before it can travel again.
+func (p *Player) EnterPortal(dim world.Dimension, _ cube.Pos) {
+	p.inPortal, p.portalDimension = true, dim
+}
+
+// tickPortal ticks the portal state of the player, making the player travel through the portal that it is in once
+// it has been in it long enough. True is returned if the player travelled to another world.
+func (p *Player) tickPortal() bool {
+	if !p.inPortal {
+		p.portalTicks, p.portalCooldown = 0, false
+		return false
+	}
+	p.inPortal = false
+	if p.portalCooldown {
+		return false
+	}
+	if p.portalTicks++; p.portalDimension == world.Nether && p.GameMode().AllowsTakingDamage() && p.portalTicks < 80 {
+		return false
+	}
+	p.portalTicks, p.portalCooldown = 0, true
+	return p.travel(p.portalDimension)
+}
+
+// travel makes the player travel through a portal of the Dimension passed, moving it to the World that the portal
+// leads to. True is returned if the player was moved to another world, in which case p is no longer usable.
+func (p *Player) travel(portal world.Dimension) bool {
+	src := p.tx.World()
+	dest := src.PortalDestination(portal)
+
+	ctx := event.C(p)
+	if p.Handler().HandlePortalTravel(ctx, portal, &dest); ctx.Cancelled() || dest == nil || dest == src {
+		return false
+	}
+	from, pos := src.Dimension(), p.Position()
+
+	// The chunks searched for a nether portal are loaded in the background
+	// before the player is added to the destination world.
+	var chunks []world.ChunkPos
+	if portal == world.Nether {
+		chunks = block.NetherPortalExitChunks(from, dest.Dimension(), pos)
+	}
+	handle := p.tx.RemoveEntity(p)
+	dest.ExecLoaded(chunks, func(tx *world.Tx) {
+		var target mgl64.Vec3
+		switch {
+		case portal == world.End && tx.World().Dimension() == world.End:
+			target = block.EndPortalExit(tx)
+		case portal == world.End:
+			spawn := tx.World().PlayerSpawn(p.UUID())
+			target = spawn.Vec3Middle()
+			if bedPos, ok := bedRespawnPosition(tx, spawn); ok {
+				target = bedPos
+			}
+		default:
+			target = block.NetherPortalExit(tx, from, pos)
+		}
+		// Move the player before adding it to the world so that the chunks around the destination are loaded.
+		p.data.Pos = target
+		np := tx.AddEntity(handle).(*Player)
+		np.teleport(target)
+	})
+	return true
+}
+
 // StartFlying makes the player start flying if they aren't already. It requires the player to be in a gamemode which
 // allows flying.
 func (p *Player) StartFlying() {
@@ -1610,17 +2040,20 @@ func (p *Player) UseItemOnEntity(e world.Entity) bool {
 		return false
 	}
 	i, left := p.HeldItems()
-	usable, ok := i.Item().(item.UsableOnEntity)
-	if !ok {
-		return true
+	if usable, ok := i.Item().(item.UsableOnEntity); ok {
+		useCtx := p.useContext()
+		if usable.UseOnEntity(e, p.tx, p, useCtx) {
+			p.SwingArm()
+			p.SetHeldItems(p.subtractItem(p.damageItem(i, useCtx.Damage), useCtx.CountSub), left)
+			p.addNewItem(useCtx)
+			return true
+		}
 	}
-	useCtx := p.useContext()
-	if !usable.UseOnEntity(e, p.tx, p, useCtx) {
-		return true
+	if vehicle, ok := e.(world.Rideable); ok {
+		// Interacting with an entity that may be ridden, such as a boat, makes
+		// the player ride it if the item held was not used on it.
+		p.Mount(vehicle)
 	}
-	p.SwingArm()
-	p.SetHeldItems(p.subtractItem(p.damageItem(i, useCtx.Damage), useCtx.CountSub), left)
-	p.addNewItem(useCtx)
 	return true
 }
 
@@ -1649,6 +2082,10 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 	i, _ := p.HeldItems()
 	living, ok := e.(entity.Living)
 	if !ok {
+		if vehicle, ok := e.(entity.Vehicle); ok {
+			vehicle.Hurt(i.AttackDamage(), entity.AttackDamageSource{Attacker: p})
+			return true
+		}
 		return false
 	}
 
@@ -2036,6 +2473,8 @@ func (p *Player) Teleport(pos mgl64.Vec3) {
 	if p.Handler().HandleTeleport(ctx, pos); ctx.Cancelled() {
 		return
 	}
+	p.Wake()
+	p.Dismount()
 	p.teleport(pos)
 }
 
@@ -2047,6 +2486,7 @@ func (p *Player) teleport(pos mgl64.Vec3) {
 	}
 	p.data.Pos = pos
 	p.data.Vel = mgl64.Vec3{}
+	p.mv = movementValidator{}
 	p.ResetFallDistance()
 }
 
@@ -2140,6 +2580,7 @@ func (p *Player) SetVelocity(velocity mgl64.Vec3) {
 		p.data.Vel = velocity
 		return
 	}
+	p.mv.external = velocity
 	for _, v := range p.viewers() {
 		v.ViewEntityVelocity(p, velocity)
 	}
@@ -2369,8 +2810,19 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 		}
 	}
 
+	if p.sleeping {
+		if _, ok := p.tx.Block(p.sleepPos).(block.Bed); !ok {
+			p.Wake()
+		}
+	}
+
 	p.checkBlockCollisions(p.data.Vel)
 	p.onGround = p.checkOnGround()
+	p.tickVehicleMovement()
+	if p.tickPortal() {
+		// The player travelled to another world and is no longer usable.
+		return
+	}
 
 	p.effects.Tick(p, p.tx)
 
@@ -2394,7 +2846,7 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 		}
 	}
 
-	held, _ := p.HeldItems()
+	held, offHand := p.HeldItems()
 	if current%4 == 0 && p.usingItem {
 		if _, ok := held.Item().(item.Consumable); ok {
 			// Eating particles seem to happen roughly every 4 ticks.
@@ -2412,6 +2864,15 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 	if p.breaking {
 		p.ContinueBreaking(p.breakingFace)
 	}
+	for _, it := range []item.Stack{held, offHand} {
+		// Maps held by the player show its position and render the area around
+		// it.
+		if filled, ok := it.Item().(item.FilledMap); ok {
+			if m, ok := filled.Map(tx); ok {
+				m.Update(tx, p)
+			}
+		}
+	}
 
 	for it, ti := range p.cooldowns {
 		if time.Now().After(ti) {
@@ -2933,6 +3394,7 @@ func (p *Player) close(msg string) {
 }
 
 func (p *Player) quit(msg string) {
+	p.Wake()
 	p.h.HandleQuit(p)
 	p.h = NopHandler{}
 
@@ -2960,7 +3422,10 @@ func (p *Player) Data() Config {
 		Name:                p.nameTag,
 		Locale:              p.locale,
 		GameMode:            p.gameMode,
+		Permissions:         maps.Clone(p.permissions),
 		Position:            p.Position(),
+		BedSpawn:            p.bedSpawn,
+		HasBedSpawn:         p.hasBedSpawn,
 		Rotation:            p.Rotation(),
 		Velocity:            p.Velocity(),
 		Health:              p.Health(),
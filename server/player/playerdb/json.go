@@ -19,6 +19,8 @@ func (p *Provider) fromJson(d jsonData, lookupWorld func(world.Dimension) *world
 		XUID:                d.XUID,
 		Name:                d.Username,
 		Position:            d.Position,
+		BedSpawn:            d.BedSpawn,
+		HasBedSpawn:         d.HasBedSpawn,
 		Rotation:            cube.Rotation{d.Yaw, d.Pitch},
 		Velocity:            d.Velocity,
 		Health:              d.Health,
@@ -32,6 +34,7 @@ func (p *Provider) fromJson(d jsonData, lookupWorld func(world.Dimension) *world
 		MaxAirSupply:        d.MaxAirSupply,
 		EnchantmentSeed:     d.EnchantmentSeed,
 		GameMode:            mode,
+		Permissions:         d.Permissions,
 		Effects:             dataToEffects(d.Effects),
 		FireTicks:           d.FireTicks,
 		FallDistance:        d.FallDistance,
@@ -65,6 +68,8 @@ func (p *Provider) toJson(d player.Config, w *world.World) jsonData {
 		UUID:            d.UUID.String(),
 		Username:        d.Name,
 		Position:        d.Position,
+		BedSpawn:        d.BedSpawn,
+		HasBedSpawn:     d.HasBedSpawn,
 		Velocity:        d.Velocity,
 		Yaw:             d.Rotation.Yaw(),
 		Pitch:           d.Rotation.Pitch(),
@@ -79,6 +84,7 @@ func (p *Provider) toJson(d player.Config, w *world.World) jsonData {
 		MaxAirSupply:    d.MaxAirSupply,
 		EnchantmentSeed: d.EnchantmentSeed,
 		GameMode:        uint8(mode),
+		Permissions:     d.Permissions,
 		Effects:         effectsToData(d.Effects),
 		FireTicks:       d.FireTicks,
 		FallDistance:    d.FallDistance,
@@ -101,6 +107,8 @@ type jsonData struct {
 	XUID                             string
 	Username                         string
 	Position, Velocity               mgl64.Vec3
+	BedSpawn                         cube.Pos
+	HasBedSpawn                      bool
 	Yaw, Pitch                       float64
 	Health, MaxHealth                float64
 	Hunger                           int
@@ -110,6 +118,7 @@ type jsonData struct {
 	Experience                       int
 	AirSupply, MaxAirSupply          int
 	GameMode                         uint8
+	Permissions                      map[string]bool
 	Inventory                        jsonInventoryData
 	EnderChestInventory              []jsonSlot
 	Effects                          []jsonEffect
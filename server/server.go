@@ -42,12 +42,19 @@ import (
 // Server implements a Dragonfly server. It runs the main server loop and
 // handles the connections of players trying to join the server.
 type Server struct {
+	// cmu protects the fields of conf that may be changed while the Server is
+	// running, such as its name and maximum player count.
+	cmu  sync.RWMutex
 	conf Config
 
 	once    sync.Once
 	started atomic.Pointer[time.Time]
 
 	world, nether, end *world.World
+	// worlds holds all worlds of the Server by their lowercase name,
+	// including the default worlds.
+	wmu    sync.RWMutex
+	worlds map[string]*world.World
 
 	customBlocks []protocol.BlockEntry
 	customItems  []protocol.ItemComponentEntry
@@ -176,12 +183,69 @@ func (srv *Server) End() *world.World {
 // is full will be refused to enter. If the config has a maximum player count
 // set to 0, MaxPlayerCount will return Server.PlayerCount + 1.
 func (srv *Server) MaxPlayerCount() int {
-	if srv.conf.MaxPlayers == 0 {
+	srv.cmu.RLock()
+	maxPlayers := srv.conf.MaxPlayers
+	srv.cmu.RUnlock()
+
+	if maxPlayers == 0 {
 		srv.pmu.RLock()
 		defer srv.pmu.RUnlock()
 		return len(srv.p) + 1
 	}
-	return srv.conf.MaxPlayers
+	return maxPlayers
+}
+
+// SetMaxPlayerCount changes the maximum amount of players that are allowed to
+// play on the server at the same time. Players that are already online are
+// not disconnected if the new maximum is lower than the current player count.
+// If set to 0, the maximum grows every time a player joins.
+func (srv *Server) SetMaxPlayerCount(n int) {
+	srv.cmu.Lock()
+	defer srv.cmu.Unlock()
+	srv.conf.MaxPlayers = max(n, 0)
+}
+
+// Name returns the name of the Server, as set in the Config or through a call
+// to SetName.
+func (srv *Server) Name() string {
+	srv.cmu.RLock()
+	defer srv.cmu.RUnlock()
+	return srv.conf.Name
+}
+
+// SetName changes the name of the Server. Players joining after the call see
+// the new name in the in-game menu. If no custom StatusProvider was set in the
+// Config, the name shown in the server list is updated too.
+func (srv *Server) SetName(name string) {
+	srv.cmu.Lock()
+	defer srv.cmu.Unlock()
+	srv.conf.Name = name
+	if _, ok := srv.conf.StatusProvider.(statusProvider); ok {
+		srv.conf.StatusProvider = statusProvider{name: name}
+	}
+}
+
+// SetMaxChunkRadius changes the maximum view distance in chunks that players
+// joining the Server after the call may have. Players that are already online
+// keep the maximum they joined with. If radius is 0, the default of 12 chunks
+// is used.
+func (srv *Server) SetMaxChunkRadius(radius int) {
+	if radius <= 0 {
+		radius = 12
+	}
+	srv.cmu.Lock()
+	defer srv.cmu.Unlock()
+	srv.conf.MaxChunkRadius = radius
+}
+
+// SetJoinQuitMessages changes the messages broadcast when players join and
+// quit the Server. The messages only apply to players joining after the call.
+// Like Config.JoinMessage and Config.QuitMessage, each message must have
+// exactly 1 argument if set. Zero Translations disable the messages.
+func (srv *Server) SetJoinQuitMessages(join, quit chat.Translation) {
+	srv.cmu.Lock()
+	defer srv.cmu.Unlock()
+	srv.conf.JoinMessage, srv.conf.QuitMessage = join, quit
 }
 
 // PlayerCount returns the total number of players connected to the Server.
@@ -273,6 +337,56 @@ func (srv *Server) PlayerByXUID(xuid string) (*world.EntityHandle, bool) {
 	return nil, false
 }
 
+// Operators returns the OperatorList of the Server, which holds the XUIDs of
+// all players that are operators. Changes made to the OperatorList directly
+// only apply to players joining afterwards: Use SetOperator to also update the
+// operator status of players currently online.
+func (srv *Server) Operators() *OperatorList {
+	return srv.conf.Operators
+}
+
+// SetOperator adds the player with the name passed to or removes it from the
+// OperatorList of the Server and updates the operator status of the player if
+// they are online. Because operators are identified by their XUID, a player
+// must be online to be made an operator, while players that are offline may
+// still be removed by the name they were added with. If SetOperator is called
+// from within a transaction, the respective transaction should be passed.
+// Passing nil is otherwise valid. An error is returned if the player could not
+// be found or if the OperatorList could not be saved.
+func (srv *Server) SetOperator(tx *world.Tx, name string, op bool) error {
+	srv.pmu.RLock()
+	p, ok := sliceutil.SearchValue(slices.Collect(maps.Values(srv.p)), func(p *onlinePlayer) bool {
+		return strings.EqualFold(p.name, name)
+	})
+	srv.pmu.RUnlock()
+
+	var err error
+	switch {
+	case op && !ok:
+		return fmt.Errorf("set operator %v: player is not online", name)
+	case op:
+		err = srv.conf.Operators.Add(p.xuid, p.name)
+	case ok:
+		err = srv.conf.Operators.Remove(p.xuid)
+	default:
+		o, found := srv.conf.Operators.Lookup(name)
+		if !found {
+			return fmt.Errorf("remove operator %v: player is not an operator", name)
+		}
+		return srv.conf.Operators.Remove(o.XUID)
+	}
+	if tx != nil {
+		if e, ok := p.handle.Entity(tx); ok {
+			e.(*player.Player).SetOperator(op)
+			return err
+		}
+	}
+	p.handle.ExecWorld(func(tx *world.Tx, e world.Entity) {
+		e.(*player.Player).SetOperator(op)
+	})
+	return err
+}
+
 // CloseOnProgramEnd closes the server right before the program ends, so that
 // all data of the server are saved properly.
 func (srv *Server) CloseOnProgramEnd() {
@@ -311,6 +425,16 @@ func (srv *Server) close() {
 	}
 
 	srv.conf.Log.Debug("Closing worlds...")
+	srv.wmu.Lock()
+	for name, w := range srv.worlds {
+		if w != srv.world && w != srv.nether && w != srv.end {
+			if err := w.Close(); err != nil {
+				srv.conf.Log.Error("Close world: "+err.Error(), "world", name)
+			}
+		}
+	}
+	srv.worlds = nil
+	srv.wmu.Unlock()
 	for _, w := range []*world.World{srv.end, srv.nether, srv.world} {
 		if err := w.Close(); err != nil {
 			srv.conf.Log.Error(fmt.Sprintf("Close dimension %v: ", w.Dimension()) + err.Error())
@@ -327,9 +451,8 @@ func (srv *Server) close() {
 }
 
 // listen makes the Server listen for new connections from the Listener passed.
-// This may be used to listen for players on different interfaces. Note that
-// the maximum player count of additional Listeners added is not enforced
-// automatically. The limit must be enforced by the Listener.
+// This may be used to listen for players on different interfaces. Connections
+// are refused if the Server already holds its maximum player count.
 func (srv *Server) listen(l Listener) {
 	wg := new(sync.WaitGroup)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -351,6 +474,11 @@ func (srv *Server) listen(l Listener) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
+			if srv.full() {
+				_ = c.WritePacket(&packet.Disconnect{Message: chat.MessageServerFull.Resolve(language.Und)})
+				_ = c.Close()
+				return
+			}
 			if msg, ok := srv.conf.Allower.Allow(c.RemoteAddr(), c.IdentityData(), c.ClientData()); !ok {
 				_ = c.WritePacket(&packet.Disconnect{HideDisconnectionScreen: msg == "", Message: msg})
 				_ = c.Close()
@@ -361,6 +489,15 @@ func (srv *Server) listen(l Listener) {
 	}
 }
 
+// full checks if the Server holds its maximum player count, so that no other
+// players may join.
+func (srv *Server) full() bool {
+	srv.cmu.RLock()
+	maxPlayers := srv.conf.MaxPlayers
+	srv.cmu.RUnlock()
+	return maxPlayers != 0 && srv.PlayerCount() >= maxPlayers
+}
+
 // startListening starts making the EncodeBlock listener listen, accepting new
 // connections from players.
 func (srv *Server) startListening() {
@@ -429,6 +566,9 @@ func (srv *Server) finaliseConn(ctx context.Context, conn session.Conn, l Listen
 	dim, _ := world.DimensionID(w.Dimension())
 	data.Dimension = int32(dim)
 	data.Yaw, data.Pitch = float32(d.Rotation.Yaw()), float32(d.Rotation.Pitch())
+	if d.Operator = srv.conf.Operators.Contains(conn.IdentityData().XUID); d.Operator {
+		data.PlayerPermissions = packet.PermissionLevelOperator
+	}
 
 	if err := conn.StartGameContext(ctx, data); err != nil {
 		_ = l.Disconnect(conn, "Connection timeout.")
@@ -455,7 +595,7 @@ func (srv *Server) defaultGameData() minecraft.GameData {
 		EntityUniqueID:  1,
 		EntityRuntimeID: 1,
 
-		WorldName:       srv.conf.Name,
+		WorldName:       srv.Name(),
 		BaseGameVersion: protocol.CurrentVersion,
 
 		Time:       int64(srv.world.Time()),
@@ -519,7 +659,16 @@ func (srv *Server) handleSessionClose(tx *world.Tx, c session.Controllable) {
 		return
 	}
 
-	if err := srv.conf.PlayerProvider.Save(c.UUID(), c.(*player.Player).Data(), tx.World()); err != nil {
+	d, w := c.(*player.Player).Data(), tx.World()
+	if w != srv.world && w != srv.nether && w != srv.end {
+		// Player data does not hold the world that the player was in, so a
+		// player in a world loaded using LoadWorld would be placed at the
+		// same position in a default world when joining again. Instead, the
+		// player is saved at its spawn in the overworld.
+		w = srv.world
+		d.Position = w.PlayerSpawn(c.UUID()).Vec3Middle()
+	}
+	if err := srv.conf.PlayerProvider.Save(c.UUID(), d, w); err != nil {
 		srv.conf.Log.Error("Save player data: " + err.Error())
 	}
 	srv.pwg.Done()
@@ -530,13 +679,16 @@ func (srv *Server) handleSessionClose(tx *world.Tx, c session.Controllable) {
 func (srv *Server) createPlayer(id uuid.UUID, conn session.Conn, conf player.Config, w *world.World) incoming {
 	srv.pwg.Add(1)
 
+	srv.cmu.RLock()
 	s := session.Config{
-		Log:            srv.conf.Log,
-		MaxChunkRadius: srv.conf.MaxChunkRadius,
-		JoinMessage:    srv.conf.JoinMessage,
-		QuitMessage:    srv.conf.QuitMessage,
-		HandleStop:     srv.handleSessionClose,
+		Log:              srv.conf.Log,
+		MaxChunkRadius:   srv.conf.MaxChunkRadius,
+		ValidateMovement: srv.conf.ValidateMovement,
+		JoinMessage:      srv.conf.JoinMessage,
+		QuitMessage:      srv.conf.QuitMessage,
+		HandleStop:       srv.handleSessionClose,
 	}.New(conn)
+	srv.cmu.RUnlock()
 
 	conf.Name = conn.IdentityData().DisplayName
 	conf.XUID = conn.IdentityData().XUID
@@ -563,6 +715,7 @@ func (srv *Server) createWorld(dim world.Dimension, nether, end **world.World) *
 		Provider:        srv.conf.WorldProvider,
 		Generator:       srv.conf.Generator(dim),
 		RandomTickSpeed: srv.conf.RandomTickSpeed,
+		MobSpawning:     srv.conf.MobSpawning,
 		ReadOnly:        srv.conf.ReadOnlyWorld,
 		Entities:        srv.conf.Entities,
 		PortalDestination: func(dim world.Dimension) *world.World {
@@ -575,6 +728,7 @@ func (srv *Server) createWorld(dim world.Dimension, nether, end **world.World) *
 		},
 	}
 	w := conf.New()
+	srv.applyWorldSettings(w)
 	logger.Info("Opened dimension.", "name", w.Name())
 	return w
 }
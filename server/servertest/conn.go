@@ -0,0 +1,452 @@
+package servertest
+
+import (
+	"context"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"net"
+	"sync"
+	"time"
+)
+
+// selfEntityRuntimeID is the entity runtime ID that a Server uses for the
+// player of a connection itself.
+const selfEntityRuntimeID = 1
+
+// Conn is an in-memory connection between a Server and a scripted client. It
+// implements session.Conn, so that a Server can read the packets sent by the
+// client and write packets back to it. The remaining methods of Conn are used
+// to act as the client: Packets are sent to the Server using Send or helpers
+// such as Move and BreakBlock, and the packets sent by the Server are read
+// using Receive and Expect.
+type Conn struct {
+	d    Dialer
+	addr net.Addr
+
+	// in holds the packets sent by the client that are yet to be read by the
+	// Server.
+	in chan packet.Packet
+
+	mu sync.Mutex
+	// out holds the packets written by the Server that are yet to be received
+	// by the client. It holds at most Dialer.BufferSize packets.
+	out    []packet.Packet
+	notify chan struct{}
+
+	reason       string
+	disconnected bool
+
+	data    minecraft.GameData
+	started chan struct{}
+
+	pos       mgl64.Vec3
+	rot       cube.Rotation
+	tick      uint64
+	held      int
+	inventory [36]protocol.ItemInstance
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// newConn creates a new Conn using the Dialer passed.
+func newConn(d Dialer, addr net.Addr) *Conn {
+	return &Conn{
+		d:       d,
+		addr:    addr,
+		in:      make(chan packet.Packet, 256),
+		notify:  make(chan struct{}, 1),
+		started: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+}
+
+// IdentityData returns the login.IdentityData that the client connected with.
+func (c *Conn) IdentityData() login.IdentityData {
+	return c.d.IdentityData
+}
+
+// ClientData returns the login.ClientData that the client connected with.
+func (c *Conn) ClientData() login.ClientData {
+	return c.d.ClientData
+}
+
+// ClientCacheEnabled always returns false, so that the Server sends full chunks
+// to the Conn.
+func (c *Conn) ClientCacheEnabled() bool {
+	return false
+}
+
+// ChunkRadius returns the chunk radius requested by the client.
+func (c *Conn) ChunkRadius() int {
+	return c.d.ChunkRadius
+}
+
+// Latency always returns 0.
+func (c *Conn) Latency() time.Duration {
+	return 0
+}
+
+// Flush does nothing. Packets written to the Conn are available to the client
+// immediately.
+func (c *Conn) Flush() error {
+	return nil
+}
+
+// RemoteAddr returns the address of the client.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+// ReadPacket reads the next packet sent by the client. An error is returned if
+// the Conn is closed.
+func (c *Conn) ReadPacket() (packet.Packet, error) {
+	select {
+	case pk := <-c.in:
+		return pk, nil
+	case <-c.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+// WritePacket writes a packet to the client. The packet may later be read by
+// the client using Receive or Expect. If Dialer.BufferSize packets are already
+// waiting to be received, the oldest of them is discarded. An error is
+// returned if the Conn is closed.
+func (c *Conn) WritePacket(pk packet.Packet) error {
+	select {
+	case <-c.closed:
+		return net.ErrClosed
+	default:
+	}
+	c.mu.Lock()
+	c.track(pk)
+	if len(c.out) >= c.d.BufferSize {
+		c.out[0], c.out = nil, c.out[1:]
+	}
+	c.out = append(c.out, pk)
+	c.mu.Unlock()
+
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+	if d, ok := pk.(*packet.Disconnect); ok {
+		c.disconnect(d.Message)
+	}
+	return nil
+}
+
+// StartGameContext starts the game for the client using the minecraft.GameData
+// passed. The data may later be obtained using GameData.
+func (c *Conn) StartGameContext(ctx context.Context, data minecraft.GameData) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.data = data
+	c.pos = vec32To64(data.PlayerPosition).Sub(mgl64.Vec3{0, 1.62})
+	c.rot = cube.Rotation{float64(data.Yaw), float64(data.Pitch)}
+	c.mu.Unlock()
+	close(c.started)
+	return nil
+}
+
+// Close closes the Conn. After closing, the Server stops reading packets from
+// the Conn and the player of the client is removed.
+func (c *Conn) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+	})
+	return nil
+}
+
+// disconnect closes the Conn with a disconnection reason.
+func (c *Conn) disconnect(reason string) {
+	c.mu.Lock()
+	if !c.disconnected {
+		c.reason, c.disconnected = reason, true
+	}
+	c.mu.Unlock()
+	_ = c.Close()
+}
+
+// Disconnected returns the reason that the Server disconnected the client with
+// and true if the Server disconnected the client.
+func (c *Conn) Disconnected() (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.reason, c.disconnected
+}
+
+// GameData returns the minecraft.GameData that the Server started the game
+// with.
+func (c *Conn) GameData() minecraft.GameData {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data
+}
+
+// Position returns the position of the client as last sent to or by the
+// Server.
+func (c *Conn) Position() mgl64.Vec3 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pos
+}
+
+// Send sends a packet to the Server as if it was sent by the client. The
+// packet is handled by the Session of the client. An error is returned if the
+// Conn is closed.
+func (c *Conn) Send(pk packet.Packet) error {
+	select {
+	case c.in <- pk:
+		return nil
+	case <-c.closed:
+		return net.ErrClosed
+	}
+}
+
+// Receive returns the next packet sent by the Server. Receive blocks until a
+// packet is available or until the timeout of the Dialer passes. An error is
+// returned if no packet was received or if the Conn was closed.
+func (c *Conn) Receive() (packet.Packet, error) {
+	return c.receiveUntil(time.Now().Add(c.d.Timeout))
+}
+
+// ExpectFunc receives packets sent by the Server until a packet is found for
+// which f returns true. The packets that do not match are discarded. An error
+// is returned if no matching packet was received within the timeout of the
+// Dialer.
+func (c *Conn) ExpectFunc(f func(pk packet.Packet) bool) (packet.Packet, error) {
+	deadline := time.Now().Add(c.d.Timeout)
+	for {
+		pk, err := c.receiveUntil(deadline)
+		if err != nil {
+			return nil, err
+		}
+		if f(pk) {
+			return pk, nil
+		}
+	}
+}
+
+// receiveUntil receives the next packet sent by the Server, waiting at most
+// until the deadline passed.
+func (c *Conn) receiveUntil(deadline time.Time) (packet.Packet, error) {
+	timeout := time.NewTimer(time.Until(deadline))
+	defer timeout.Stop()
+	for {
+		c.mu.Lock()
+		if len(c.out) > 0 {
+			pk := c.out[0]
+			c.out[0], c.out = nil, c.out[1:]
+			c.mu.Unlock()
+			return pk, nil
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-c.notify:
+		case <-c.closed:
+			return nil, net.ErrClosed
+		case <-timeout.C:
+			return nil, errTimeout
+		}
+	}
+}
+
+// Expect receives packets sent by the Server until a packet of the type T is
+// found. The packets of other types are discarded. An error is returned if no
+// packet of the type was received within the timeout of the Dialer.
+func Expect[T packet.Packet](c *Conn) (T, error) {
+	pk, err := c.ExpectFunc(func(pk packet.Packet) bool {
+		_, ok := pk.(T)
+		return ok
+	})
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("expect %T: %w", zero, err)
+	}
+	return pk.(T), nil
+}
+
+// Move moves the client to a position with a rotation. The position passed is
+// the position of the feet of the player.
+func (c *Conn) Move(pos mgl64.Vec3, rot cube.Rotation) error {
+	c.mu.Lock()
+	c.pos, c.rot = pos, rot
+	c.mu.Unlock()
+	return c.Send(c.authInput(nil))
+}
+
+// Chat sends a chat message as the client.
+func (c *Conn) Chat(message string) error {
+	return c.Send(&packet.Text{
+		TextType:   packet.TextTypeChat,
+		SourceName: c.d.IdentityData.DisplayName,
+		Message:    message,
+		XUID:       c.d.IdentityData.XUID,
+	})
+}
+
+// ExecuteCommand executes a command as the client. The command line passed
+// should start with a slash.
+func (c *Conn) ExecuteCommand(commandLine string) error {
+	return c.Send(&packet.CommandRequest{
+		CommandLine: commandLine,
+		CommandOrigin: protocol.CommandOrigin{
+			Origin: protocol.CommandOriginPlayer,
+			UUID:   uuid.New(),
+		},
+	})
+}
+
+// SelectSlot changes the held hotbar slot of the client.
+func (c *Conn) SelectSlot(slot int) error {
+	if slot < 0 || slot > 8 {
+		return fmt.Errorf("select slot: slot %v is not in the hotbar", slot)
+	}
+	c.mu.Lock()
+	c.held = slot
+	it := c.inventory[slot]
+	c.mu.Unlock()
+	return c.Send(&packet.MobEquipment{
+		EntityRuntimeID: selfEntityRuntimeID,
+		NewItem:         it,
+		InventorySlot:   byte(slot),
+		HotBarSlot:      byte(slot),
+		WindowID:        protocol.WindowIDInventory,
+	})
+}
+
+// HeldItem returns the item in the held hotbar slot of the client, as last
+// sent by the Server.
+func (c *Conn) HeldItem() protocol.ItemInstance {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inventory[c.held]
+}
+
+// BreakBlock breaks the block at the position passed as the client, using the
+// item held by the client.
+func (c *Conn) BreakBlock(pos cube.Pos) error {
+	return c.Send(c.authInput(func(pk *packet.PlayerAuthInput) {
+		pk.InputData.Set(packet.InputFlagPerformItemInteraction)
+		pk.ItemInteractionData = c.useItemData(protocol.UseItemActionBreakBlock, pos, cube.FaceUp, mgl64.Vec3{})
+	}))
+}
+
+// UseItemOnBlock uses the item held by the client on a face of the block at
+// the position passed, for example to place a block. The click position is
+// the position on the block that was clicked, with values in the range 0-1.
+func (c *Conn) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec3) error {
+	data := c.useItemData(protocol.UseItemActionClickBlock, pos, face, clickPos)
+	return c.Send(&packet.InventoryTransaction{TransactionData: &data})
+}
+
+// UseItem uses the item held by the client in the air.
+func (c *Conn) UseItem() error {
+	data := c.useItemData(protocol.UseItemActionClickAir, cube.Pos{}, cube.FaceDown, mgl64.Vec3{})
+	return c.Send(&packet.InventoryTransaction{TransactionData: &data})
+}
+
+// AttackEntity attacks the entity with the runtime ID passed as the client.
+// The runtime ID of an entity may be found in the packet.AddActor or
+// packet.AddPlayer packet sent by the Server.
+func (c *Conn) AttackEntity(runtimeID uint64) error {
+	c.mu.Lock()
+	slot, held, pos := c.held, c.inventory[c.held], c.pos
+	c.mu.Unlock()
+	return c.Send(&packet.InventoryTransaction{
+		TransactionData: &protocol.UseItemOnEntityTransactionData{
+			TargetEntityRuntimeID: runtimeID,
+			ActionType:            protocol.UseItemOnEntityActionAttack,
+			HotBarSlot:            int32(slot),
+			HeldItem:              held,
+			Position:              vec64To32(pos.Add(mgl64.Vec3{0, 1.62})),
+		},
+	})
+}
+
+// authInput creates a packet.PlayerAuthInput with the current position and
+// rotation of the client. If f is not nil, it is called to modify the packet
+// before it is returned.
+func (c *Conn) authInput(f func(pk *packet.PlayerAuthInput)) *packet.PlayerAuthInput {
+	c.mu.Lock()
+	c.tick++
+	pk := &packet.PlayerAuthInput{
+		Pitch:     float32(c.rot.Pitch()),
+		Yaw:       float32(c.rot.Yaw()),
+		HeadYaw:   float32(c.rot.Yaw()),
+		Position:  vec64To32(c.pos.Add(mgl64.Vec3{0, 1.62})),
+		InputData: protocol.NewBitset(packet.PlayerAuthInputBitsetSize),
+		Tick:      c.tick,
+	}
+	c.mu.Unlock()
+	if f != nil {
+		f(pk)
+	}
+	return pk
+}
+
+// useItemData creates a protocol.UseItemTransactionData with an action type
+// for the held item of the client.
+func (c *Conn) useItemData(action uint32, pos cube.Pos, face cube.Face, clickPos mgl64.Vec3) protocol.UseItemTransactionData {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return protocol.UseItemTransactionData{
+		ActionType:      action,
+		BlockPosition:   protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
+		BlockFace:       int32(face),
+		HotBarSlot:      int32(c.held),
+		HeldItem:        c.inventory[c.held],
+		Position:        vec64To32(c.pos.Add(mgl64.Vec3{0, 1.62})),
+		ClickedPosition: vec64To32(clickPos),
+	}
+}
+
+// track updates the state of the client as a result of a packet written by
+// the Server. c.mu must be held when track is called.
+func (c *Conn) track(pk packet.Packet) {
+	switch pk := pk.(type) {
+	case *packet.MovePlayer:
+		if pk.EntityRuntimeID == selfEntityRuntimeID {
+			c.pos = vec32To64(pk.Position).Sub(mgl64.Vec3{0, 1.62})
+			c.rot = cube.Rotation{float64(pk.Yaw), float64(pk.Pitch)}
+		}
+	case *packet.CorrectPlayerMovePrediction:
+		if pk.PredictionType == packet.PredictionTypePlayer {
+			c.pos = vec32To64(pk.Position).Sub(mgl64.Vec3{0, 1.62})
+		}
+	case *packet.InventoryContent:
+		if pk.WindowID == protocol.WindowIDInventory {
+			copy(c.inventory[:], pk.Content)
+		}
+	case *packet.InventorySlot:
+		if pk.WindowID == protocol.WindowIDInventory && int(pk.Slot) < len(c.inventory) {
+			c.inventory[pk.Slot] = pk.NewItem
+		}
+	case *packet.PlayerHotBar:
+		if pk.WindowID == protocol.WindowIDInventory && pk.SelectHotBarSlot {
+			c.held = int(pk.SelectedHotBarSlot)
+		}
+	}
+}
+
+// vec32To64 converts a mgl32.Vec3 to a mgl64.Vec3.
+func vec32To64(vec3 mgl32.Vec3) mgl64.Vec3 {
+	return mgl64.Vec3{float64(vec3[0]), float64(vec3[1]), float64(vec3[2])}
+}
+
+// vec64To32 converts a mgl64.Vec3 to a mgl32.Vec3.
+func vec64To32(vec3 mgl64.Vec3) mgl32.Vec3 {
+	return mgl32.Vec3{float32(vec3[0]), float32(vec3[1]), float32(vec3[2])}
+}
@@ -0,0 +1,162 @@
+// Package servertest provides an in-memory Listener and client connection that
+// may be used to test a server.Server without a network or a real Minecraft
+// client. A Listener is added to the server.Config of a Server, after which
+// clients are connected to it using a Dialer:
+//
+//	l := servertest.NewListener()
+//	conf.Listeners = append(conf.Listeners, l.Listen)
+//	srv := conf.New()
+//	srv.Listen()
+//	go func() {
+//		for p := range srv.Accept() {
+//			p.Handle(handler{})
+//		}
+//	}()
+//
+//	conn, err := servertest.Dialer{IdentityData: login.IdentityData{DisplayName: "Steve"}}.Dial(ctx, l)
+//
+// The Conn returned by Dialer.Dial may then be used to send packets to the
+// Server and to assert on the packets that the Server sends back.
+package servertest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Listener is an in-memory server.Listener. Connections are made to it using
+// Dialer.Dial. A Listener is created using NewListener.
+type Listener struct {
+	incoming chan *Conn
+	closed   chan struct{}
+	once     sync.Once
+
+	port atomic.Int32
+}
+
+// NewListener creates a new Listener that is ready to be added to a
+// server.Config.
+func NewListener() *Listener {
+	return &Listener{incoming: make(chan *Conn), closed: make(chan struct{})}
+}
+
+// Listen returns the Listener. It may be added to server.Config.Listeners to
+// make the Server accept connections from the Listener.
+func (l *Listener) Listen(server.Config) (server.Listener, error) {
+	return l, nil
+}
+
+// Accept blocks until the next connection is dialled and returns it. An error
+// is returned if the Listener was closed using Close.
+func (l *Listener) Accept() (session.Conn, error) {
+	select {
+	case conn := <-l.incoming:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+// Disconnect disconnects a connection from the Listener with a reason.
+func (l *Listener) Disconnect(conn session.Conn, reason string) error {
+	c, ok := conn.(*Conn)
+	if !ok {
+		return fmt.Errorf("disconnect: connection %T was not created by servertest", conn)
+	}
+	c.disconnect(reason)
+	return nil
+}
+
+// Close closes the Listener. Any blocked calls to Accept return an error. Conns
+// that were already accepted are not closed.
+func (l *Listener) Close() error {
+	l.once.Do(func() {
+		close(l.closed)
+	})
+	return nil
+}
+
+// Dialer holds the data that a client connects to a Listener with. The zero
+// value of Dialer is valid for use, in which case a client with a random UUID
+// and name is connected.
+type Dialer struct {
+	// IdentityData is the identity of the client. If IdentityData.Identity is
+	// empty, a random UUID is used. If IdentityData.DisplayName is empty, a
+	// name is derived from the UUID.
+	IdentityData login.IdentityData
+	// ClientData is the additional data of the client, such as its skin and
+	// language. If ClientData.LanguageCode is empty, en_US is used.
+	ClientData login.ClientData
+	// ChunkRadius is the chunk radius requested by the client. If 0, a chunk
+	// radius of 4 is requested.
+	ChunkRadius int
+	// Timeout is the maximum duration that methods of the Conn wait for a
+	// packet from the Server. If 0, a timeout of 5 seconds is used.
+	Timeout time.Duration
+	// BufferSize is the maximum number of packets sent by the Server that the
+	// Conn holds until they are received by the client. If the buffer is full,
+	// the oldest packet is discarded to make room for a new one. If 0, a
+	// buffer size of 4096 is used.
+	BufferSize int
+}
+
+// Dial connects a new client to the Listener passed and blocks until the
+// Server has started the game for it. An error is returned if the Server
+// disconnected the client, for example because it was refused by the
+// server.Allower, or if the context passed was cancelled before the game was
+// started.
+func (d Dialer) Dial(ctx context.Context, l *Listener) (*Conn, error) {
+	if d.IdentityData.Identity == "" {
+		d.IdentityData.Identity = uuid.New().String()
+	}
+	if d.IdentityData.DisplayName == "" {
+		d.IdentityData.DisplayName = "Player" + d.IdentityData.Identity[:8]
+	}
+	if d.ClientData.LanguageCode == "" {
+		d.ClientData.LanguageCode = "en_US"
+	}
+	if d.ChunkRadius == 0 {
+		d.ChunkRadius = 4
+	}
+	if d.Timeout == 0 {
+		d.Timeout = time.Second * 5
+	}
+	if d.BufferSize <= 0 {
+		d.BufferSize = 4096
+	}
+	port := 30000 + int(l.port.Add(1))
+	addr, _ := net.ResolveUDPAddr("udp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	conn := newConn(d, addr)
+
+	select {
+	case l.incoming <- conn:
+	case <-l.closed:
+		return nil, fmt.Errorf("dial: %w", net.ErrClosed)
+	case <-ctx.Done():
+		return nil, fmt.Errorf("dial: %w", ctx.Err())
+	}
+	select {
+	case <-conn.started:
+		return conn, nil
+	case <-conn.closed:
+		reason, _ := conn.Disconnected()
+		return nil, fmt.Errorf("dial: disconnected: %v", reason)
+	case <-ctx.Done():
+		_ = conn.Close()
+		return nil, fmt.Errorf("dial: %w", ctx.Err())
+	}
+}
+
+// errTimeout is returned when no matching packet was received from the Server
+// within the timeout of a Conn.
+var errTimeout = errors.New("timed out waiting for packet")
@@ -0,0 +1,206 @@
+package servertest
+
+import (
+	"context"
+	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// newServer starts a Server that accepts connections from the Listener
+// returned. The players that join the Server are sent to the channel
+// returned. The Server is closed when the test ends.
+func newServer(t *testing.T, conf server.Config) (*Listener, <-chan *player.Player) {
+	t.Helper()
+	l := NewListener()
+	conf.Log = slog.New(slog.NewTextHandler(io.Discard, nil))
+	conf.Listeners = []func(server.Config) (server.Listener, error){l.Listen}
+	conf.DisableResourceBuilding = true
+	srv := conf.New()
+	srv.Listen()
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+
+	players := make(chan *player.Player, 1)
+	go func() {
+		for p := range srv.Accept() {
+			players <- p
+		}
+	}()
+	return l, players
+}
+
+// dial connects a client with the name passed to the Listener and waits for
+// its player to join.
+func dial(t *testing.T, l *Listener, players <-chan *player.Player, name string) (*Conn, *player.Player) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := Dialer{IdentityData: login.IdentityData{DisplayName: name}}.Dial(ctx, l)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	select {
+	case p := <-players:
+		return conn, p
+	case <-ctx.Done():
+		t.Fatalf("player %v did not join", name)
+		return nil, nil
+	}
+}
+
+// exec runs f on the player passed in its world and waits for it to finish.
+func exec(t *testing.T, p *player.Player, f func(tx *world.Tx, p *player.Player)) {
+	t.Helper()
+	if !p.H().ExecWorld(func(tx *world.Tx, e world.Entity) { f(tx, e.(*player.Player)) }) {
+		t.Fatalf("player %v is no longer in a world", p.Name())
+	}
+}
+
+// eventually calls f until it returns true, failing the test if it does not
+// do so within a second.
+func eventually(t *testing.T, msg string, f func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if f() {
+			return
+		}
+	}
+	t.Fatal(msg)
+}
+
+func TestDial(t *testing.T) {
+	l, players := newServer(t, server.Config{})
+	conn, p := dial(t, l, players, "Steve")
+
+	if p.Name() != "Steve" {
+		t.Errorf("expected player name Steve, got %v", p.Name())
+	}
+	if conn.GameData().WorldName == "" {
+		t.Error("expected game data to be set after dialling")
+	}
+	if _, disconnected := conn.Disconnected(); disconnected {
+		t.Error("expected client to be connected")
+	}
+}
+
+func TestDialDisallowed(t *testing.T) {
+	l, _ := newServer(t, server.Config{Allower: server.NewWhitelist()})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := (Dialer{}).Dial(ctx, l); err == nil {
+		t.Fatal("expected dial to fail for a player that is not whitelisted")
+	}
+}
+
+func TestExpect(t *testing.T) {
+	l, players := newServer(t, server.Config{})
+	conn, p := dial(t, l, players, "Steve")
+
+	exec(t, p, func(tx *world.Tx, p *player.Player) {
+		p.Message("hello")
+	})
+	pk, err := Expect[*packet.Text](conn)
+	if err != nil {
+		t.Fatalf("expect text: %v", err)
+	}
+	if pk.Message != "hello" {
+		t.Errorf("expected message hello, got %q", pk.Message)
+	}
+}
+
+func TestExpectTimeout(t *testing.T) {
+	l, _ := newServer(t, server.Config{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := Dialer{Timeout: 50 * time.Millisecond}.Dial(ctx, l)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := Expect[*packet.Transfer](conn); err == nil {
+		t.Fatal("expected timeout waiting for a packet that is never sent")
+	}
+}
+
+func TestMove(t *testing.T) {
+	l, players := newServer(t, server.Config{})
+	conn, p := dial(t, l, players, "Steve")
+
+	exec(t, p, func(tx *world.Tx, p *player.Player) {
+		p.Teleport(mgl64.Vec3{0.5, 10, 0.5})
+	})
+	eventually(t, "expected client to be teleported", func() bool {
+		return conn.Position().ApproxEqualThreshold(mgl64.Vec3{0.5, 10, 0.5}, 0.01)
+	})
+
+	target := mgl64.Vec3{1.5, 10, 0.5}
+	if err := conn.Move(target, cube.Rotation{90, 0}); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	eventually(t, "expected player to move to the position of the client", func() bool {
+		var pos mgl64.Vec3
+		exec(t, p, func(tx *world.Tx, p *player.Player) { pos = p.Position() })
+		return pos.ApproxEqualThreshold(target, 0.01)
+	})
+}
+
+func TestBreakBlock(t *testing.T) {
+	l, players := newServer(t, server.Config{})
+	conn, p := dial(t, l, players, "Steve")
+
+	pos := cube.Pos{1, 10, 0}
+	exec(t, p, func(tx *world.Tx, p *player.Player) {
+		p.SetGameMode(world.GameModeCreative)
+		tx.SetBlock(pos, block.Stone{}, nil)
+		p.Teleport(mgl64.Vec3{0.5, 10, 0.5})
+	})
+	eventually(t, "expected client to be teleported", func() bool {
+		return conn.Position().ApproxEqualThreshold(mgl64.Vec3{0.5, 10, 0.5}, 0.01)
+	})
+
+	if err := conn.BreakBlock(pos); err != nil {
+		t.Fatalf("break block: %v", err)
+	}
+	eventually(t, "expected block to be broken", func() bool {
+		var b world.Block
+		exec(t, p, func(tx *world.Tx, p *player.Player) { b = tx.Block(pos) })
+		_, air := b.(block.Air)
+		return air
+	})
+}
+
+func TestBufferSize(t *testing.T) {
+	c := newConn(Dialer{BufferSize: 2}, nil)
+	for i := range 3 {
+		_ = c.WritePacket(&packet.Text{Message: string(rune('a' + i))})
+	}
+	if len(c.out) != 2 {
+		t.Fatalf("expected 2 buffered packets, got %v", len(c.out))
+	}
+	c.d.Timeout = time.Second
+	pk, err := c.Receive()
+	if err != nil {
+		t.Fatalf("receive: %v", err)
+	}
+	if msg := pk.(*packet.Text).Message; msg != "b" {
+		t.Errorf("expected oldest packet to be discarded, got %q first", msg)
+	}
+}
@@ -0,0 +1,145 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/player/camera"
+	"github.com/df-mc/dragonfly/server/player/hud"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"slices"
+)
+
+// SetCamera sets the camera of the player to the preset of the
+// camera.Instruction passed. The preset is sent to the client first if it
+// was not yet sent or if it changed since.
+func (s *Session) SetCamera(i camera.Instruction) {
+	set := protocol.CameraInstructionSet{Preset: s.cameraPresetIndex(i.Preset())}
+	if e, d, ok := i.Ease(); ok {
+		set.Ease = protocol.Option(protocol.CameraEase{Type: uint8(e), Duration: float32(d.Seconds())})
+	}
+	if pos, ok := i.Position(); ok {
+		set.Position = protocol.Option(vec64To32(pos))
+	}
+	if rot, ok := i.Rotation(); ok {
+		set.Rotation = protocol.Option(mgl32.Vec2{float32(rot.Pitch()), float32(rot.Yaw())})
+	}
+	if pos, ok := i.Facing(); ok {
+		set.Facing = protocol.Option(vec64To32(pos))
+	}
+	if off, ok := i.ViewOffset(); ok {
+		set.ViewOffset = protocol.Option(vec2To32(off))
+	}
+	if off, ok := i.EntityOffset(); ok {
+		set.EntityOffset = protocol.Option(vec64To32(off))
+	}
+	s.writePacket(&packet.CameraInstruction{Set: protocol.Option(set)})
+}
+
+// FadeCamera fades the screen of the player to the colour of the camera.Fade
+// passed.
+func (s *Session) FadeCamera(f camera.Fade) {
+	s.writePacket(&packet.CameraInstruction{Fade: protocol.Option(protocol.CameraInstructionFade{
+		TimeData: protocol.Option(protocol.CameraFadeTimeData{
+			FadeInDuration:  float32(f.FadeInDuration().Seconds()),
+			WaitDuration:    float32(f.Duration().Seconds()),
+			FadeOutDuration: float32(f.FadeOutDuration().Seconds()),
+		}),
+		Colour: protocol.Option(f.Colour()),
+	})})
+}
+
+// ClearCamera clears all camera instructions sent to the player, returning the
+// camera to the default camera of the player.
+func (s *Session) ClearCamera() {
+	s.writePacket(&packet.CameraInstruction{Clear: protocol.Option(true)})
+}
+
+// ShakeCamera makes the camera of the player shake as specified by the
+// camera.Shake passed.
+func (s *Session) ShakeCamera(shake camera.Shake) {
+	s.writePacket(&packet.CameraShake{
+		Intensity: float32(shake.Intensity()),
+		Duration:  float32(shake.Duration().Seconds()),
+		Type:      uint8(shake.Type()),
+		Action:    packet.CameraShakeActionAdd,
+	})
+}
+
+// StopCameraShake stops any shaking of the camera of the player.
+func (s *Session) StopCameraShake() {
+	s.writePacket(&packet.CameraShake{Action: packet.CameraShakeActionStop})
+}
+
+// SetHudElementsVisible either hides the HUD elements passed or resets them
+// to be visible again, depending on the value of visible.
+func (s *Session) SetHudElementsVisible(elements []hud.Element, visible bool) {
+	pk := &packet.SetHud{Elements: make([]byte, 0, len(elements)), Visibility: packet.HudVisibilityHide}
+	if visible {
+		pk.Visibility = packet.HudVisibilityReset
+	}
+	for _, e := range elements {
+		pk.Elements = append(pk.Elements, byte(e))
+	}
+	s.writePacket(pk)
+}
+
+// SendFog sends the fog stack passed to the player. The last fog identifier
+// in the stack is applied on top of the others.
+func (s *Session) SendFog(stack []string) {
+	s.writePacket(&packet.PlayerFog{Stack: slices.Clone(stack)})
+}
+
+// cameraPresetIndex returns the index of the camera.Preset passed in the list
+// of presets sent to the client. If the preset was not yet sent, or was sent
+// with different properties, the list is updated and sent to the client again.
+func (s *Session) cameraPresetIndex(p camera.Preset) uint32 {
+	s.cameraMu.Lock()
+	defer s.cameraMu.Unlock()
+
+	index := slices.IndexFunc(s.cameraPresets, func(other camera.Preset) bool {
+		return other.Name() == p.Name()
+	})
+	switch {
+	case index == -1:
+		index = len(s.cameraPresets)
+		s.cameraPresets = append(s.cameraPresets, p)
+	case s.cameraPresets[index] != p:
+		s.cameraPresets[index] = p
+	default:
+		return uint32(index)
+	}
+	presets := make([]protocol.CameraPreset, 0, len(s.cameraPresets))
+	for _, preset := range s.cameraPresets {
+		presets = append(presets, cameraPresetToProtocol(preset))
+	}
+	s.writePacket(&packet.CameraPresets{Presets: presets})
+	return uint32(index)
+}
+
+// cameraPresetToProtocol converts a camera.Preset to its protocol
+// representation.
+func cameraPresetToProtocol(p camera.Preset) protocol.CameraPreset {
+	preset := protocol.CameraPreset{Name: p.Name(), Parent: p.Parent()}
+	if pos, ok := p.Position(); ok {
+		preset.PosX, preset.PosY, preset.PosZ = protocol.Option(float32(pos[0])), protocol.Option(float32(pos[1])), protocol.Option(float32(pos[2]))
+	}
+	if rot, ok := p.Rotation(); ok {
+		preset.RotX, preset.RotY = protocol.Option(float32(rot.Pitch())), protocol.Option(float32(rot.Yaw()))
+	}
+	if off, ok := p.ViewOffset(); ok {
+		preset.ViewOffset = protocol.Option(vec2To32(off))
+	}
+	if off, ok := p.EntityOffset(); ok {
+		preset.EntityOffset = protocol.Option(vec64To32(off))
+	}
+	if radius, ok := p.Radius(); ok {
+		preset.Radius = protocol.Option(float32(radius))
+	}
+	return preset
+}
+
+// vec2To32 converts a mgl64.Vec2 to a mgl32.Vec2.
+func vec2To32(vec2 mgl64.Vec2) mgl32.Vec2 {
+	return mgl32.Vec2{float32(vec2[0]), float32(vec2[1])}
+}
@@ -1,12 +1,18 @@
 package session
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 	"golang.org/x/text/language"
 	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 )
 
 // SendCommandOutput sends the output of a command to the player. It will be shown to the caller of the
@@ -46,7 +52,7 @@ type translation interface {
 
 // sendAvailableCommands sends all available commands of the server. Once sent, they will be visible in the
 // /help list and will be auto-completed.
-func (s *Session) sendAvailableCommands(co Controllable) map[string]map[int]cmd.Runnable {
+func (s *Session) sendAvailableCommands(co Controllable, tx *world.Tx) map[string]map[int]cmd.Runnable {
 	commands := cmd.Commands()
 	m := make(map[string]map[int]cmd.Runnable, len(commands))
 
@@ -82,7 +88,7 @@ func (s *Session) sendAvailableCommands(co Controllable) map[string]map[int]cmd.
 
 		for i, params := range params {
 			for _, paramInfo := range params {
-				t, enum := valueToParamType(paramInfo, co)
+				t, enum := valueToParamType(paramInfo, co, tx)
 				t |= protocol.CommandArgValid
 				suffix := paramInfo.Suffix
 
@@ -124,6 +130,24 @@ func (s *Session) sendAvailableCommands(co Controllable) map[string]map[int]cmd.
 					Optional: paramInfo.Optional,
 					Options:  opt,
 				})
+				switch paramInfo.Value.(type) {
+				case cube.Rotation:
+					// A rotation consists of two values, so the parameter is split up into a yaw and pitch parameter.
+					params := overloads[i].Parameters
+					params[len(params)-1].Name = paramInfo.Name + "Yaw"
+					overloads[i].Parameters = append(params, protocol.CommandParameter{
+						Name:     paramInfo.Name + "Pitch",
+						Type:     t,
+						Optional: paramInfo.Optional,
+					})
+				case cmd.BlockState:
+					// The block states of a block are shown as a separate, optional parameter after the block name.
+					overloads[i].Parameters = append(overloads[i].Parameters, protocol.CommandParameter{
+						Name:     "blockStates",
+						Type:     protocol.CommandArgValid | protocol.CommandArgTypeBlockStates,
+						Optional: true,
+					})
+				}
 			}
 		}
 		pk.Commands = append(pk.Commands, protocol.Command{
@@ -167,7 +191,7 @@ type commandEnum struct {
 
 // valueToParamType finds the command argument type of the value passed and returns it, in addition to creating
 // an enum if applicable.
-func valueToParamType(i cmd.ParamInfo, source cmd.Source) (t uint32, enum commandEnum) {
+func valueToParamType(i cmd.ParamInfo, source cmd.Source, tx *world.Tx) (t uint32, enum commandEnum) {
 	switch i.Value.(type) {
 	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
 		return protocol.CommandArgTypeInt, enum
@@ -177,6 +201,8 @@ func valueToParamType(i cmd.ParamInfo, source cmd.Source) (t uint32, enum comman
 		return protocol.CommandArgTypeString, enum
 	case cmd.Varargs:
 		return protocol.CommandArgTypeRawText, enum
+	case cmd.JSON:
+		return protocol.CommandArgTypeJSON, enum
 	case cmd.Target, []cmd.Target:
 		return protocol.CommandArgTypeTarget, enum
 	case bool:
@@ -186,6 +212,16 @@ func valueToParamType(i cmd.ParamInfo, source cmd.Source) (t uint32, enum comman
 		}
 	case mgl64.Vec3:
 		return protocol.CommandArgTypePosition, enum
+	case cube.Pos:
+		return protocol.CommandArgTypeBlockPosition, enum
+	case cube.Rotation, time.Duration:
+		return protocol.CommandArgTypeValue, enum
+	case cmd.BlockState:
+		return 0, commandEnum{Type: "Block", Options: blockNames()}
+	case cmd.ItemStack:
+		return 0, commandEnum{Type: "Item", Options: itemNames()}
+	case cmd.EntityType:
+		return 0, commandEnum{Type: "EntityType", Options: entityTypeNames(tx), Dynamic: true}
 	case cmd.SubCommand:
 		return 0, commandEnum{
 			Type:    "SubCommand" + i.Name,
@@ -202,10 +238,44 @@ func valueToParamType(i cmd.ParamInfo, source cmd.Source) (t uint32, enum comman
 	return protocol.CommandArgTypeValue, enum
 }
 
+// blockNames returns the names of all registered blocks without the minecraft: prefix, sorted alphabetically.
+var blockNames = sync.OnceValue(func() []string {
+	var names []string
+	for _, b := range world.Blocks() {
+		n, _ := b.EncodeBlock()
+		names = append(names, strings.TrimPrefix(n, "minecraft:"))
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+})
+
+// itemNames returns the names of all registered items without the minecraft: prefix, sorted alphabetically.
+var itemNames = sync.OnceValue(func() []string {
+	var names []string
+	for _, it := range world.Items() {
+		n, _ := it.EncodeItem()
+		names = append(names, strings.TrimPrefix(n, "minecraft:"))
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+})
+
+// entityTypeNames returns the names of all entity types registered in the world.EntityRegistry of the world of the
+// transaction passed, without the minecraft: prefix and sorted alphabetically.
+func entityTypeNames(tx *world.Tx) []string {
+	types := tx.World().EntityRegistry().Types()
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, strings.TrimPrefix(t.EncodeEntity(), "minecraft:"))
+	}
+	slices.Sort(names)
+	return names
+}
+
 // resendCommands resends all commands that a Session has access to if the map of runnable commands passed does not
 // match with the commands that the Session is currently allowed to execute.
 // True is returned if the commands were resent.
-func (s *Session) resendCommands(before map[string]map[int]cmd.Runnable, co Controllable) (map[string]map[int]cmd.Runnable, bool) {
+func (s *Session) resendCommands(before map[string]map[int]cmd.Runnable, co Controllable, tx *world.Tx) (map[string]map[int]cmd.Runnable, bool) {
 	commands := cmd.Commands()
 	m := make(map[string]map[int]cmd.Runnable, len(commands))
 
@@ -217,13 +287,13 @@ func (s *Session) resendCommands(before map[string]map[int]cmd.Runnable, co Cont
 		}
 	}
 	if len(before) != len(m) {
-		return s.sendAvailableCommands(co), true
+		return s.sendAvailableCommands(co, tx), true
 	}
 	// First check for commands that were newly added.
 	for name, r := range m {
 		for k := range r {
 			if _, ok := before[name][k]; !ok {
-				return s.sendAvailableCommands(co), true
+				return s.sendAvailableCommands(co, tx), true
 			}
 		}
 	}
@@ -231,48 +301,50 @@ func (s *Session) resendCommands(before map[string]map[int]cmd.Runnable, co Cont
 	for name, r := range before {
 		for k := range r {
 			if _, ok := m[name][k]; !ok {
-				return s.sendAvailableCommands(co), true
+				return s.sendAvailableCommands(co, tx), true
 			}
 		}
 	}
 	return m, false
 }
 
-// enums returns a map of all enums exposed to the Session and records the values those enums currently hold.
-func (s *Session) enums(co Controllable) (map[string]cmd.Enum, map[string][]string) {
-	enums, enumValues := make(map[string]cmd.Enum), make(map[string][]string)
+// softEnum returns the options of an enum that may change while the Session is active. The options are resent to the
+// client whenever they change.
+type softEnum func(co Controllable, tx *world.Tx) []string
+
+// enums returns a map of all soft enums exposed to the Session and records the values those enums currently hold.
+func (s *Session) enums(co Controllable, tx *world.Tx) (map[string]softEnum, map[string][]string) {
+	enums, enumValues := make(map[string]softEnum), make(map[string][]string)
 	for alias, c := range cmd.Commands() {
 		if c.Name() == alias {
 			for _, params := range c.Params(co) {
 				for _, paramInfo := range params {
-					if enum, ok := paramInfo.Value.(cmd.Enum); ok {
-						enums[enum.Type()] = enum
-						enumValues[enum.Type()] = enum.Options(co)
+					switch v := paramInfo.Value.(type) {
+					case cmd.EntityType:
+						enums["EntityType"] = func(_ Controllable, tx *world.Tx) []string { return entityTypeNames(tx) }
+					case cmd.Enum:
+						enums[v.Type()] = func(co Controllable, _ *world.Tx) []string { return v.Options(co) }
 					}
 				}
 			}
 		}
 	}
+	for name, enum := range enums {
+		enumValues[name] = enum(co, tx)
+	}
 	return enums, enumValues
 }
 
 // resendEnums checks the options of the enums passed against the values that were previously recorded. If they do not
 // match, the enum is resent to the client and the values are updated in the before map.
-func (s *Session) resendEnums(enums map[string]cmd.Enum, before map[string][]string, c Controllable) {
+func (s *Session) resendEnums(enums map[string]softEnum, before map[string][]string, c Controllable, tx *world.Tx) {
 	for name, enum := range enums {
 		valuesBefore := before[name]
-		values := enum.Options(c)
+		values := enum(c, tx)
 		before[name] = values
 
-		if len(valuesBefore) != len(values) {
+		if !slices.Equal(valuesBefore, values) {
 			s.writePacket(&packet.UpdateSoftEnum{EnumType: name, Options: values, ActionType: packet.SoftEnumActionSet})
-			continue
-		}
-		for k, v := range values {
-			if valuesBefore[k] != v {
-				s.writePacket(&packet.UpdateSoftEnum{EnumType: name, Options: values, ActionType: packet.SoftEnumActionSet})
-				break
-			}
 		}
 	}
 }
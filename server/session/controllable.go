@@ -34,6 +34,7 @@ type Controllable interface {
 	SetHeldSlot(slot int) error
 
 	Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64)
+	ValidateMovement(deltaPos mgl64.Vec3, ticks int) bool
 
 	Speed() float64
 	FlightSpeed() float64
@@ -42,6 +43,7 @@ type Controllable interface {
 	ExecuteCommand(commandLine string)
 	GameMode() world.GameMode
 	SetGameMode(mode world.GameMode)
+	Operator() bool
 	Effects() []effect.Effect
 
 	UseItem()
@@ -88,6 +90,12 @@ type Controllable interface {
 	StartGliding()
 	Gliding() bool
 	StopGliding()
+	Sleep(pos cube.Pos)
+	Sleeping() (cube.Pos, bool)
+	Wake()
+	Dismount()
+	MoveVehicle(pos mgl64.Vec3, rot cube.Rotation)
+	ValidateVehicleMovement(pos mgl64.Vec3) bool
 	Jump()
 
 	StartBreaking(pos cube.Pos, face cube.Face)
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
@@ -28,6 +29,26 @@ func (s *Session) parseEntityMetadata(e world.Entity) protocol.EntityMetadata {
 
 	m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagHasGravity)
 	m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagClimb)
+	if seat, _, ok := e.H().Seat(); ok {
+		// The seat offset is relative to the position of the vehicle as sent
+		// to the client, which includes the offset of the vehicle.
+		offset := seat.Position.Add(entityOffset(e))
+		if vehicle, _ := e.H().Vehicle(); vehicle != nil {
+			if o, ok := vehicle.Type().(OffsetEntity); ok {
+				offset[1] -= o.NetworkOffset()
+			}
+		}
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagRiding)
+		m[protocol.EntityDataKeySeatOffset] = vec64To32(offset)
+	}
+	if r, ok := e.(world.Rideable); ok {
+		for i, seat := range r.Seats() {
+			if seat.Driver {
+				m[protocol.EntityDataKeyControllingSeatIndex] = byte(i)
+				break
+			}
+		}
+	}
 	if g, ok := e.H().Type().(glint); ok && g.Glint() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagEnchanted)
 	}
@@ -54,6 +75,14 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if cr, ok := e.(crawler); ok && cr.Crawling() {
 		m.SetFlag(protocol.EntityDataKeyFlagsTwo, protocol.EntityDataFlagCrawling&63)
 	}
+	if sl, ok := e.(sleeper); ok {
+		if pos, sleeping := sl.Sleeping(); sleeping {
+			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSleeping)
+			// The second bit of the player flags indicates that the player is sleeping.
+			m.SetFlag(protocol.EntityDataKeyPlayerFlags, 1)
+			m[protocol.EntityDataKeyBedPosition] = protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
+		}
+	}
 	if gl, ok := e.(glider); ok && gl.Gliding() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagGliding)
 	}
@@ -187,6 +216,10 @@ type crawler interface {
 	Crawling() bool
 }
 
+type sleeper interface {
+	Sleeping() (cube.Pos, bool)
+}
+
 type glider interface {
 	Gliding() bool
 }
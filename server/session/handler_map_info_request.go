@@ -0,0 +1,41 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"image"
+	"sync"
+)
+
+// MapInfoRequestHandler handles the MapInfoRequest packet, sent by the client when it first shows a map that it
+// does not yet have the data of.
+type MapInfoRequestHandler struct {
+	mu sync.Mutex
+	// requested holds the IDs of all maps requested by the client. Changes to maps are only sent to the client
+	// if it requested the map before.
+	requested map[int64]struct{}
+}
+
+// Handle ...
+func (h *MapInfoRequestHandler) Handle(p packet.Packet, s *Session, tx *world.Tx, _ Controllable) error {
+	pk := p.(*packet.MapInfoRequest)
+	m, ok := tx.Map(pk.MapID)
+	if !ok {
+		// The client may request maps of items that were never created on the server, which we simply ignore.
+		return nil
+	}
+	h.mu.Lock()
+	h.requested[pk.MapID] = struct{}{}
+	h.mu.Unlock()
+
+	s.sendMap(m, image.Rect(0, 0, 128, 128))
+	return nil
+}
+
+// viewing checks if the client requested the map with the ID passed.
+func (h *MapInfoRequestHandler) viewing(id int64) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, ok := h.requested[id]
+	return ok
+}
@@ -0,0 +1,32 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// MoveActorAbsoluteHandler handles the MoveActorAbsolute packet, sent by the client to move the vehicle that it
+// is driving, such as a boat.
+type MoveActorAbsoluteHandler struct{}
+
+// Handle ...
+func (h *MoveActorAbsoluteHandler) Handle(p packet.Packet, s *Session, tx *world.Tx, c Controllable) error {
+	pk := p.(*packet.MoveActorAbsolute)
+	handle, ok := s.entityFromRuntimeID(pk.EntityRuntimeID)
+	if !ok || !s.drives(handle) {
+		// The client may still send movement of a vehicle that it just stopped driving, which we ignore.
+		return nil
+	}
+	e, ok := handle.Entity(tx)
+	if !ok {
+		return nil
+	}
+	pos := vec32To64(pk.Position).Sub(entityOffset(e))
+	if s.conf.ValidateMovement && !c.ValidateVehicleMovement(pos) {
+		// The vehicle was moved back to its position for the client.
+		return nil
+	}
+	c.MoveVehicle(pos, cube.Rotation{float64(pk.Rotation[1]), float64(pk.Rotation[0])})
+	return nil
+}
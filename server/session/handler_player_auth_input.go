@@ -43,7 +43,22 @@ func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Se
 
 	pk.Position = pk.Position.Sub(mgl32.Vec3{0, 1.62}) // Sub the base offset of players from the pos.
 
+	// The client sends a PlayerAuthInput packet every tick, so the movement
+	// in a packet is movement of a single tick. The tick in the packet is
+	// controlled by the client and can therefore not be used to determine the
+	// number of ticks that the movement took.
+	ticks := 1
+	s.inputTick = pk.Tick
+	if s.correction != nil {
+		s.correctionTicks++
+	}
+
 	newPos := vec32To64(pk.Position)
+	if _, riding := s.ent.Vehicle(); riding {
+		// The position of a riding player is controlled by the server, which
+		// moves it along with its vehicle. Only the rotation is used.
+		newPos = pos
+	}
 	deltaPos, deltaYaw, deltaPitch := newPos.Sub(pos), float64(pk.Yaw)-yaw, float64(pk.Pitch)-pitch
 	if mgl64.FloatEqual(deltaPos.Len(), 0) && mgl64.FloatEqual(deltaYaw, 0) && mgl64.FloatEqual(deltaPitch, 0) {
 		// The PlayerAuthInput packet is sent every tick, so don't do anything if the position and rotation
@@ -51,6 +66,7 @@ func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Se
 		return nil
 	}
 
+	validate := s.conf.ValidateMovement
 	if expected := s.teleportPos.Load(); expected != nil {
 		if newPos.Sub(*expected).Len() > 1 {
 			// The player has moved before it received the teleport packet. Ignore this movement entirely and
@@ -59,8 +75,33 @@ func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Se
 			return nil
 		}
 		s.teleportPos.Store(nil)
+		validate = false
+	}
+	if s.correction != nil {
+		if newPos.Add(mgl64.Vec3{0, 1.62}).Sub(vec32To64(s.correction.Position)).Len() > 1 {
+			// Like with teleports, the player has moved before it received the
+			// correction. Ignore the movement until the client is back close to
+			// the corrected position, resending the correction in case the
+			// client ended up elsewhere after replaying its movement.
+			if s.correctionTicks%20 == 0 {
+				s.correction.Tick = pk.Tick
+				s.writePacket(s.correction)
+			}
+			return nil
+		}
+		// The client moved back close to the corrected position. It replays
+		// its movement since the correction, so the movement is validated
+		// over all ticks since then, bounded by the number of packets that
+		// the server received in the meantime.
+		ticks = min(int(pk.Tick-s.correction.Tick), s.correctionTicks)
+		s.correction = nil
 	}
 
+	if validate && !c.ValidateMovement(deltaPos, ticks) {
+		// Only rotate the player: The client has been corrected back to its
+		// position.
+		deltaPos = mgl64.Vec3{}
+	}
 	s.moving = true
 	c.Move(deltaPos, deltaYaw, deltaPitch)
 	return nil
@@ -314,6 +314,23 @@ func (s *Session) Disconnect(message string) {
 	}
 }
 
+// CorrectMovement corrects the movement of the client back to the position
+// passed, after it sent movement that was not valid. Movement sent by the
+// client is ignored until it is back close to the position.
+func (s *Session) CorrectMovement(pos mgl64.Vec3, onGround bool) {
+	if s == Nop {
+		return
+	}
+	s.correction = &packet.CorrectPlayerMovePrediction{
+		PredictionType: packet.PredictionTypePlayer,
+		Position:       vec64To32(pos.Add(mgl64.Vec3{0, 1.62})),
+		OnGround:       onGround,
+		Tick:           s.inputTick,
+	}
+	s.correctionTicks = 0
+	s.writePacket(s.correction)
+}
+
 // SendSpeed sends the speed of the player in an UpdateAttributes packet, so that it is updated client-side.
 func (s *Session) SendSpeed(speed float64) {
 	s.writePacket(&packet.UpdateAttributes{
@@ -486,10 +503,17 @@ func (s *Session) SendAbilities(c Controllable) {
 	if mode.AllowsInteraction() {
 		abilities |= protocol.AbilityDoorsAndSwitches | protocol.AbilityOpenContainers | protocol.AbilityAttackPlayers | protocol.AbilityAttackMobs
 	}
+	// Operators are sent a higher permission level and the commands layer, so that the client shows operator
+	// features and commands that require operator permissions.
+	perm, cmdPerm, cmdAbilities := uint8(packet.PermissionLevelMember), uint8(packet.CommandPermissionLevelNormal), uint32(0)
+	if c.Operator() {
+		perm, cmdPerm = packet.PermissionLevelOperator, packet.CommandPermissionLevelGameDirectors
+		cmdAbilities = protocol.AbilityOperatorCommands | protocol.AbilityTeleport
+	}
 	s.writePacket(&packet.UpdateAbilities{AbilityData: protocol.AbilityData{
 		EntityUniqueID:     selfEntityRuntimeID,
-		PlayerPermissions:  packet.PermissionLevelMember,
-		CommandPermissions: packet.CommandPermissionLevelNormal,
+		PlayerPermissions:  perm,
+		CommandPermissions: cmdPerm,
 		Layers: []protocol.AbilityLayer{
 			{
 				Type:      protocol.AbilityLayerTypeBase,
@@ -498,6 +522,11 @@ func (s *Session) SendAbilities(c Controllable) {
 				FlySpeed:  float32(c.FlightSpeed()),
 				WalkSpeed: float32(c.Speed()),
 			},
+			{
+				Type:      protocol.AbilityLayerTypeCommands,
+				Abilities: protocol.AbilityOperatorCommands | protocol.AbilityTeleport,
+				Values:    cmdAbilities,
+			},
 		},
 	}})
 }
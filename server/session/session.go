@@ -8,6 +8,7 @@ import (
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/item/recipe"
+	"github.com/df-mc/dragonfly/server/player/camera"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/form"
 	"github.com/df-mc/dragonfly/server/player/skin"
@@ -44,6 +45,13 @@ type Session struct {
 	chunkRadius, maxChunkRadius int32
 
 	teleportPos atomic.Pointer[mgl64.Vec3]
+	// inputTick is the tick of the last PlayerAuthInput packet received.
+	inputTick uint64
+	// correction is the last correction of the movement of the client sent
+	// that the client did not yet move back to. correctionTicks counts the
+	// PlayerAuthInput packets received since.
+	correction      *packet.CorrectPlayerMovePrediction
+	correctionTicks int
 
 	entityMutex sync.RWMutex
 	// currentEntityRuntimeID holds the runtime ID assigned to the last entity. It is incremented for every
@@ -83,6 +91,11 @@ type Session struct {
 	openChunkTransactions []map[uint64]struct{}
 	invOpened             bool
 
+	cameraMu sync.Mutex
+	// cameraPresets holds the camera presets sent to the client. The index of
+	// a preset in the slice is used to refer to it in camera instructions.
+	cameraPresets []camera.Preset
+
 	closeBackground chan struct{}
 }
 
@@ -131,6 +144,11 @@ type Config struct {
 
 	MaxChunkRadius int
 
+	// ValidateMovement specifies if movement sent by the client should be
+	// validated using Controllable.ValidateMovement, or
+	// Controllable.ValidateVehicleMovement for vehicles, before it is applied.
+	ValidateMovement bool
+
 	JoinMessage, QuitMessage chat.Translation
 
 	HandleStop func(*world.Tx, Controllable)
@@ -330,7 +348,7 @@ func (s *Session) handlePackets() {
 func (s *Session) background() {
 	var (
 		r          map[string]map[int]cmd.Runnable
-		enums      map[string]cmd.Enum
+		enums      map[string]softEnum
 		enumValues map[string][]string
 		ok         bool
 		i          int
@@ -338,8 +356,8 @@ func (s *Session) background() {
 
 	s.ent.ExecWorld(func(tx *world.Tx, e world.Entity) {
 		co := e.(Controllable)
-		r = s.sendAvailableCommands(co)
-		enums, enumValues = s.enums(co)
+		r = s.sendAvailableCommands(co, tx)
+		enums, enumValues = s.enums(co, tx)
 	})
 
 	t := time.NewTicker(time.Second / 20)
@@ -353,12 +371,12 @@ func (s *Session) background() {
 				if i++; i%20 == 0 {
 					// Enum resending happens relatively often and frequent updates are more important than with full
 					// command changes. Those are generally only related to permission changes, which doesn't happen often.
-					s.resendEnums(enums, enumValues, c)
+					s.resendEnums(enums, enumValues, c, tx)
 				}
 				if i%100 == 0 {
 					// Try to resend commands only every 5 seconds.
-					if r, ok = s.resendCommands(r, c); ok {
-						enums, enumValues = s.enums(c)
+					if r, ok = s.resendCommands(r, c, tx); ok {
+						enums, enumValues = s.enums(c, tx)
 					}
 				}
 				s.sendChunks(tx, c)
@@ -478,8 +496,10 @@ func (s *Session) registerHandlers() {
 		packet.IDInventoryTransaction:      &InventoryTransactionHandler{},
 		packet.IDItemStackRequest:          &ItemStackRequestHandler{changes: map[byte]map[byte]changeInfo{}, responseChanges: map[int32]map[*inventory.Inventory]map[byte]responseChange{}},
 		packet.IDLecternUpdate:             &LecternUpdateHandler{},
+		packet.IDMapInfoRequest:            &MapInfoRequestHandler{requested: make(map[int64]struct{})},
 		packet.IDMobEquipment:              &MobEquipmentHandler{},
 		packet.IDModalFormResponse:         &ModalFormResponseHandler{forms: make(map[uint32]form.Form)},
+		packet.IDMoveActorAbsolute:         &MoveActorAbsoluteHandler{},
 		packet.IDMovePlayer:                nil,
 		packet.IDNPCRequest:                &NPCRequestHandler{},
 		packet.IDPlayerAction:              &PlayerActionHandler{},
@@ -2,6 +2,7 @@ package session
 
 import (
 	"github.com/df-mc/dragonfly/server/entity/effect"
+	"image"
 	"image/color"
 	"math/rand/v2"
 	"strings"
@@ -55,6 +56,9 @@ func (s *Session) ViewEntity(e world.Entity) {
 	if s.entityHidden(e) {
 		return
 	}
+	// Links with entities riding e or ridden by e are sent after the entity
+	// itself is added to the client.
+	defer s.viewEntityLinks(e.H())
 	var runtimeID uint64
 
 	_, controllable := e.(Controllable)
@@ -129,6 +133,8 @@ func (s *Session) ViewEntity(e world.Entity) {
 		case entity.FallingBlockType:
 			metadata[protocol.EntityDataKeyVariant] = int32(world.BlockRuntimeID(v.Behaviour().(*entity.FallingBlockBehaviour).Block()))
 		}
+	case *entity.Boat:
+		metadata[protocol.EntityDataKeyVariant] = int32(v.Type().Uint8())
 	}
 	if v, ok := e.H().Type().(NetworkEncodeableEntity); ok {
 		id = v.NetworkEncodeEntity()
@@ -193,6 +199,11 @@ func (s *Session) ViewEntityMovement(e world.Entity, pos mgl64.Vec3, rot cube.Ro
 	if (id == selfEntityRuntimeID && s.moving) || s.entityHidden(e) {
 		return
 	}
+	if _, riding := e.H().Vehicle(); riding || s.drives(e.H()) {
+		// Riding entities are moved client-side along with their vehicle, and
+		// vehicles driven by the client are moved by the client itself.
+		return
+	}
 
 	flags := byte(0)
 	if onGround {
@@ -217,6 +228,77 @@ func (s *Session) ViewEntityVelocity(e world.Entity, velocity mgl64.Vec3) {
 	})
 }
 
+// ViewEntityMount ...
+func (s *Session) ViewEntityMount(rider world.Entity, vehicle world.Rideable) {
+	s.sendEntityLink(rider.H(), vehicle.H(), true)
+}
+
+// ViewEntityDismount ...
+func (s *Session) ViewEntityDismount(rider, vehicle world.Entity) {
+	riderID, vehicleID, ok := s.linkRuntimeIDs(rider.H(), vehicle.H())
+	if !ok {
+		return
+	}
+	s.writePacket(&packet.SetActorLink{EntityLink: protocol.EntityLink{
+		RiddenEntityUniqueID: int64(vehicleID),
+		RiderEntityUniqueID:  int64(riderID),
+		Type:                 protocol.EntityLinkRemove,
+		RiderInitiated:       true,
+	}})
+}
+
+// drives checks if the entity controlled by the Session is driving the
+// entity passed, meaning the client controls its movement.
+func (s *Session) drives(h *world.EntityHandle) bool {
+	vehicle, ok := s.ent.Vehicle()
+	if !ok || vehicle != h {
+		return false
+	}
+	seat, _, _ := s.ent.Seat()
+	return seat.Driver
+}
+
+// viewEntityLinks sends the links between the entity passed and the entity it
+// is riding and the entities riding it. Links with entities not currently
+// viewed by the Session are not sent.
+func (s *Session) viewEntityLinks(h *world.EntityHandle) {
+	if vehicle, ok := h.Vehicle(); ok {
+		s.sendEntityLink(h, vehicle, false)
+	}
+	for _, rider := range h.Riders() {
+		s.sendEntityLink(rider, h, false)
+	}
+}
+
+// sendEntityLink sends a link between the rider and vehicle passed to the
+// client, provided both entities are viewed by the Session.
+func (s *Session) sendEntityLink(rider, vehicle *world.EntityHandle, riderInitiated bool) {
+	riderID, vehicleID, ok := s.linkRuntimeIDs(rider, vehicle)
+	if !ok {
+		return
+	}
+	linkType := byte(protocol.EntityLinkPassenger)
+	if seat, _, _ := rider.Seat(); seat.Driver {
+		linkType = protocol.EntityLinkRider
+	}
+	s.writePacket(&packet.SetActorLink{EntityLink: protocol.EntityLink{
+		RiddenEntityUniqueID: int64(vehicleID),
+		RiderEntityUniqueID:  int64(riderID),
+		Type:                 linkType,
+		RiderInitiated:       riderInitiated,
+	}})
+}
+
+// linkRuntimeIDs returns the runtime IDs of the rider and vehicle passed. False
+// is returned if either of the entities is not viewed by the Session.
+func (s *Session) linkRuntimeIDs(rider, vehicle *world.EntityHandle) (riderID, vehicleID uint64, ok bool) {
+	s.entityMutex.RLock()
+	defer s.entityMutex.RUnlock()
+	riderID, riderOk := s.entityRuntimeIDs[rider]
+	vehicleID, vehicleOk := s.entityRuntimeIDs[vehicle]
+	return riderID, vehicleID, riderOk && vehicleOk
+}
+
 // entityOffset returns the offset that entities have client-side.
 func entityOffset(e world.Entity) mgl64.Vec3 {
 	if offset, ok := e.H().Type().(OffsetEntity); ok {
@@ -629,6 +711,18 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventExtinguishFire
 	case sound.Ignite:
 		pk.SoundType = packet.SoundEventIgnite
+	case sound.PowerOn:
+		pk.SoundType = packet.SoundEventPowerOn
+	case sound.PowerOff:
+		pk.SoundType = packet.SoundEventPowerOff
+	case sound.PistonExtend:
+		pk.SoundType = packet.SoundEventPistonOut
+	case sound.PistonRetract:
+		pk.SoundType = packet.SoundEventPistonIn
+	case sound.EnderEyePlace:
+		pk.SoundType = packet.SoundEventEnderEyePlaced
+	case sound.EndPortalOpen:
+		pk.SoundType = packet.SoundEventEndPortalCreated
 	case sound.Burning:
 		pk.SoundType = packet.SoundEventPlayerHurtOnFire
 	case sound.Drowning:
@@ -954,6 +1048,11 @@ func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
 			ActionType:      packet.AnimateActionCriticalHit,
 			EntityRuntimeID: s.entityRuntimeID(e),
 		})
+	case entity.WakeUpAction:
+		s.writePacket(&packet.Animate{
+			ActionType:      packet.AnimateActionStopSleep,
+			EntityRuntimeID: s.entityRuntimeID(e),
+		})
 	case entity.DeathAction:
 		s.writePacket(&packet.ActorEvent{
 			EntityRuntimeID: s.entityRuntimeID(e),
@@ -1228,6 +1327,51 @@ func (s *Session) ViewWeather(raining, thunder bool) {
 	s.writePacket(pk)
 }
 
+// ViewMap sends the changes made to the map passed to the client, if it requested the map before.
+func (s *Session) ViewMap(m *world.Map, area image.Rectangle) {
+	if s.handlers[packet.IDMapInfoRequest].(*MapInfoRequestHandler).viewing(m.ID()) {
+		s.sendMap(m, area)
+	}
+}
+
+// sendMap sends the markers of the map passed to the client, along with the pixels of the map in the area passed.
+func (s *Session) sendMap(m *world.Map, area image.Rectangle) {
+	dim, _ := world.DimensionID(m.Dimension())
+	centre := m.Centre()
+	pk := &packet.ClientBoundMapItemData{
+		MapID:       m.ID(),
+		UpdateFlags: packet.MapUpdateFlagDecoration,
+		Dimension:   byte(dim),
+		LockedMap:   m.Locked(),
+		Origin:      protocol.BlockPos{int32(centre[0]), int32(centre[1]), int32(centre[2])},
+		Scale:       byte(m.Scale()),
+	}
+	for _, marker := range m.Markers() {
+		d := protocol.MapDecoration{
+			Rotation: marker.Rotation,
+			X:        byte(marker.X),
+			Y:        byte(marker.Y),
+			Colour:   color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		}
+		switch marker.Type {
+		case world.MapMarkerPlayer:
+			d.Type = protocol.MapDecorationTypeMarkerWhite
+		case world.MapMarkerPlayerOffMap:
+			d.Type = protocol.MapDecorationTypeSquareWhite
+		case world.MapMarkerFrame:
+			d.Type = protocol.MapDecorationTypeMarkerGreen
+		}
+		pk.Decorations = append(pk.Decorations, d)
+	}
+	if !area.Empty() {
+		pk.UpdateFlags |= packet.MapUpdateFlagTexture
+		pk.Width, pk.Height = int32(area.Dx()), int32(area.Dy())
+		pk.XOffset, pk.YOffset = int32(area.Min.X), int32(area.Min.Y)
+		pk.Pixels = m.Pixels(area)
+	}
+	s.writePacket(pk)
+}
+
 // nextWindowID produces the next window ID for a new window. It is an int of 1-99.
 func (s *Session) nextWindowID() byte {
 	if s.openedWindowID.CompareAndSwap(99, 1) {
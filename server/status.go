@@ -20,3 +20,23 @@ func (s statusProvider) ServerStatus(playerCount, maxPlayers int) minecraft.Serv
 		MaxPlayers:  maxPlayers,
 	}
 }
+
+// serverStatusProvider is the minecraft.ServerStatusProvider passed to the
+// Listeners of a Server. It forwards to the current StatusProvider of the
+// Server with the Server's current maximum player count.
+type serverStatusProvider struct {
+	srv *Server
+}
+
+// ServerStatus returns the minecraft.ServerStatus of the StatusProvider of the
+// Server.
+func (s serverStatusProvider) ServerStatus(playerCount, _ int) minecraft.ServerStatus {
+	s.srv.cmu.RLock()
+	provider, maxPlayers := s.srv.conf.StatusProvider, s.srv.conf.MaxPlayers
+	s.srv.cmu.RUnlock()
+
+	if maxPlayers == 0 {
+		maxPlayers = playerCount + 1
+	}
+	return provider.ServerStatus(playerCount, maxPlayers)
+}
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/pelletier/go-toml"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ConfigWatcher watches the TOML file that a UserConfig was read from and
+// applies changes made to the file to a running Server. Changes to the name,
+// maximum player count, maximum chunk radius, join and quit messages,
+// difficulty and tick range are applied immediately. Changes to other fields
+// are logged as requiring a restart of the Server. A ConfigWatcher may be
+// obtained by calling Server.WatchConfig.
+type ConfigWatcher struct {
+	srv  *Server
+	file string
+
+	mu  sync.Mutex
+	uc  UserConfig
+	mod time.Time
+}
+
+// WatchConfig returns a ConfigWatcher for the TOML file passed. uc is the
+// UserConfig that the Server was created with, which was read from the file.
+// ConfigWatcher.Run must be called to start watching the file:
+//
+//	go srv.WatchConfig("config.toml", uc).Run(ctx)
+func (srv *Server) WatchConfig(file string, uc UserConfig) *ConfigWatcher {
+	w := &ConfigWatcher{srv: srv, file: file, uc: uc}
+	if stat, err := os.Stat(file); err == nil {
+		w.mod = stat.ModTime()
+	}
+	return w
+}
+
+// Run checks the file of the ConfigWatcher for changes every second and
+// reloads it when it was changed. The file is also reloaded when the process
+// receives a SIGHUP signal. Run blocks until the context.Context passed is
+// cancelled. Errors that occur while reloading are logged.
+func (w *ConfigWatcher) Run(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			err = w.Reload()
+		case <-t.C:
+			if w.modified() {
+				err = w.Reload()
+			}
+		}
+		if err != nil {
+			w.srv.conf.Log.Error("reload config: " + err.Error())
+		}
+	}
+}
+
+// modified checks if the file of the ConfigWatcher was modified since it was
+// last loaded.
+func (w *ConfigWatcher) modified() bool {
+	stat, err := os.Stat(w.file)
+	if err != nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return !stat.ModTime().Equal(w.mod)
+}
+
+// Reload reads the file of the ConfigWatcher and applies all changes compared
+// to the UserConfig last loaded to the Server. The fields that changed are
+// logged, including those that require a restart to take effect. Nothing is
+// applied if the file could not be read or holds invalid values.
+func (w *ConfigWatcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	stat, err := os.Stat(w.file)
+	if err != nil {
+		return fmt.Errorf("stat config: %w", err)
+	}
+	data, err := os.ReadFile(w.file)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	w.mod = stat.ModTime()
+
+	uc := DefaultConfig()
+	if err := toml.Unmarshal(data, &uc); err != nil {
+		return fmt.Errorf("decode config: %w", err)
+	}
+	if _, err := parseDifficulty(uc.World.Difficulty); err != nil {
+		return err
+	}
+	var applied, restart []string
+	for _, field := range changedConfigFields(w.uc, uc) {
+		apply, ok := liveConfigFields[field]
+		if !ok {
+			restart = append(restart, field)
+			continue
+		}
+		apply(w.srv, uc)
+		applied = append(applied, field)
+	}
+	w.uc = uc
+
+	if len(applied) > 0 {
+		w.srv.conf.Log.Info("Config reloaded.", "applied", applied)
+	}
+	if len(restart) > 0 {
+		w.srv.conf.Log.Warn("Config changes require a restart to take effect.", "fields", restart)
+	}
+	return nil
+}
+
+// liveConfigFields holds the fields of a UserConfig that may be changed while a
+// Server is running, mapped to a function applying the new value to a Server.
+var liveConfigFields = map[string]func(srv *Server, uc UserConfig){
+	"Server.Name": func(srv *Server, uc UserConfig) {
+		srv.SetName(uc.Server.Name)
+	},
+	"Server.DisableJoinQuitMessages": func(srv *Server, uc UserConfig) {
+		if uc.Server.DisableJoinQuitMessages {
+			srv.SetJoinQuitMessages(chat.Translation{}, chat.Translation{})
+			return
+		}
+		srv.SetJoinQuitMessages(chat.MessageJoin, chat.MessageQuit)
+	},
+	"Players.MaxCount": func(srv *Server, uc UserConfig) {
+		srv.SetMaxPlayerCount(uc.Players.MaxCount)
+	},
+	"Players.MaximumChunkRadius": func(srv *Server, uc UserConfig) {
+		srv.SetMaxChunkRadius(uc.Players.MaximumChunkRadius)
+	},
+	"World.Difficulty": func(srv *Server, uc UserConfig) {
+		if diff, _ := parseDifficulty(uc.World.Difficulty); diff != nil {
+			for _, w := range srv.Worlds() {
+				w.SetDifficulty(diff)
+			}
+		}
+	},
+	"World.TickRange": func(srv *Server, uc UserConfig) {
+		if uc.World.TickRange != 0 {
+			for _, w := range srv.Worlds() {
+				w.SetTickRange(uc.World.TickRange)
+			}
+		}
+	},
+}
+
+// changedConfigFields returns the names of the fields that differ between the
+// UserConfigs passed, such as "Server.Name", sorted alphabetically.
+func changedConfigFields(a, b UserConfig) []string {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	var changed []string
+	for i := range va.NumField() {
+		section := va.Type().Field(i)
+		for j := range section.Type.NumField() {
+			if !reflect.DeepEqual(va.Field(i).Field(j).Interface(), vb.Field(i).Field(j).Interface()) {
+				changed = append(changed, section.Name+"."+section.Type.Field(j).Name)
+			}
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"fmt"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// WhitelistEntry is an entry of a Whitelist, holding a player that is allowed
+// to join a Server.
+type WhitelistEntry struct {
+	// Name is the name of the player. Names are case-insensitive. The name is
+	// only used to match the player until its XUID is known. After that, it is
+	// kept only to display the entry.
+	Name string `json:"name"`
+	// XUID is the XUID of the player. If empty, it is set to the XUID of the
+	// first player with the Name that joins, so that the player remains
+	// whitelisted after changing their name and others cannot join by taking
+	// the name.
+	XUID string `json:"xuid,omitempty"`
+}
+
+// Whitelist is a list of players that are allowed to join a Server. Players
+// are matched by their XUID or, for entries without an XUID, by their name.
+// Whitelist implements Allower and may be set as Config.Allower, optionally
+// combined with other Allowers using Allowers, to disallow every player not
+// in the list. If the Whitelist was loaded from a file, every change to the
+// list is written back to that file and changes made to the file by others
+// are picked up automatically. Whitelist is safe for concurrent use.
+type Whitelist struct {
+	mu      sync.Mutex
+	f       listFile[[]WhitelistEntry]
+	entries []WhitelistEntry
+}
+
+// NewWhitelist returns a Whitelist holding the entries passed. Changes to the
+// Whitelist returned are not persisted.
+func NewWhitelist(entries ...WhitelistEntry) *Whitelist {
+	return &Whitelist{entries: slices.Clone(entries)}
+}
+
+// LoadWhitelist loads a Whitelist from the JSON file passed. The file holds an
+// array of WhitelistEntry objects and is created if it does not yet exist.
+func LoadWhitelist(file string) (*Whitelist, error) {
+	w := &Whitelist{f: listFile[[]WhitelistEntry]{path: file}}
+	if _, err := w.f.load(&w.entries); err != nil {
+		return nil, fmt.Errorf("load whitelist: %w", err)
+	}
+	return w, nil
+}
+
+// Add adds the WhitelistEntry passed to the Whitelist, replacing any existing
+// entry for the same player. The XUID of the entry may be empty if it is not
+// known. An error is returned if the list could not be written to its file.
+func (w *Whitelist) Add(e WhitelistEntry) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.f.load(&w.entries); err != nil {
+		return fmt.Errorf("add to whitelist: %w", err)
+	}
+	w.entries = slices.DeleteFunc(w.entries, func(other WhitelistEntry) bool {
+		return matchesPlayer(other.Name, other.XUID, e.Name, e.XUID)
+	})
+	w.entries = append(w.entries, e)
+	return w.f.save(w.entries)
+}
+
+// Remove removes the entries with the name or XUID passed from the Whitelist.
+// False is returned if no such entry existed. An error is returned if the list
+// could not be written to its file.
+func (w *Whitelist) Remove(nameOrXUID string) (bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.f.load(&w.entries); err != nil {
+		return false, fmt.Errorf("remove from whitelist: %w", err)
+	}
+	n := len(w.entries)
+	w.entries = slices.DeleteFunc(w.entries, func(e WhitelistEntry) bool {
+		return strings.EqualFold(e.Name, nameOrXUID) || (e.XUID != "" && e.XUID == nameOrXUID)
+	})
+	if len(w.entries) == n {
+		return false, nil
+	}
+	return true, w.f.save(w.entries)
+}
+
+// Contains checks if the player with the name and XUID passed is in the
+// Whitelist. The XUID may be empty.
+func (w *Whitelist) Contains(name, xuid string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, _ = w.f.load(&w.entries)
+	return w.index(name, xuid) != -1
+}
+
+// Entries returns all entries of the Whitelist, sorted alphabetically by name.
+func (w *Whitelist) Entries() []WhitelistEntry {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, _ = w.f.load(&w.entries)
+	return slices.SortedFunc(slices.Values(w.entries), func(a, b WhitelistEntry) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+}
+
+// Allow disallows players that are not in the Whitelist. If the player matched
+// an entry by its name only, the XUID of the player is stored in the entry.
+func (w *Whitelist) Allow(_ net.Addr, d login.IdentityData, _ login.ClientData) (string, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, _ = w.f.load(&w.entries)
+	i := w.index(d.DisplayName, d.XUID)
+	if i == -1 {
+		return "You are not whitelisted on this server.", false
+	}
+	if e := &w.entries[i]; e.XUID == "" && d.XUID != "" {
+		e.XUID, e.Name = d.XUID, d.DisplayName
+		_ = w.f.save(w.entries)
+	}
+	return "", true
+}
+
+// index returns the index of the entry of the player with the name and XUID
+// passed, or -1 if the player is not in the list. index must only be called
+// while w.mu is locked.
+func (w *Whitelist) index(name, xuid string) int {
+	return slices.IndexFunc(w.entries, func(e WhitelistEntry) bool {
+		return matchesPlayer(e.Name, e.XUID, name, xuid)
+	})
+}
+
+// matchesPlayer checks if an entry of a list, such as a Whitelist or BanList,
+// with the name and XUID entryName and entryXUID applies to the player with
+// the name and XUID passed. Entries with an XUID match only by that XUID, so
+// that a player cannot match them by taking the name of another player.
+// Entries without an XUID match by name.
+func matchesPlayer(entryName, entryXUID, name, xuid string) bool {
+	if entryXUID != "" {
+		return entryXUID == xuid
+	}
+	return strings.EqualFold(entryName, name)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/segmentio/fasthash/fnv1"
 	"image"
+	"maps"
 	"math"
 	"math/bits"
 	"math/rand/v2"
@@ -227,6 +228,27 @@ func BlockByName(name string, properties map[string]any) (Block, bool) {
 	return blocks[rid], true
 }
 
+// BlockProperties returns the properties of the default state of the block
+// with the name passed. The map returned may be modified and passed to
+// BlockByName to find other states of the block. If no block with the name
+// exists, false is returned.
+func BlockProperties(name string) (map[string]any, bool) {
+	properties, ok := blockProperties[name]
+	return maps.Clone(properties), ok
+}
+
+// Blocks returns a list of all registered blocks. Block states that exist in
+// vanilla, but that have no Block implementation registered, are not included.
+func Blocks() []Block {
+	m := make([]Block, 0, len(blocks))
+	for _, b := range blocks {
+		if _, ok := b.(unknownBlock); !ok {
+			m = append(m, b)
+		}
+	}
+	return m
+}
+
 // CustomBlocks returns a map of all custom blocks registered with their names as keys.
 func CustomBlocks() map[string]CustomBlock {
 	return customBlocks
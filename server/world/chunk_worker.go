@@ -0,0 +1,175 @@
+package world
+
+import (
+	"errors"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+	"sync"
+)
+
+// chunkWorkers loads and generates chunks for a World on a pool of goroutines,
+// so that a slow Provider or Generator does not stall transactions of the
+// World. Chunks loaded by the workers are inserted into the World once a
+// transaction calls insertLoadedChunks.
+type chunkWorkers struct {
+	requests chan ChunkPos
+
+	// pending holds the positions of chunks requested that have not yet been
+	// inserted into the World. If the value is true, the chunk was loaded
+	// synchronously in the meantime and the result of the worker should be
+	// discarded. pending is only accessed within transactions.
+	pending map[ChunkPos]bool
+
+	// failed holds columns that could not be loaded because of an error. They
+	// are not added to the World, but are handed to a Loader once so that it
+	// does not request them again.
+	failed map[ChunkPos]*Column
+
+	mu     sync.Mutex
+	loaded []loadedColumn
+}
+
+// loadedColumn is a Column loaded or generated by a chunk worker or loaded
+// synchronously.
+type loadedColumn struct {
+	pos ChunkPos
+	col *Column
+	err error
+
+	// scheduled holds the scheduled block updates stored with the chunk and
+	// savedTick the tick at which they were stored. They are only added to
+	// the World once the Column is inserted.
+	scheduled []chunk.ScheduledBlockUpdate
+	savedTick int64
+}
+
+// newChunkWorkers creates chunkWorkers and starts n goroutines that load
+// chunks for the World passed.
+func newChunkWorkers(w *World, n int) *chunkWorkers {
+	cw := &chunkWorkers{requests: make(chan ChunkPos, 1024), pending: make(map[ChunkPos]bool), failed: make(map[ChunkPos]*Column)}
+	w.running.Add(n)
+	for range n {
+		go cw.work(w)
+	}
+	return cw
+}
+
+// work loads chunks requested until the World is closed.
+func (cw *chunkWorkers) work(w *World) {
+	defer w.running.Done()
+	for {
+		select {
+		case pos := <-cw.requests:
+			l := w.readColumn(pos)
+			cw.mu.Lock()
+			cw.loaded = append(cw.loaded, l)
+			cw.mu.Unlock()
+		case <-w.closing:
+			return
+		}
+	}
+}
+
+// request requests the chunk at the position passed to be loaded by one of
+// the workers. Nothing happens if the chunk was already requested. False is
+// returned if too many chunks are currently being loaded, in which case the
+// chunk should be requested again later.
+func (cw *chunkWorkers) request(pos ChunkPos) bool {
+	if _, ok := cw.pending[pos]; ok {
+		return true
+	}
+	select {
+	case cw.requests <- pos:
+		cw.pending[pos] = false
+		return true
+	default:
+		return false
+	}
+}
+
+// discard marks the result of a chunk worker loading the chunk at the
+// position passed to be discarded, because the chunk was loaded synchronously.
+func (cw *chunkWorkers) discard(pos ChunkPos) {
+	if _, ok := cw.pending[pos]; ok {
+		cw.pending[pos] = true
+	}
+}
+
+// insertLoadedChunks inserts all chunks that were loaded by the chunk workers
+// since the last call into the World. Chunks that were loaded synchronously in
+// the meantime are discarded.
+func (w *World) insertLoadedChunks() {
+	cw := w.chunkWorkers
+	cw.mu.Lock()
+	loaded := cw.loaded
+	cw.loaded = nil
+	cw.mu.Unlock()
+
+	for _, l := range loaded {
+		discard := cw.pending[l.pos]
+		delete(cw.pending, l.pos)
+		if _, ok := w.chunks[l.pos]; ok || discard {
+			continue
+		}
+		if l.err != nil {
+			w.conf.Log.Error("load chunk: "+l.err.Error(), "X", l.pos[0], "Z", l.pos[1])
+			cw.failed[l.pos] = l.col
+			continue
+		}
+		w.insertColumn(l)
+	}
+}
+
+// loadedChunk returns the chunk at the position passed if it is loaded. If
+// not, the chunk is requested to be loaded asynchronously and false is
+// returned.
+func (w *World) loadedChunk(pos ChunkPos) (*Column, bool) {
+	if c, ok := w.chunks[pos]; ok {
+		return c, true
+	}
+	if c, ok := w.chunkWorkers.failed[pos]; ok {
+		delete(w.chunkWorkers.failed, pos)
+		return c, true
+	}
+	w.chunkWorkers.request(pos)
+	return nil, false
+}
+
+// readColumn reads the chunk at the position passed from the Provider, or
+// generates it if the Provider does not have it, and fills its light. The
+// Column returned is not yet added to the World and readColumn does not touch
+// any state of the World, so it is safe to call from any goroutine.
+func (w *World) readColumn(pos ChunkPos) loadedColumn {
+	l := loadedColumn{pos: pos}
+	column, err := w.conf.Provider.LoadColumn(pos, w.conf.Dim)
+	switch {
+	case err == nil:
+		l.col, l.scheduled, l.savedTick = w.columnFrom(column, pos), column.ScheduledBlocks, column.Tick
+	case errors.Is(err, leveldb.ErrNotFound):
+		// The provider doesn't have a chunk saved at this position, so we generate a new one.
+		l.col = newColumn(chunk.New(airRID, w.Range()))
+		w.conf.Generator.GenerateChunk(pos, l.col.Chunk)
+	default:
+		l.col, l.err = newColumn(chunk.New(airRID, w.Range())), err
+	}
+	chunk.LightArea([]*chunk.Chunk{l.col.Chunk}, int(pos[0]), int(pos[1])).Fill()
+	return l
+}
+
+// insertColumn adds a Column read using readColumn to the World, schedules
+// the block updates stored with it and spreads light between it and its
+// neighbours. insertColumn must only be called within a transaction.
+func (w *World) insertColumn(l loadedColumn) {
+	w.chunks[l.pos] = l.col
+	for _, e := range l.col.Entities {
+		w.entities[e] = l.pos
+		e.w = w
+	}
+	scheduled := make([]scheduledTick, 0, len(l.scheduled))
+	for _, t := range l.scheduled {
+		bl := blockByRuntimeIDOrAir(t.Block)
+		scheduled = append(scheduled, scheduledTick{pos: t.Pos, b: bl, bhash: BlockHash(bl), t: w.scheduledUpdates.currentTick + (t.Tick - l.savedTick)})
+	}
+	w.scheduledUpdates.add(scheduled)
+	w.calculateLight(l.pos)
+}
@@ -3,6 +3,7 @@ package world
 import (
 	"log/slog"
 	"math/rand/v2"
+	"runtime"
 	"time"
 )
 
@@ -44,6 +45,16 @@ type Config struct {
 	// will stop random ticking altogether, while setting it higher results in
 	// faster ticking.
 	RandomTickSpeed int
+	// ChunkWorkers is the amount of goroutines that load and generate chunks
+	// for loaders, such as players, in the background. Transactions that need
+	// a chunk that is not yet loaded still load it directly. If 0,
+	// runtime.GOMAXPROCS(0) workers are used.
+	ChunkWorkers int
+	// MobSpawning specifies if mobs should spawn naturally in the World, as
+	// specified by the spawn lists of the Entities registry. Natural spawning
+	// is disabled by default. Mobs still spawn through other means, such as
+	// spawn eggs, if it is disabled.
+	MobSpawning bool
 	// RandSource is the rand.Source used for generation of random numbers in a
 	// World, such as when selecting blocks to tick or when deciding where to
 	// strike lightning. If set to nil, RandSource defaults to a `rand.PCG`
@@ -78,6 +89,9 @@ func (conf Config) New() *World {
 	if conf.RandomTickSpeed == 0 {
 		conf.RandomTickSpeed = 3
 	}
+	if conf.ChunkWorkers <= 0 {
+		conf.ChunkWorkers = runtime.GOMAXPROCS(0)
+	}
 	if conf.RandSource == nil {
 		t := uint64(time.Now().UnixNano())
 		conf.RandSource = rand.NewPCG(t, t)
@@ -88,6 +102,7 @@ func (conf Config) New() *World {
 		entities:         make(map[*EntityHandle]ChunkPos),
 		viewers:          make(map[*Loader]Viewer),
 		chunks:           make(map[ChunkPos]*Column),
+		maps:             make(map[int64]*Map),
 		closing:          make(chan struct{}),
 		queue:            make(chan transaction, 128),
 		r:                rand.New(conf.RandSource),
@@ -101,6 +116,7 @@ func (conf Config) New() *World {
 	w.handler.Store(&h)
 
 	w.running.Add(3)
+	w.chunkWorkers = newChunkWorkers(w, conf.ChunkWorkers)
 
 	t := ticker{interval: time.Second / 20}
 	go t.tickLoop(w)
@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
+	"image"
 	"io"
 	"maps"
 	"slices"
@@ -33,6 +34,38 @@ type EntityType interface {
 	EncodeNBT(data *EntityData) map[string]any
 }
 
+// CustomEntityType is an EntityType that is not part of vanilla Minecraft,
+// such as an NPC or boss with a model of its own. The geometry, textures,
+// render controllers and animations of a CustomEntityType registered to the
+// EntityRegistry of a server are added to the resource pack built by the
+// server, so that clients are able to render entities of the type.
+type CustomEntityType interface {
+	EntityType
+	// Name is the name of the entity type displayed to clients, for example
+	// in death messages.
+	Name() string
+	// Geometry returns the contents of a geometry (.geo.json) file holding
+	// the models of the entity type. Each geometry in the file may be referred
+	// to by render controllers by the last part of its identifier, so
+	// 'geometry.boss.angry' is referred to as 'Geometry.angry'. The first
+	// geometry in the file may also be referred to as 'Geometry.default'.
+	Geometry() []byte
+	// Textures returns the textures of the entity type, indexed by a name by
+	// which they may be referred to by render controllers, so that a texture
+	// named 'angry' is referred to as 'Texture.angry'. The texture named
+	// 'default' is used if RenderControllers returns nil.
+	Textures() map[string]image.Image
+	// RenderControllers returns the contents of a render controllers
+	// (.render_controllers.json) file, of which all render controllers are
+	// used to render entities of the type. If nil is returned, entities are
+	// rendered using the default geometry and texture.
+	RenderControllers() []byte
+	// Animations returns the contents of an animations (.animation.json)
+	// file, of which all animations are played on entities of the type, or
+	// nil if the entity type has no animations.
+	Animations() []byte
+}
+
 // EntityConfig is used to configure the initial settings of an Entity upon
 // creation using NewEntity.
 type EntityConfig interface {
@@ -53,6 +86,13 @@ type EntityHandle struct {
 
 	data EntityData
 
+	// vehicle is the entity that the entity is riding and seat is the seat of
+	// vehicle that it is riding in. riders holds the entities riding the
+	// entity, in the order of the seats they are riding in.
+	vehicle *EntityHandle
+	seat    Seat
+	riders  []*EntityHandle
+
 	// TODO Handler? Handle world change here?
 }
 
@@ -261,8 +301,8 @@ func (e *EntityHandle) setAndUnlockWorld(w *World) {
 	e.cond.Broadcast()
 }
 
-// decodeNBT decodes the position, velocity, rotation, age, on-fire duration and
-// name tag of an entity.
+// decodeNBT decodes the position, velocity, rotation, age, on-fire duration,
+// name tag and natural spawn flag of an entity.
 func (e *EntityHandle) decodeNBT(m map[string]any) {
 	e.data.Pos = readVec3(m, "Pos")
 	e.data.Vel = readVec3(m, "Motion")
@@ -270,19 +310,25 @@ func (e *EntityHandle) decodeNBT(m map[string]any) {
 	e.data.Age = time.Duration(readInt16(m, "Age")) * (time.Second / 20)
 	e.data.FireDuration = time.Duration(readInt16(m, "Fire")) * time.Second / 20
 	e.data.Name, _ = m["NameTag"].(string)
+	e.data.NaturallySpawned = readUint8(m, "NaturalSpawn") == 1
 }
 
-// encodeNBT encodes the position, velocity, rotation, age, on-fire duration and
-// name tag of an entity.
+// encodeNBT encodes the position, velocity, rotation, age, on-fire duration,
+// name tag and natural spawn flag of an entity.
 func (e *EntityHandle) encodeNBT() map[string]any {
+	natural := uint8(0)
+	if e.data.NaturallySpawned {
+		natural = 1
+	}
 	return map[string]any{
-		"Pos":     []float32{float32(e.data.Pos[0]), float32(e.data.Pos[1]), float32(e.data.Pos[2])},
-		"Motion":  []float32{float32(e.data.Vel[0]), float32(e.data.Vel[1]), float32(e.data.Vel[2])},
-		"Yaw":     float32(e.data.Rot[0]),
-		"Pitch":   float32(e.data.Rot[1]),
-		"Fire":    int16(e.data.FireDuration.Seconds() * 20),
-		"Age":     int16(e.data.Age / (time.Second * 20)),
-		"NameTag": e.data.Name,
+		"Pos":          []float32{float32(e.data.Pos[0]), float32(e.data.Pos[1]), float32(e.data.Pos[2])},
+		"Motion":       []float32{float32(e.data.Vel[0]), float32(e.data.Vel[1]), float32(e.data.Vel[2])},
+		"Yaw":          float32(e.data.Rot[0]),
+		"Pitch":        float32(e.data.Rot[1]),
+		"Fire":         int16(e.data.FireDuration.Seconds() * 20),
+		"Age":          int16(e.data.Age / (time.Second * 20)),
+		"NameTag":      e.data.Name,
+		"NaturalSpawn": natural,
 	}
 }
 
@@ -293,6 +339,11 @@ type EntityData struct {
 	Name         string
 	FireDuration time.Duration
 	Age          time.Duration
+	// NaturallySpawned specifies if the entity was spawned naturally by the
+	// World. Entities that spawned naturally in a hostile or ambient
+	// SpawnCategory despawn when no player is near them, unless they have a
+	// name tag.
+	NaturallySpawned bool
 
 	Data any
 }
@@ -371,6 +422,11 @@ type EntityRegistryConfig struct {
 	Snowball           func(opts EntitySpawnOpts, owner Entity) *EntityHandle
 	SplashPotion       func(opts EntitySpawnOpts, t any, owner Entity) *EntityHandle
 	Lightning          func(opts EntitySpawnOpts) *EntityHandle
+	Boat               func(opts EntitySpawnOpts, boat Item) *EntityHandle
+	Minecart           func(opts EntitySpawnOpts) *EntityHandle
+	// Spawns returns the entities that may spawn naturally in a Biome. If
+	// nil, no entities spawn naturally.
+	Spawns func(b Biome) []SpawnEntry
 }
 
 // New creates an EntityRegistry using conf and the EntityTypes passed.
@@ -404,6 +460,23 @@ func (reg EntityRegistry) Types() []EntityType {
 	return slices.Collect(maps.Values(reg.ent))
 }
 
+// SpawnCategory returns the SpawnCategory of an EntityType as found in the
+// natural spawns of the EntityRegistryConfig. False is returned if the
+// EntityType does not spawn naturally in any Biome.
+func (reg EntityRegistry) SpawnCategory(t EntityType) (SpawnCategory, bool) {
+	if reg.conf.Spawns == nil {
+		return 0, false
+	}
+	for _, b := range Biomes() {
+		for _, e := range reg.conf.Spawns(b) {
+			if e.Type == t {
+				return e.Category, true
+			}
+		}
+	}
+	return 0, false
+}
+
 func readVec3(x map[string]any, k string) mgl64.Vec3 {
 	if i, ok := x[k].([]any); ok {
 		if len(i) != 3 {
@@ -437,3 +510,8 @@ func readInt16(m map[string]any, k string) int16 {
 	v, _ := m[k].(int16)
 	return v
 }
+
+func readUint8(m map[string]any, k string) uint8 {
+	v, _ := m[k].(uint8)
+	return v
+}
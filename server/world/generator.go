@@ -6,6 +6,8 @@ import (
 
 // Generator handles the generating of newly created chunks. Worlds have one generator which is used to
 // generate chunks when the provider of the world cannot find a chunk at a given chunk position.
+// Chunks are generated on multiple goroutines at the same time, so implementations of Generator must be
+// safe for concurrent use.
 type Generator interface {
 	// GenerateChunk generates a chunk at a chunk position passed. The generator sets blocks in the chunk that
 	// is passed to the method.
@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math"
+	"math/rand/v2"
+)
+
+// End is a seeded, noise based world.Generator that generates terrain
+// resembling that of a vanilla End: A central island with obsidian pillars
+// and an exit portal, surrounded by a ring of void and, further out, the
+// outer islands. End always generates the same terrain for the same seed and
+// is safe for concurrent use. End must be created using NewEnd.
+type End struct {
+	islands, edge, detail octaveNoise
+	pillars               []endPillar
+
+	endStone, obsidian, bedrock uint32
+}
+
+// endPillar is one of the obsidian pillars placed in a circle on the central
+// island of the End.
+type endPillar struct {
+	x, z, radius, height int
+}
+
+// NewEnd creates an End generator that generates terrain using the seed
+// passed. Like other generators, NewEnd must only be called after the block
+// registry has been finalised.
+func NewEnd(seed int64) *End {
+	r := rand.New(rand.NewPCG(uint64(seed)^0x656e64, uint64(seed)))
+	g := &End{
+		islands: newOctaveNoise(r, 3, 1.0/110),
+		edge:    newOctaveNoise(r, 2, 1.0/40),
+		detail:  newOctaveNoise(r, 2, 1.0/20),
+
+		endStone: world.BlockRuntimeID(block.EndStone{}),
+		obsidian: world.BlockRuntimeID(block.Obsidian{}),
+		bedrock:  world.BlockRuntimeID(block.Bedrock{}),
+	}
+	// Like in vanilla, there are ten pillars with increasing sizes, which are
+	// shuffled around the circle.
+	sizes := r.Perm(10)
+	for i, size := range sizes {
+		angle := 2 * math.Pi * float64(i) / 10
+		g.pillars = append(g.pillars, endPillar{
+			x:      int(math.Round(42 * math.Cos(angle))),
+			z:      int(math.Round(42 * math.Sin(angle))),
+			radius: 2 + size/3,
+			height: 76 + size*3,
+		})
+	}
+	return g
+}
+
+// GenerateChunk generates the islands, pillars and exit portal of the chunk
+// at the position passed.
+func (g *End) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			if top, bottom, ok := g.island(baseX+x, baseZ+z); ok {
+				for y := max(bottom, c.Range().Min()); y <= min(top, c.Range().Max()); y++ {
+					c.SetBlock(uint8(x), int16(y), uint8(z), 0, g.endStone)
+				}
+			}
+			fillBiome(c, uint8(x), uint8(z), biome.End{})
+		}
+	}
+	if abs(baseX) > 64 || abs(baseZ) > 64 {
+		// The pillars and exit portal are only found close to the centre.
+		return
+	}
+	for _, p := range g.pillars {
+		g.pillar(c, baseX, baseZ, p)
+	}
+	g.exitPortal(c, baseX, baseZ)
+}
+
+// island returns the top and bottom y of the island at a specific x and z. If
+// there is no island at that position, false is returned.
+func (g *End) island(x, z int) (top, bottom int, ok bool) {
+	fx, fz := float64(x), float64(z)
+	dist := math.Sqrt(fx*fx + fz*fz)
+
+	// The central island is roughly circular, with a rugged edge.
+	var f float64
+	if radius := 90 + g.edge.at2D(fx, fz)*30; dist < radius {
+		f = 1 - dist/radius
+	} else if dist > 1000 {
+		// Outside the void surrounding the central island, the outer islands
+		// are formed wherever the island noise is high enough.
+		falloff := clamp((dist-1000)/200, 0, 1)
+		if n := g.islands.at2D(fx, fz) * 1.6 * falloff; n > 0.3 {
+			f = clamp((n-0.3)/0.7, 0, 1)
+		}
+	}
+	if f <= 0 {
+		return 0, 0, false
+	}
+	top = 56 + int(f*10+g.detail.at2D(fx, fz)*3)
+	bottom = top - int(math.Sqrt(f)*60)
+	return top, min(bottom, top-1), true
+}
+
+// pillar places the part of an obsidian pillar that is within the chunk with
+// the base x and z passed. A bedrock block is placed on top of each pillar.
+func (g *End) pillar(c *chunk.Chunk, baseX, baseZ int, p endPillar) {
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			dx, dz := baseX+x-p.x, baseZ+z-p.z
+			if dx*dx+dz*dz > p.radius*p.radius+1 {
+				continue
+			}
+			for y := 40; y <= p.height && y <= c.Range().Max(); y++ {
+				c.SetBlock(uint8(x), int16(y), uint8(z), 0, g.obsidian)
+			}
+			if dx == 0 && dz == 0 && p.height < c.Range().Max() {
+				c.SetBlock(uint8(x), int16(p.height+1), uint8(z), 0, g.bedrock)
+			}
+		}
+	}
+}
+
+// exitPortal places the part of the inactive exit portal that is within the
+// chunk with the base x and z passed. The exit portal consists of a bedrock
+// basin with a bedrock pillar in the centre, placed on top of the central
+// island.
+func (g *End) exitPortal(c *chunk.Chunk, baseX, baseZ int) {
+	top, _, _ := g.island(0, 0)
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			dx, dz := baseX+x, baseZ+z
+			d := dx*dx + dz*dz
+			if d > 12 {
+				continue
+			}
+			c.SetBlock(uint8(x), int16(top), uint8(z), 0, g.bedrock)
+			if d > 6 {
+				// The rim of the basin.
+				c.SetBlock(uint8(x), int16(top+1), uint8(z), 0, g.bedrock)
+			}
+			if d == 0 {
+				for y := top + 1; y <= top+4; y++ {
+					c.SetBlock(uint8(x), int16(y), uint8(z), 0, g.bedrock)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math"
+	"math/rand/v2"
+)
+
+// ore describes a type of ore vein generated in a chunk.
+type ore struct {
+	// stone and deepslate are the blocks placed if the vein replaces stone or
+	// deepslate respectively. If either is nil, that block is not replaced.
+	stone, deepslate world.Block
+	// count is the number of veins attempted per chunk and size the number of
+	// blocks per vein.
+	count, size int
+	// minY and maxY are the bounds within which veins generate. If triangle is
+	// true, veins are most common halfway between minY and maxY.
+	minY, maxY int
+	triangle   bool
+}
+
+// overworldOres holds all ores and rock veins generated in the Overworld.
+var overworldOres = []ore{
+	{stone: block.Granite{}, count: 2, size: 33, minY: 0, maxY: 60},
+	{stone: block.Diorite{}, count: 2, size: 33, minY: 0, maxY: 60},
+	{stone: block.Andesite{}, count: 2, size: 33, minY: 0, maxY: 60},
+	{deepslate: block.Tuff{}, count: 2, size: 33, minY: -64, maxY: 0},
+	{stone: block.Dirt{}, deepslate: block.Dirt{}, count: 7, size: 33, minY: 0, maxY: 160},
+	{stone: block.Gravel{}, deepslate: block.Gravel{}, count: 10, size: 33, minY: -64, maxY: 320},
+	{stone: block.CoalOre{Type: block.StoneOre()}, deepslate: block.CoalOre{Type: block.DeepslateOre()}, count: 20, size: 17, minY: 0, maxY: 192, triangle: true},
+	{stone: block.CoalOre{Type: block.StoneOre()}, count: 20, size: 17, minY: 136, maxY: 320},
+	{stone: block.IronOre{Type: block.StoneOre()}, deepslate: block.IronOre{Type: block.DeepslateOre()}, count: 10, size: 9, minY: -24, maxY: 56, triangle: true},
+	{stone: block.IronOre{Type: block.StoneOre()}, count: 40, size: 9, minY: 80, maxY: 384, triangle: true},
+	{stone: block.CopperOre{Type: block.StoneOre()}, deepslate: block.CopperOre{Type: block.DeepslateOre()}, count: 16, size: 10, minY: -16, maxY: 112, triangle: true},
+	{stone: block.GoldOre{Type: block.StoneOre()}, deepslate: block.GoldOre{Type: block.DeepslateOre()}, count: 4, size: 9, minY: -64, maxY: 32, triangle: true},
+	{stone: block.LapisOre{Type: block.StoneOre()}, deepslate: block.LapisOre{Type: block.DeepslateOre()}, count: 2, size: 7, minY: -32, maxY: 32, triangle: true},
+	{stone: block.DiamondOre{Type: block.StoneOre()}, deepslate: block.DiamondOre{Type: block.DeepslateOre()}, count: 4, size: 8, minY: -80, maxY: 16, triangle: true},
+	{stone: block.EmeraldOre{Type: block.StoneOre()}, deepslate: block.EmeraldOre{Type: block.DeepslateOre()}, count: 3, size: 3, minY: -16, maxY: 320},
+}
+
+// generateOres generates the ores passed in the chunk. Ore veins only replace
+// the stone and deepslate runtime IDs passed and are cut off at the borders
+// of the chunk.
+func generateOres(c *chunk.Chunk, r *rand.Rand, stone, deepslate uint32, ores []ore) {
+	minY, maxY := c.Range().Min(), c.Range().Max()
+	for _, o := range ores {
+		var stoneRID, deepslateRID uint32
+		if o.stone != nil {
+			stoneRID = world.BlockRuntimeID(o.stone)
+		}
+		if o.deepslate != nil {
+			deepslateRID = world.BlockRuntimeID(o.deepslate)
+		}
+		for i := 0; i < o.count; i++ {
+			var y int
+			if o.triangle {
+				half := (o.maxY - o.minY) / 2
+				y = o.minY + r.IntN(half+1) + r.IntN(half+1)
+			} else {
+				y = o.minY + r.IntN(o.maxY-o.minY+1)
+			}
+			if y < minY || y > maxY {
+				continue
+			}
+			vein(r, float64(r.IntN(16)), float64(y), float64(r.IntN(16)), o.size, func(x, y, z int) {
+				if x < 0 || x > 15 || z < 0 || z > 15 || y <= minY || y > maxY {
+					return
+				}
+				switch c.Block(uint8(x), int16(y), uint8(z), 0) {
+				case stone:
+					if o.stone != nil {
+						c.SetBlock(uint8(x), int16(y), uint8(z), 0, stoneRID)
+					}
+				case deepslate:
+					if o.deepslate != nil {
+						c.SetBlock(uint8(x), int16(y), uint8(z), 0, deepslateRID)
+					}
+				}
+			})
+		}
+	}
+}
+
+// vein calls f for every position in a vein of a specific size, shaped like
+// an elongated blob centred around x, y and z.
+func vein(r *rand.Rand, x, y, z float64, size int, f func(x, y, z int)) {
+	angle := r.Float64() * math.Pi
+	spread := float64(size) / 8
+	x0, x1 := x+math.Sin(angle)*spread, x-math.Sin(angle)*spread
+	z0, z1 := z+math.Cos(angle)*spread, z-math.Cos(angle)*spread
+	y0, y1 := y+float64(r.IntN(3)-1), y+float64(r.IntN(3)-1)
+
+	for i := 0; i < size; i++ {
+		t := float64(i) / float64(size)
+		cx, cy, cz := lerp(t, x0, x1), lerp(t, y0, y1), lerp(t, z0, z1)
+		radius := ((math.Sin(math.Pi*t)+1)*r.Float64()*float64(size)/16 + 1) / 2
+
+		for bx := int(math.Floor(cx - radius)); bx <= int(math.Floor(cx+radius)); bx++ {
+			for by := int(math.Floor(cy - radius)); by <= int(math.Floor(cy+radius)); by++ {
+				for bz := int(math.Floor(cz - radius)); bz <= int(math.Floor(cz+radius)); bz++ {
+					dx, dy, dz := (float64(bx)+0.5-cx)/radius, (float64(by)+0.5-cy)/radius, (float64(bz)+0.5-cz)/radius
+					if dx*dx+dy*dy+dz*dz < 1 {
+						f(bx, by, bz)
+					}
+				}
+			}
+		}
+	}
+}
+
+// setIfAir sets a block at a position in the chunk if that position is within
+// the chunk and is currently air or a replaceable plant.
+func setIfAir(c *chunk.Chunk, x, y, z int, b world.Block) {
+	if x < 0 || x > 15 || z < 0 || z > 15 || y < c.Range().Min() || y > c.Range().Max() {
+		return
+	}
+	current, _ := world.BlockByRuntimeID(c.Block(uint8(x), int16(y), uint8(z), 0))
+	if _, ok := current.(block.Air); !ok {
+		if r, ok := current.(block.Replaceable); !ok || !r.ReplaceableBy(b) {
+			return
+		}
+	}
+	c.SetBlock(uint8(x), int16(y), uint8(z), 0, world.BlockRuntimeID(b))
+}
+
+// setLog places a log at a position in the chunk if it is within the chunk.
+// Unlike setIfAir, setLog overwrites leaves.
+func setLog(c *chunk.Chunk, x, y, z int, wood block.WoodType) {
+	if x < 0 || x > 15 || z < 0 || z > 15 || y < c.Range().Min() || y > c.Range().Max() {
+		return
+	}
+	c.SetBlock(uint8(x), int16(y), uint8(z), 0, world.BlockRuntimeID(block.Log{Wood: wood}))
+}
+
+// setSoil replaces the block below the trunk of a tree with dirt, as grass
+// and snow cannot exist below a log.
+func setSoil(c *chunk.Chunk, x, y, z int) {
+	c.SetBlock(uint8(x), int16(y-1), uint8(z), 0, world.BlockRuntimeID(block.Dirt{}))
+}
+
+// leafLayer places a square layer of leaves with a specific radius around the
+// centre x and z. If corners is false, the corners of the layer are randomly
+// left out.
+func leafLayer(c *chunk.Chunk, r *rand.Rand, x, y, z, radius int, wood block.WoodType, corners bool) {
+	for dx := -radius; dx <= radius; dx++ {
+		for dz := -radius; dz <= radius; dz++ {
+			if !corners && radius > 0 && abs(dx) == radius && abs(dz) == radius && r.IntN(2) == 0 {
+				continue
+			}
+			setIfAir(c, x+dx, y, z+dz, block.Leaves{Wood: wood})
+		}
+	}
+}
+
+// oakTree returns a function that places a tree with the shape of an oak tree
+// using a specific type of wood.
+func oakTree(wood block.WoodType) func(c *chunk.Chunk, r *rand.Rand, x uint8, y int16, z uint8) {
+	return func(c *chunk.Chunk, r *rand.Rand, ux uint8, uy int16, uz uint8) {
+		x, y, z := int(ux), int(uy), int(uz)
+		height := 4 + r.IntN(3)
+		if wood == block.BirchWood() {
+			height++
+		}
+		if y+height+1 > c.Range().Max() {
+			return
+		}
+		setSoil(c, x, y, z)
+		top := y + height
+		for ly := top - 3; ly <= top; ly++ {
+			radius := 2
+			if ly >= top-1 {
+				radius = 1
+			}
+			leafLayer(c, r, x, ly, z, radius, wood, false)
+		}
+		for ly := y; ly < top; ly++ {
+			setLog(c, x, ly, z, wood)
+		}
+	}
+}
+
+// spruceTree places a cone shaped spruce tree.
+func spruceTree(c *chunk.Chunk, r *rand.Rand, ux uint8, uy int16, uz uint8) {
+	x, y, z := int(ux), int(uy), int(uz)
+	wood := block.SpruceWood()
+	height := 6 + r.IntN(4)
+	if y+height+1 > c.Range().Max() {
+		return
+	}
+	setSoil(c, x, y, z)
+	top := y + height
+	setIfAir(c, x, top, z, block.Leaves{Wood: wood})
+	radius := 0
+	for ly := top - 1; ly >= y+2; ly-- {
+		leafLayer(c, r, x, ly, z, radius, wood, radius < 2)
+		if radius++; radius > 2 || (radius == 2 && r.IntN(2) == 0) {
+			radius = 1
+		}
+	}
+	for ly := y; ly < top; ly++ {
+		setLog(c, x, ly, z, wood)
+	}
+}
+
+// jungleTree places a tall jungle tree with a wide canopy.
+func jungleTree(c *chunk.Chunk, r *rand.Rand, ux uint8, uy int16, uz uint8) {
+	x, y, z := int(ux), int(uy), int(uz)
+	wood := block.JungleWood()
+	height := 7 + r.IntN(6)
+	if y+height+1 > c.Range().Max() {
+		return
+	}
+	setSoil(c, x, y, z)
+	top := y + height
+	leafLayer(c, r, x, top-3, z, 3, wood, false)
+	leafLayer(c, r, x, top-2, z, 2, wood, true)
+	leafLayer(c, r, x, top-1, z, 2, wood, false)
+	leafLayer(c, r, x, top, z, 1, wood, false)
+	for ly := y; ly < top; ly++ {
+		setLog(c, x, ly, z, wood)
+	}
+}
+
+// acaciaTree places an acacia tree with a bent trunk and a flat canopy.
+func acaciaTree(c *chunk.Chunk, r *rand.Rand, ux uint8, uy int16, uz uint8) {
+	x, y, z := int(ux), int(uy), int(uz)
+	wood := block.AcaciaWood()
+	height := 5 + r.IntN(3)
+	if y+height+2 > c.Range().Max() {
+		return
+	}
+	setSoil(c, x, y, z)
+	dx, dz := r.IntN(3)-1, r.IntN(3)-1
+	bend := height - 2 - r.IntN(2)
+	tx, tz := x, z
+	for ly := 0; ly < height; ly++ {
+		if ly >= bend {
+			tx, tz = x+dx*(ly-bend+1)/2, z+dz*(ly-bend+1)/2
+		}
+		setLog(c, tx, y+ly, tz, wood)
+	}
+	top := y + height
+	leafLayer(c, r, tx, top, tz, 2, wood, false)
+	leafLayer(c, r, tx, top+1, tz, 1, wood, false)
+}
+
+// darkOakTree places a dark oak tree with a two by two trunk.
+func darkOakTree(c *chunk.Chunk, r *rand.Rand, ux uint8, uy int16, uz uint8) {
+	x, y, z := int(ux), int(uy), int(uz)
+	wood := block.DarkOakWood()
+	height := 6 + r.IntN(3)
+	if y+height+2 > c.Range().Max() {
+		return
+	}
+	top := y + height
+	for ly := top - 2; ly <= top+1; ly++ {
+		radius := 3
+		if ly == top+1 {
+			radius = 2
+		}
+		for dx := -radius; dx <= radius+1; dx++ {
+			for dz := -radius; dz <= radius+1; dz++ {
+				if (dx == -radius || dx == radius+1) && (dz == -radius || dz == radius+1) {
+					continue
+				}
+				setIfAir(c, x+dx, ly, z+dz, block.Leaves{Wood: wood})
+			}
+		}
+	}
+	for i := 0; i < 4; i++ {
+		lx, lz := x+i%2, z+i/2
+		if lx > 15 || lz > 15 {
+			continue
+		}
+		setSoil(c, lx, y, lz)
+		for ly := y; ly < top; ly++ {
+			setLog(c, lx, ly, lz, wood)
+		}
+	}
+}
+
+// mixedTree returns a function that randomly places one of the trees passed.
+func mixedTree(trees ...func(c *chunk.Chunk, r *rand.Rand, x uint8, y int16, z uint8)) func(c *chunk.Chunk, r *rand.Rand, x uint8, y int16, z uint8) {
+	return func(c *chunk.Chunk, r *rand.Rand, x uint8, y int16, z uint8) {
+		trees[r.IntN(len(trees))](c, r, x, y, z)
+	}
+}
+
+// abs returns the absolute value of an int.
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
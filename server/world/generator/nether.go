@@ -0,0 +1,344 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math/rand/v2"
+	"slices"
+)
+
+// lavaLevel is the height up to which (inclusive) the caverns of the Nether
+// are filled with lava.
+const lavaLevel = 31
+
+// Nether is a seeded, noise based world.Generator that generates terrain
+// resembling that of a vanilla Nether: Large caverns enclosed by a netherrack
+// floor and ceiling, with lava seas and the nether biomes found in the biome
+// package, such as soul sand valleys and crimson and warped forests.
+// Nether always generates the same terrain for the same seed and is safe for
+// concurrent use. Nether must be created using NewNether.
+type Nether struct {
+	seed int64
+
+	terrain, temperature, humidity, patches octaveNoise
+
+	air, netherrack, lava, bedrock uint32
+}
+
+// NewNether creates a Nether generator that generates terrain using the seed
+// passed. Like other generators, NewNether must only be called after the block
+// registry has been finalised.
+func NewNether(seed int64) *Nether {
+	r := rand.New(rand.NewPCG(uint64(seed)^0x6e6574686572, uint64(seed)))
+	return &Nether{
+		seed:        seed,
+		terrain:     newOctaveNoise(r, 3, 1.0/70),
+		temperature: newOctaveNoise(r, 2, 1.0/300),
+		humidity:    newOctaveNoise(r, 2, 1.0/300),
+		patches:     newOctaveNoise(r, 2, 1.0/16),
+
+		air:        world.BlockRuntimeID(block.Air{}),
+		netherrack: world.BlockRuntimeID(block.Netherrack{}),
+		lava:       world.BlockRuntimeID(block.Lava{Still: true, Depth: 8}),
+		bedrock:    world.BlockRuntimeID(block.Bedrock{}),
+	}
+}
+
+// netherOres holds all ores generated in the Nether.
+var netherOres = []ore{
+	{stone: block.NetherQuartzOre{}, count: 16, size: 14, minY: 10, maxY: 117},
+	{stone: block.NetherGoldOre{}, count: 10, size: 10, minY: 10, maxY: 117},
+	{stone: block.AncientDebris{}, count: 1, size: 3, minY: 8, maxY: 24, triangle: true},
+	{stone: block.AncientDebris{}, count: 1, size: 2, minY: 8, maxY: 119},
+	{stone: block.Gravel{}, count: 2, size: 33, minY: 5, maxY: 41},
+}
+
+// GenerateChunk generates the caverns, lava seas, biomes and decoration of the
+// chunk at the position passed.
+func (g *Nether) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	r := chunkRand(g.seed, pos[0], pos[1], 0)
+
+	var biomes [16][16]world.Biome
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			biomes[x][z] = g.biome(float64(baseX+x), float64(baseZ+z))
+		}
+	}
+	g.caverns(c, baseX, baseZ, r)
+	g.surface(c, baseX, baseZ, &biomes, r)
+	generateOres(c, r, g.netherrack, g.netherrack, netherOres)
+	g.decorate(c, &biomes, chunkRand(g.seed, pos[0], pos[1], 1))
+
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			fillBiome(c, x, z, biomes[x][z])
+		}
+	}
+}
+
+// biome selects the nether biome at a specific x and z.
+func (g *Nether) biome(x, z float64) world.Biome {
+	temp, hum := g.temperature.at2D(x, z)*1.6, g.humidity.at2D(x, z)*1.6
+	switch {
+	case temp > 0.35:
+		return biome.CrimsonForest{}
+	case hum > 0.35:
+		return biome.WarpedForest{}
+	case temp < -0.35 && hum > -0.1:
+		return biome.SoulSandValley{}
+	case temp < -0.35:
+		return biome.BasaltDeltas{}
+	}
+	return biome.NetherWastes{}
+}
+
+// caverns fills the chunk with netherrack, carving out large caverns using
+// three-dimensional noise. Caverns below lavaLevel are filled with lava. The
+// bottom and top of the chunk are closed off with bedrock.
+func (g *Nether) caverns(c *chunk.Chunk, baseX, baseZ int, r *rand.Rand) {
+	minY, maxY := c.Range().Min(), c.Range().Max()
+	density := sampleGrid(baseX, baseZ, minY, maxY, 1, func(x, y, z float64, v []float64) {
+		v[0] = g.terrain.at3D(x, y*1.8, z)
+	})
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			for y := minY; y <= maxY; y++ {
+				if y-minY < 5 && r.IntN(5) >= y-minY || maxY-y < 5 && r.IntN(5) >= maxY-y {
+					c.SetBlock(uint8(x), int16(y), uint8(z), 0, g.bedrock)
+					continue
+				}
+				// The floor and ceiling become increasingly solid towards the
+				// bottom and the top of the Nether.
+				d := density.at(x, y, z, 0) - 0.15
+				if y < minY+16 {
+					d += float64(minY+16-y) / 16 * 1.2
+				} else if y > maxY-24 {
+					d += float64(y-(maxY-24)) / 24 * 1.5
+				}
+				switch {
+				case d > 0:
+					c.SetBlock(uint8(x), int16(y), uint8(z), 0, g.netherrack)
+				case y <= lavaLevel:
+					c.SetBlock(uint8(x), int16(y), uint8(z), 0, g.lava)
+				}
+			}
+		}
+	}
+}
+
+// surface replaces the netherrack forming the floors of the caverns with the
+// floor blocks of the biome of each column.
+func (g *Nether) surface(c *chunk.Chunk, baseX, baseZ int, biomes *[16][16]world.Biome, r *rand.Rand) {
+	minY, maxY := int16(c.Range().Min()), int16(c.Range().Max())
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			patch := g.patches.at2D(float64(baseX+int(x)), float64(baseZ+int(z)))
+			depth := -1
+			for y := maxY - 1; y > minY; y-- {
+				b := c.Block(x, y, z, 0)
+				if b != g.netherrack {
+					depth = -1
+					continue
+				}
+				if depth++; depth == 0 && c.Block(x, y+1, z, 0) != g.air {
+					// Only floors exposed to air receive a surface.
+					depth = 4
+				}
+				if depth > 3 {
+					continue
+				}
+				if s := netherFloor(biomes[x][z], int(y), depth, patch, r); s != nil {
+					c.SetBlock(x, y, z, 0, world.BlockRuntimeID(s))
+				}
+			}
+		}
+	}
+}
+
+// netherFloor returns the block placed at a specific depth of the floor of a
+// cavern in a nether biome, or nil if the netherrack should be kept.
+func netherFloor(b world.Biome, y, depth int, patch float64, r *rand.Rand) world.Block {
+	switch b.(type) {
+	case biome.CrimsonForest:
+		if depth == 0 {
+			return block.Nylium{}
+		}
+	case biome.WarpedForest:
+		if depth == 0 {
+			return block.Nylium{Warped: true}
+		}
+	case biome.SoulSandValley:
+		if depth < 3 {
+			if patch > 0 {
+				return block.SoulSoil{}
+			}
+			return block.SoulSand{}
+		}
+	case biome.BasaltDeltas:
+		if depth < 2 {
+			if patch > 0.2 || r.IntN(6) == 0 {
+				return block.Blackstone{Type: block.NormalBlackstone()}
+			}
+			return block.Basalt{}
+		}
+	default:
+		if y >= lavaLevel-3 && y <= lavaLevel+3 && depth < 2 {
+			if patch > 0.3 {
+				return block.SoulSand{}
+			} else if patch < -0.3 {
+				return block.Gravel{}
+			}
+		}
+	}
+	return nil
+}
+
+// decorate places glowstone clusters on the ceilings of the chunk and the
+// features of the biome of each column, such as huge fungi and basalt
+// pillars, on its floors.
+func (g *Nether) decorate(c *chunk.Chunk, biomes *[16][16]world.Biome, r *rand.Rand) {
+	for i := 0; i < 10; i++ {
+		x, y, z := 2+r.IntN(12), 10+r.IntN(110), 2+r.IntN(12)
+		for y < c.Range().Max() && c.Block(uint8(x), int16(y), uint8(z), 0) == g.air {
+			y++
+		}
+		if c.Block(uint8(x), int16(y), uint8(z), 0) == g.netherrack && c.Block(uint8(x), int16(y-1), uint8(z), 0) == g.air {
+			g.glowstone(c, r, x, y-1, z)
+		}
+	}
+	var distinct []world.Biome
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			if b := biomes[x][z]; !slices.Contains(distinct, b) {
+				distinct = append(distinct, b)
+			}
+		}
+	}
+	for _, b := range distinct {
+		g.decorateBiome(c, biomes, b, r)
+	}
+}
+
+// decorateBiome places the features of a biome on the floors of the columns
+// of the chunk with that biome. Like in the Overworld, every feature is
+// attempted as often as it would be in a chunk fully covered by the biome, but
+// only placed if its position lies in the biome.
+func (g *Nether) decorateBiome(c *chunk.Chunk, biomes *[16][16]world.Biome, b world.Biome, r *rand.Rand) {
+	in := func(x, z int) bool {
+		return biomes[x][z] == b
+	}
+	switch b.(type) {
+	case biome.CrimsonForest, biome.WarpedForest:
+		_, warped := b.(biome.WarpedForest)
+		nylium := world.BlockRuntimeID(block.Nylium{Warped: warped})
+		for i := 0; i < 8; i++ {
+			x, z := 2+r.IntN(12), 2+r.IntN(12)
+			if !in(x, z) {
+				continue
+			}
+			if y, ok := g.floor(c, x, 20+r.IntN(100), z); ok && c.Block(uint8(x), int16(y), uint8(z), 0) == nylium {
+				hugeFungus(c, r, x, y+1, z, warped)
+			}
+		}
+		if warped {
+			for i := 0; i < 30; i++ {
+				x, z := r.IntN(16), r.IntN(16)
+				if !in(x, z) {
+					continue
+				}
+				if y, ok := g.floor(c, x, 20+r.IntN(100), z); ok && c.Block(uint8(x), int16(y), uint8(z), 0) == nylium {
+					setIfAir(c, x, y+1, z, block.NetherSprouts{})
+				}
+			}
+		}
+	case biome.SoulSandValley, biome.BasaltDeltas:
+		for i := 0; i < 2; i++ {
+			x, z := r.IntN(16), r.IntN(16)
+			if !in(x, z) {
+				continue
+			}
+			if y, ok := g.floor(c, x, 20+r.IntN(100), z); ok {
+				for h := 1; h <= 24 && c.Block(uint8(x), int16(y+h), uint8(z), 0) == g.air; h++ {
+					c.SetBlock(uint8(x), int16(y+h), uint8(z), 0, world.BlockRuntimeID(block.Basalt{}))
+				}
+			}
+		}
+	}
+}
+
+// floor looks for the first floor below the y value passed in the column at
+// x and z, returning its y value if it is a solid, non-lava block with air
+// above it.
+func (g *Nether) floor(c *chunk.Chunk, x, y, z int) (int, bool) {
+	for ; y > lavaLevel; y-- {
+		b := c.Block(uint8(x), int16(y), uint8(z), 0)
+		if b == g.air {
+			continue
+		}
+		return y, b != g.lava && b != g.bedrock && c.Block(uint8(x), int16(y+1), uint8(z), 0) == g.air
+	}
+	return 0, false
+}
+
+// glowstone places a cluster of glowstone hanging from the ceiling, starting
+// at the position passed.
+func (g *Nether) glowstone(c *chunk.Chunk, r *rand.Rand, x, y, z int) {
+	glowstone := world.BlockRuntimeID(block.Glowstone{})
+	c.SetBlock(uint8(x), int16(y), uint8(z), 0, glowstone)
+	for i := 0; i < 60; i++ {
+		bx, by, bz := x+r.IntN(6)-r.IntN(6), y-r.IntN(8), z+r.IntN(6)-r.IntN(6)
+		if bx < 0 || bx > 15 || bz < 0 || bz > 15 || by <= c.Range().Min() || c.Block(uint8(bx), int16(by), uint8(bz), 0) != g.air {
+			continue
+		}
+		// Glowstone only grows attached to exactly one other glowstone block.
+		var n int
+		for _, d := range [6][3]int{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}} {
+			nx, ny, nz := bx+d[0], by+d[1], bz+d[2]
+			if nx >= 0 && nx < 16 && nz >= 0 && nz < 16 && c.Block(uint8(nx), int16(ny), uint8(nz), 0) == glowstone {
+				n++
+			}
+		}
+		if n == 1 {
+			c.SetBlock(uint8(bx), int16(by), uint8(bz), 0, glowstone)
+		}
+	}
+}
+
+// hugeFungus places a huge crimson or warped fungus with its stem starting at
+// the position passed.
+func hugeFungus(c *chunk.Chunk, r *rand.Rand, x, y, z int, warped bool) {
+	wood := block.CrimsonWood()
+	if warped {
+		wood = block.WarpedWood()
+	}
+	height := 4 + r.IntN(6)
+	if y+height+1 > c.Range().Max() {
+		return
+	}
+	top := y + height
+	for ly := top - 3; ly <= top; ly++ {
+		radius := 2
+		if ly == top {
+			radius = 1
+		}
+		for dx := -radius; dx <= radius; dx++ {
+			for dz := -radius; dz <= radius; dz++ {
+				if ly < top-1 && abs(dx) < radius && abs(dz) < radius {
+					// The lower part of the cap is hollow.
+					continue
+				}
+				var cap world.Block = block.NetherWartBlock{Warped: warped}
+				if r.IntN(12) == 0 {
+					cap = block.Shroomlight{}
+				}
+				setIfAir(c, x+dx, ly, z+dz, cap)
+			}
+		}
+	}
+	for ly := y; ly < top; ly++ {
+		setLog(c, x, ly, z, wood)
+	}
+}
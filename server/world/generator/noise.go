@@ -0,0 +1,213 @@
+package generator
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// perlin is a seeded implementation of improved Perlin noise in two and three
+// dimensions. The values returned are roughly in the range [-1, 1]. A perlin
+// is immutable after creation and is safe for use by multiple goroutines.
+type perlin struct {
+	perm [512]uint8
+	// ox, oy and oz offset the coordinates passed so that two perlin noises
+	// created with the same permutation still produce different values.
+	ox, oy, oz float64
+}
+
+// newPerlin creates a new perlin noise using the rand.Rand passed to shuffle
+// its permutation table.
+func newPerlin(r *rand.Rand) *perlin {
+	p := &perlin{ox: r.Float64() * 256, oy: r.Float64() * 256, oz: r.Float64() * 256}
+	for i := 0; i < 256; i++ {
+		p.perm[i] = uint8(i)
+	}
+	for i := 255; i > 0; i-- {
+		j := r.IntN(i + 1)
+		p.perm[i], p.perm[j] = p.perm[j], p.perm[i]
+	}
+	copy(p.perm[256:], p.perm[:256])
+	return p
+}
+
+// noise2D returns the noise value at a specific x and z.
+func (p *perlin) noise2D(x, z float64) float64 {
+	return p.noise3D(x, 0, z)
+}
+
+// noise3D returns the noise value at a specific x, y and z.
+func (p *perlin) noise3D(x, y, z float64) float64 {
+	x, y, z = x+p.ox, y+p.oy, z+p.oz
+	fx, fy, fz := math.Floor(x), math.Floor(y), math.Floor(z)
+	xi, yi, zi := int(fx)&255, int(fy)&255, int(fz)&255
+	x, y, z = x-fx, y-fy, z-fz
+	u, v, w := fade(x), fade(y), fade(z)
+
+	a := int(p.perm[xi]) + yi
+	aa, ab := int(p.perm[a])+zi, int(p.perm[a+1])+zi
+	b := int(p.perm[xi+1]) + yi
+	ba, bb := int(p.perm[b])+zi, int(p.perm[b+1])+zi
+
+	return lerp(w,
+		lerp(v,
+			lerp(u, grad(p.perm[aa], x, y, z), grad(p.perm[ba], x-1, y, z)),
+			lerp(u, grad(p.perm[ab], x, y-1, z), grad(p.perm[bb], x-1, y-1, z)),
+		),
+		lerp(v,
+			lerp(u, grad(p.perm[aa+1], x, y, z-1), grad(p.perm[ba+1], x-1, y, z-1)),
+			lerp(u, grad(p.perm[ab+1], x, y-1, z-1), grad(p.perm[bb+1], x-1, y-1, z-1)),
+		),
+	)
+}
+
+// fade is the quintic smoothing function used by improved Perlin noise.
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+// lerp linearly interpolates between a and b using t.
+func lerp(t, a, b float64) float64 {
+	return a + t*(b-a)
+}
+
+// grad returns the dot product of a pseudo-random gradient vector selected by
+// hash and the distance vector x, y, z.
+func grad(hash uint8, x, y, z float64) float64 {
+	h := hash & 15
+	u, v := y, z
+	if h < 8 {
+		u = x
+	}
+	if h < 4 {
+		v = y
+	} else if h == 12 || h == 14 {
+		v = x
+	}
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+	return u + v
+}
+
+// octaveNoise combines several octaves of perlin noise, each with double the
+// frequency and half the amplitude of the previous one. The values returned
+// are roughly in the range [-1, 1].
+type octaveNoise struct {
+	octaves []*perlin
+	// frequency is the frequency of the first octave.
+	frequency float64
+	// norm normalises the sum of all octaves to the range [-1, 1].
+	norm float64
+}
+
+// newOctaveNoise creates an octaveNoise with n octaves, the first of which has
+// the frequency passed.
+func newOctaveNoise(r *rand.Rand, n int, frequency float64) octaveNoise {
+	o := octaveNoise{octaves: make([]*perlin, n), frequency: frequency}
+	amplitude := 1.0
+	for i := range o.octaves {
+		o.octaves[i] = newPerlin(r)
+		o.norm += amplitude
+		amplitude /= 2
+	}
+	return o
+}
+
+// at2D returns the noise value at a specific x and z.
+func (o octaveNoise) at2D(x, z float64) float64 {
+	var sum float64
+	frequency, amplitude := o.frequency, 1.0
+	for _, p := range o.octaves {
+		sum += p.noise2D(x*frequency, z*frequency) * amplitude
+		frequency, amplitude = frequency*2, amplitude/2
+	}
+	return sum / o.norm
+}
+
+// at3D returns the noise value at a specific x, y and z.
+func (o octaveNoise) at3D(x, y, z float64) float64 {
+	var sum float64
+	frequency, amplitude := o.frequency, 1.0
+	for _, p := range o.octaves {
+		sum += p.noise3D(x*frequency, y*frequency, z*frequency) * amplitude
+		frequency, amplitude = frequency*2, amplitude/2
+	}
+	return sum / o.norm
+}
+
+// gridCellWidth and gridCellHeight are the horizontal and vertical sizes of
+// the cells of a grid.
+const gridCellWidth, gridCellHeight = 4, 8
+
+// grid holds noise values sampled on a coarse grid spanning a chunk. Values
+// in between grid points are found by trilinear interpolation, which is
+// significantly cheaper than sampling noise for every block.
+type grid struct {
+	minY, n, channels int
+	v                 []float64
+}
+
+// sampleGrid samples a grid spanning the chunk with the base x and z passed
+// from minY to maxY. For every grid point, f is called with the world
+// coordinates of the point and a slice to which the values of each of the
+// channels should be written.
+func sampleGrid(baseX, baseZ, minY, maxY, channels int, f func(x, y, z float64, v []float64)) grid {
+	const w = 16/gridCellWidth + 1
+	n := (maxY-minY)/gridCellHeight + 2
+	g := grid{minY: minY, n: n, channels: channels, v: make([]float64, w*w*n*channels)}
+	for gx := 0; gx < w; gx++ {
+		for gz := 0; gz < w; gz++ {
+			for gy := 0; gy < n; gy++ {
+				i := ((gx*w+gz)*n + gy) * channels
+				f(float64(baseX+gx*gridCellWidth), float64(minY+gy*gridCellHeight), float64(baseZ+gz*gridCellWidth), g.v[i:i+channels])
+			}
+		}
+	}
+	return g
+}
+
+// at returns the interpolated value of a channel at a chunk-relative x and z
+// and an absolute y.
+func (g grid) at(x, y, z, channel int) float64 {
+	const w = 16/gridCellWidth + 1
+	y -= g.minY
+	gx, gy, gz := x/gridCellWidth, y/gridCellHeight, z/gridCellWidth
+	tx := float64(x%gridCellWidth) / gridCellWidth
+	ty := float64(y%gridCellHeight) / gridCellHeight
+	tz := float64(z%gridCellWidth) / gridCellWidth
+	v := func(dx, dy, dz int) float64 {
+		return g.v[(((gx+dx)*w+gz+dz)*g.n+gy+dy)*g.channels+channel]
+	}
+	return lerp(ty,
+		lerp(tz, lerp(tx, v(0, 0, 0), v(1, 0, 0)), lerp(tx, v(0, 0, 1), v(1, 0, 1))),
+		lerp(tz, lerp(tx, v(0, 1, 0), v(1, 1, 0)), lerp(tx, v(0, 1, 1), v(1, 1, 1))),
+	)
+}
+
+// chunkRand returns a rand.Rand that is seeded deterministically using the
+// seed of a generator, the position of a chunk and a salt, so that features
+// generated in a chunk are always the same for the same seed.
+func chunkRand(seed int64, x, z int32, salt uint64) *rand.Rand {
+	h := uint64(seed)
+	h = h*0x9e3779b97f4a7c15 + uint64(uint32(x))
+	h = h*0xbf58476d1ce4e5b9 + uint64(uint32(z))
+	return rand.New(rand.NewPCG(h, h^salt))
+}
+
+// spline linearly interpolates a value v between a number of points on a
+// curve. The points must be ordered by their first value.
+func spline(v float64, points [][2]float64) float64 {
+	if v <= points[0][0] {
+		return points[0][1]
+	}
+	for i := 1; i < len(points); i++ {
+		if v <= points[i][0] {
+			a, b := points[i-1], points[i]
+			return lerp((v-a[0])/(b[0]-a[0]), a[1], b[1])
+		}
+	}
+	return points[len(points)-1][1]
+}
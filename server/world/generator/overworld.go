@@ -0,0 +1,386 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// seaLevel is the height up to which (exclusive) the oceans and rivers of the
+// Overworld are filled with water.
+const seaLevel = 63
+
+// Overworld is a seeded, noise based world.Generator that generates terrain
+// resembling that of a vanilla Overworld, including oceans, rivers,
+// mountains, caves, ores, trees and vegetation. Biomes are selected from the
+// biomes in the biome package using temperature and humidity noise.
+// Overworld always generates the same terrain for the same seed. Overworld is
+// safe for concurrent use, so that GenerateChunk may be called from multiple
+// goroutines simultaneously. Overworld must be created using NewOverworld.
+type Overworld struct {
+	seed int64
+
+	continentalness, erosion, peaks, detail, river octaveNoise
+	temperature, humidity, weirdness               octaveNoise
+	cheese, spaghettiA, spaghettiB                 octaveNoise
+
+	air, stone, deepslate, water, lava, bedrock uint32
+}
+
+// NewOverworld creates an Overworld generator that generates terrain using
+// the seed passed. Like other generators, NewOverworld must only be called
+// after the block registry has been finalised, which is the case for the
+// function passed to server.Config.Generator.
+func NewOverworld(seed int64) *Overworld {
+	r := rand.New(rand.NewPCG(uint64(seed), uint64(seed)>>32|uint64(seed)<<32))
+	return &Overworld{
+		seed:            seed,
+		continentalness: newOctaveNoise(r, 4, 1.0/900),
+		erosion:         newOctaveNoise(r, 3, 1.0/700),
+		peaks:           newOctaveNoise(r, 4, 1.0/450),
+		detail:          newOctaveNoise(r, 4, 1.0/120),
+		river:           newOctaveNoise(r, 3, 1.0/700),
+		temperature:     newOctaveNoise(r, 3, 1.0/1100),
+		humidity:        newOctaveNoise(r, 3, 1.0/900),
+		weirdness:       newOctaveNoise(r, 2, 1.0/500),
+		cheese:          newOctaveNoise(r, 2, 1.0/90),
+		spaghettiA:      newOctaveNoise(r, 2, 1.0/70),
+		spaghettiB:      newOctaveNoise(r, 2, 1.0/70),
+
+		air:       world.BlockRuntimeID(block.Air{}),
+		stone:     world.BlockRuntimeID(block.Stone{}),
+		deepslate: world.BlockRuntimeID(block.Deepslate{Type: block.NormalDeepslate()}),
+		water:     world.BlockRuntimeID(block.Water{Still: true, Depth: 8}),
+		lava:      world.BlockRuntimeID(block.Lava{Still: true, Depth: 8}),
+		bedrock:   world.BlockRuntimeID(block.Bedrock{}),
+	}
+}
+
+// column holds the terrain height and biome of a single x, z column in a
+// chunk, as computed from the climate noise of the Overworld.
+type column struct {
+	height int
+	biome  world.Biome
+}
+
+// GenerateChunk generates the terrain, caves, ores and decoration of the
+// chunk at the position passed.
+func (g *Overworld) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	r := chunkRand(g.seed, pos[0], pos[1], 0)
+
+	var cols [16][16]column
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			cols[x][z] = g.column(baseX+x, baseZ+z)
+		}
+	}
+	g.terrain(c, &cols, r)
+	g.carveCaves(c, baseX, baseZ, &cols)
+	g.surface(c, &cols, r)
+	generateOres(c, r, g.stone, g.deepslate, overworldOres)
+	g.decorate(c, &cols, chunkRand(g.seed, pos[0], pos[1], 1))
+
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			fillBiome(c, x, z, cols[x][z].biome)
+		}
+	}
+}
+
+// column computes the terrain height and biome at a specific x and z using the
+// climate noise of the Overworld.
+func (g *Overworld) column(x, z int) column {
+	fx, fz := float64(x), float64(z)
+	cont := clamp(g.continentalness.at2D(fx, fz)*1.6, -1, 1)
+	ero := clamp(g.erosion.at2D(fx, fz)*1.6, -1, 1)
+
+	height := spline(cont, [][2]float64{{-1, 30}, {-0.5, 40}, {-0.22, 52}, {-0.12, 61}, {-0.05, 65}, {0.2, 70}, {0.5, 78}, {1, 92}})
+
+	// Mountains form further inland, where the erosion is low. The peaks noise
+	// is folded to form ridges.
+	ridge := 1 - math.Abs(g.peaks.at2D(fx, fz))*2.5
+	if ridge > 0 {
+		inland, rough := clamp((cont+0.1)/0.6, 0, 1), clamp((0.2-ero)/0.8, 0, 1)
+		height += ridge * ridge * inland * rough * 150
+	}
+	height += g.detail.at2D(fx, fz) * (5 + 12*clamp(-ero, 0, 1))
+
+	var river bool
+	if rv := math.Abs(g.river.at2D(fx, fz)); cont > -0.15 && rv < 0.05 && height < 110 {
+		t := rv / 0.05
+		t = t * t * (3 - 2*t)
+		height = lerp(t, seaLevel-5, height)
+		river = height < seaLevel
+	}
+	temp, hum := climateIndex(g.temperature.at2D(fx, fz)), climateIndex(g.humidity.at2D(fx, fz)*1.2)
+	weird := g.weirdness.at2D(fx, fz) * 1.6
+
+	h := int(height)
+	return column{height: h, biome: overworldBiome(h, cont, ero, weird, temp, hum, river)}
+}
+
+// terrain fills the chunk with stone up to the height of each column, with
+// deepslate in the lower part of the world, a bedrock floor and water up to
+// sea level.
+func (g *Overworld) terrain(c *chunk.Chunk, cols *[16][16]column, r *rand.Rand) {
+	minY := int16(c.Range().Min())
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			height := int16(cols[x][z].height)
+			for y := minY; y <= max(height, seaLevel-1); y++ {
+				switch {
+				case y > height:
+					c.SetBlock(x, y, z, 0, g.water)
+				case y < minY+5 && (y == minY || r.IntN(5) >= int(y-minY)):
+					c.SetBlock(x, y, z, 0, g.bedrock)
+				case y < 0 || (y < 8 && r.IntN(8) >= int(y)):
+					c.SetBlock(x, y, z, 0, g.deepslate)
+				default:
+					c.SetBlock(x, y, z, 0, g.stone)
+				}
+			}
+		}
+	}
+}
+
+// carveCaves carves cheese caves and spaghetti tunnels out of the terrain of
+// the chunk. The cave noise is sampled on a coarse grid and interpolated in
+// between for performance. Caves close to the bottom of the world are filled
+// with lava.
+func (g *Overworld) carveCaves(c *chunk.Chunk, baseX, baseZ int, cols *[16][16]column) {
+	minY := c.Range().Min()
+	caves := sampleGrid(baseX, baseZ, minY, c.Range().Max(), 3, func(x, y, z float64, v []float64) {
+		v[0] = g.cheese.at3D(x, y*1.6, z)
+		v[1] = g.spaghettiA.at3D(x, y*1.4, z)
+		v[2] = g.spaghettiB.at3D(x, y*1.4, z)
+	})
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			height := cols[x][z].height
+			// Tunnels may break through the surface on land, but never below
+			// water, so that no air pockets are created below oceans.
+			top := height - 8
+			if height >= seaLevel+2 {
+				top = height
+			}
+			for y := minY + 5; y <= top; y++ {
+				cheese := y < height-10 && caves.at(x, y, z, 0) > 0.42
+				if !cheese {
+					a, b := caves.at(x, y, z, 1), caves.at(x, y, z, 2)
+					if a*a+b*b >= 0.0022 {
+						continue
+					}
+				}
+				if y < -54 {
+					c.SetBlock(uint8(x), int16(y), uint8(z), 0, g.lava)
+					continue
+				}
+				c.SetBlock(uint8(x), int16(y), uint8(z), 0, g.air)
+			}
+		}
+	}
+}
+
+// surface replaces the top layers of stone of each column with the surface
+// blocks of the biome of that column, such as grass and dirt or sand and
+// sandstone.
+func (g *Overworld) surface(c *chunk.Chunk, cols *[16][16]column, r *rand.Rand) {
+	minY := int16(c.Range().Min())
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			col := cols[x][z]
+			depth := -1
+			maxDepth := 3 + r.IntN(2)
+			for y := int16(col.height); y > minY && depth < maxDepth; y-- {
+				if c.Block(x, y, z, 0) != g.stone {
+					if depth >= 0 {
+						// We hit a cave: Stop placing surface blocks.
+						break
+					}
+					continue
+				}
+				depth++
+				top, filler := surfaceBlocks(col.biome, int(y), col.height < seaLevel-1)
+				b := filler
+				if depth == 0 {
+					b = top
+				}
+				if depth == maxDepth && filler == (block.Sand{}) {
+					// Sand is held up by a layer of sandstone.
+					b = block.Sandstone{}
+				}
+				c.SetBlock(x, y, z, 0, world.BlockRuntimeID(b))
+			}
+		}
+	}
+}
+
+// decorate places trees and vegetation on the surface of the chunk depending
+// on the biome of each column. Features are kept within the chunk, so that
+// generating a chunk never modifies neighbouring chunks.
+func (g *Overworld) decorate(c *chunk.Chunk, cols *[16][16]column, r *rand.Rand) {
+	var biomes []world.Biome
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			if b := cols[x][z].biome; !slices.Contains(biomes, b) {
+				biomes = append(biomes, b)
+			}
+		}
+	}
+	for _, b := range biomes {
+		g.decorateBiome(c, cols, b, r)
+	}
+	g.sugarCane(c, r)
+}
+
+// decorateBiome places the trees and vegetation of a biome in the columns of
+// the chunk with that biome. Every feature is attempted as often as it would
+// be in a chunk fully covered by the biome, but only placed if its position
+// lies in the biome, so that the density of each feature matches the area
+// that the biome covers in the chunk.
+func (g *Overworld) decorateBiome(c *chunk.Chunk, cols *[16][16]column, b world.Biome, r *rand.Rand) {
+	f := decorationOf(b)
+	in := func(x, z uint8) bool {
+		return cols[x][z].biome == b
+	}
+
+	for i := 0; i < f.trees(r); i++ {
+		x, z := uint8(3+r.IntN(10)), uint8(3+r.IntN(10))
+		if !in(x, z) || cols[x][z].isWater() {
+			continue
+		}
+		if y, ok := g.plantable(c, x, z); ok {
+			switch c.Block(x, y, z, 0) {
+			case world.BlockRuntimeID(block.Grass{}), world.BlockRuntimeID(block.Dirt{}), world.BlockRuntimeID(block.Snow{}):
+				f.tree(c, r, x, y+1, z)
+			}
+		}
+	}
+	for i := 0; i < f.grass; i++ {
+		x, z := uint8(r.IntN(16)), uint8(r.IntN(16))
+		if !in(x, z) {
+			continue
+		}
+		if y, ok := g.plantable(c, x, z); ok && c.Block(x, y, z, 0) == world.BlockRuntimeID(block.Grass{}) {
+			if r.IntN(8) == 0 {
+				placeDouble(c, x, y+1, z, block.DoubleTallGrass{Type: block.NormalDoubleTallGrass()}, block.DoubleTallGrass{Type: block.NormalDoubleTallGrass(), UpperPart: true})
+				continue
+			}
+			c.SetBlock(x, y+1, z, 0, world.BlockRuntimeID(block.ShortGrass{}))
+		}
+	}
+	for i := 0; i < f.flowers; i++ {
+		x, z := uint8(r.IntN(16)), uint8(r.IntN(16))
+		if !in(x, z) {
+			continue
+		}
+		if y, ok := g.plantable(c, x, z); ok && c.Block(x, y, z, 0) == world.BlockRuntimeID(block.Grass{}) {
+			c.SetBlock(x, y+1, z, 0, world.BlockRuntimeID(block.Flower{Type: f.flower(r)}))
+		}
+	}
+	for i := 0; i < f.cacti; i++ {
+		x, z := uint8(1+2*r.IntN(8)), uint8(1+2*r.IntN(8))
+		if !in(x, z) {
+			continue
+		}
+		if y, ok := g.plantable(c, x, z); ok && c.Block(x, y, z, 0) == world.BlockRuntimeID(block.Sand{}) {
+			for h := int16(1); h <= int16(1+r.IntN(3)); h++ {
+				c.SetBlock(x, y+h, z, 0, world.BlockRuntimeID(block.Cactus{}))
+			}
+		}
+	}
+	for i := 0; i < f.deadBushes; i++ {
+		x, z := uint8(r.IntN(16)), uint8(r.IntN(16))
+		if !in(x, z) {
+			continue
+		}
+		if y, ok := g.plantable(c, x, z); ok {
+			if b := c.Block(x, y, z, 0); b == world.BlockRuntimeID(block.Sand{}) || b == world.BlockRuntimeID(block.Sand{Red: true}) || b == world.BlockRuntimeID(block.Terracotta{}) {
+				c.SetBlock(x, y+1, z, 0, world.BlockRuntimeID(block.DeadBush{}))
+			}
+		}
+	}
+}
+
+// sugarCane places sugar cane on grass and sand blocks directly next to water
+// at sea level.
+func (g *Overworld) sugarCane(c *chunk.Chunk, r *rand.Rand) {
+	y := int16(seaLevel - 1)
+	for i := 0; i < 10; i++ {
+		x, z := uint8(1+r.IntN(14)), uint8(1+r.IntN(14))
+		if b := c.Block(x, y, z, 0); b != world.BlockRuntimeID(block.Grass{}) && b != world.BlockRuntimeID(block.Sand{}) {
+			continue
+		}
+		if c.Block(x, y+1, z, 0) != g.air {
+			continue
+		}
+		if c.Block(x+1, y, z, 0) != g.water && c.Block(x-1, y, z, 0) != g.water && c.Block(x, y, z+1, 0) != g.water && c.Block(x, y, z-1, 0) != g.water {
+			continue
+		}
+		for h := int16(1); h <= int16(1+r.IntN(3)); h++ {
+			c.SetBlock(x, y+h, z, 0, world.BlockRuntimeID(block.SugarCane{}))
+		}
+	}
+}
+
+// plantable returns the y value of the highest block at an x and z in the
+// chunk if it is a dry block on which plants may grow.
+func (g *Overworld) plantable(c *chunk.Chunk, x, z uint8) (int16, bool) {
+	y := c.HighestBlock(x, z)
+	if y < seaLevel-1 || y >= int16(c.Range().Max()) {
+		return y, false
+	}
+	b := c.Block(x, y, z, 0)
+	return y, b != g.water && b != g.lava && b != g.air
+}
+
+// isWater checks if the column is covered by water.
+func (col column) isWater() bool {
+	return col.height < seaLevel-1
+}
+
+// fillBiome sets the biome of an entire x, z column in the chunk.
+func fillBiome(c *chunk.Chunk, x, z uint8, b world.Biome) {
+	id := uint32(b.EncodeBiome())
+	for y := int16(c.Range().Min()); y <= int16(c.Range().Max()); y += 16 {
+		for i := int16(0); i < 16; i++ {
+			c.SetBiome(x, y+i, z, id)
+		}
+	}
+}
+
+// placeDouble places a two block tall plant with its lower and upper part at
+// a specific position in the chunk.
+func placeDouble(c *chunk.Chunk, x uint8, y int16, z uint8, lower, upper world.Block) {
+	if int(y)+1 > c.Range().Max() {
+		return
+	}
+	c.SetBlock(x, y, z, 0, world.BlockRuntimeID(lower))
+	c.SetBlock(x, y+1, z, 0, world.BlockRuntimeID(upper))
+}
+
+// clamp clamps v between a minimum and maximum value.
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
+
+// climateIndex converts a temperature or humidity noise value to an index in
+// the range [0, 4], where 0 is the coldest or driest and 4 the hottest or most
+// humid.
+func climateIndex(v float64) int {
+	switch v *= 1.6; {
+	case v < -0.45:
+		return 0
+	case v < -0.15:
+		return 1
+	case v < 0.2:
+		return 2
+	case v < 0.55:
+		return 3
+	}
+	return 4
+}
@@ -0,0 +1,252 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"math/rand/v2"
+)
+
+// landBiomes holds the biomes selected for inland areas of the Overworld,
+// indexed by temperature and humidity respectively.
+var landBiomes = [5][5]world.Biome{
+	{biome.SnowyPlains{}, biome.SnowyPlains{}, biome.SnowyPlains{}, biome.SnowyTaiga{}, biome.Taiga{}},
+	{biome.Plains{}, biome.Plains{}, biome.Forest{}, biome.Taiga{}, biome.OldGrowthSpruceTaiga{}},
+	{biome.FlowerForest{}, biome.Plains{}, biome.Forest{}, biome.BirchForest{}, biome.DarkForest{}},
+	{biome.Savanna{}, biome.Savanna{}, biome.Forest{}, biome.Jungle{}, biome.Jungle{}},
+	{biome.Desert{}, biome.Desert{}, biome.Desert{}, biome.Desert{}, biome.Desert{}},
+}
+
+// oceanBiomes holds the shallow and deep ocean biomes, indexed by temperature.
+var oceanBiomes = [5][2]world.Biome{
+	{biome.FrozenOcean{}, biome.DeepFrozenOcean{}},
+	{biome.ColdOcean{}, biome.DeepColdOcean{}},
+	{biome.Ocean{}, biome.DeepOcean{}},
+	{biome.LukewarmOcean{}, biome.DeepLukewarmOcean{}},
+	{biome.WarmOcean{}, biome.WarmOcean{}},
+}
+
+// overworldBiome selects the biome of a column using its terrain height and
+// climate values.
+func overworldBiome(height int, cont, ero, weird float64, temp, hum int, river bool) world.Biome {
+	switch {
+	case cont < -0.19:
+		return oceanBiomes[temp][boolIndex(cont < -0.45)]
+	case river:
+		if temp == 0 {
+			return biome.FrozenRiver{}
+		}
+		return biome.River{}
+	case cont < -0.11 && height < seaLevel+4:
+		if ero < -0.3 {
+			return biome.StonyShore{}
+		} else if temp == 0 {
+			return biome.SnowyBeach{}
+		} else if temp == 4 {
+			return biome.Desert{}
+		}
+		return biome.Beach{}
+	case height > 140:
+		if temp >= 3 {
+			return biome.StonyPeaks{}
+		} else if weird > 0 {
+			return biome.JaggedPeaks{}
+		}
+		return biome.FrozenPeaks{}
+	case height > 105:
+		switch temp {
+		case 0:
+			return biome.SnowySlopes{}
+		case 1:
+			if hum >= 2 {
+				return biome.Grove{}
+			}
+			return biome.SnowySlopes{}
+		case 2:
+			if weird > 0.5 {
+				return biome.CherryGrove{}
+			}
+			return biome.Meadow{}
+		case 3:
+			return biome.WindsweptHills{}
+		}
+		return biome.WindsweptSavanna{}
+	case hum == 4 && (temp == 2 || temp == 3) && height < seaLevel+3:
+		if temp == 3 {
+			return biome.MangroveSwamp{}
+		}
+		return biome.Swamp{}
+	}
+	b := landBiomes[temp][hum]
+	switch b.(type) {
+	case biome.SnowyPlains:
+		if weird > 0.6 {
+			return biome.IceSpikes{}
+		}
+	case biome.Plains:
+		if weird > 0.6 {
+			return biome.SunflowerPlains{}
+		}
+	case biome.Desert:
+		if weird < -0.6 {
+			return biome.ErodedBadlands{}
+		} else if weird < -0.35 {
+			return biome.Badlands{}
+		}
+	case biome.Savanna:
+		if ero < -0.4 {
+			return biome.SavannaPlateau{}
+		}
+	}
+	return b
+}
+
+// surfaceBlocks returns the top and filler blocks used for the surface of a
+// column with a specific biome. The top block is the highest block of the
+// column, with the filler blocks directly below it.
+func surfaceBlocks(b world.Biome, y int, underwater bool) (top, filler world.Block) {
+	switch b.(type) {
+	case biome.Desert, biome.Beach, biome.SnowyBeach, biome.WarmOcean, biome.LukewarmOcean, biome.DeepLukewarmOcean, biome.River, biome.FrozenRiver:
+		return block.Sand{}, block.Sand{}
+	case biome.Badlands, biome.ErodedBadlands:
+		if y < seaLevel+10 {
+			return block.Sand{Red: true}, terracottaBand(y)
+		}
+		return terracottaBand(y), terracottaBand(y - 1)
+	case biome.Ocean, biome.DeepOcean, biome.ColdOcean, biome.DeepColdOcean, biome.FrozenOcean, biome.DeepFrozenOcean:
+		return block.Gravel{}, block.Gravel{}
+	case biome.StonyShore, biome.StonyPeaks, biome.JaggedPeaks:
+		return block.Stone{}, block.Stone{}
+	case biome.FrozenPeaks, biome.SnowySlopes:
+		return block.Snow{}, block.Snow{}
+	case biome.SnowyPlains, biome.SnowyTaiga, biome.IceSpikes, biome.Grove:
+		if !underwater {
+			return block.Snow{}, block.Dirt{}
+		}
+	case biome.WindsweptHills:
+		if y > 120 {
+			return block.Stone{}, block.Stone{}
+		}
+	case biome.Swamp, biome.MangroveSwamp:
+		if underwater {
+			return block.Clay{}, block.Dirt{}
+		}
+	}
+	if underwater {
+		return block.Dirt{}, block.Dirt{}
+	}
+	return block.Grass{}, block.Dirt{}
+}
+
+// terracottaBands holds the colours of the horizontal bands of terracotta
+// found in badlands biomes.
+var terracottaBands = [...]world.Block{
+	block.Terracotta{},
+	block.StainedTerracotta{Colour: item.ColourOrange()},
+	block.StainedTerracotta{Colour: item.ColourOrange()},
+	block.Terracotta{},
+	block.StainedTerracotta{Colour: item.ColourYellow()},
+	block.StainedTerracotta{Colour: item.ColourBrown()},
+	block.Terracotta{},
+	block.StainedTerracotta{Colour: item.ColourRed()},
+	block.StainedTerracotta{Colour: item.ColourWhite()},
+	block.StainedTerracotta{Colour: item.ColourLightGrey()},
+	block.Terracotta{},
+	block.StainedTerracotta{Colour: item.ColourOrange()},
+}
+
+// terracottaBand returns the terracotta block found at a specific height in a
+// badlands biome.
+func terracottaBand(y int) world.Block {
+	return terracottaBands[(y%len(terracottaBands)+len(terracottaBands))%len(terracottaBands)]
+}
+
+// decoration describes the trees and vegetation placed in a chunk of a
+// specific biome.
+type decoration struct {
+	// treeCount is the average number of trees per chunk. Values below 1 are
+	// treated as the chance of a single tree generating in the chunk.
+	treeCount float64
+	// tree places a single tree with its trunk at a specific position.
+	tree func(c *chunk.Chunk, r *rand.Rand, x uint8, y int16, z uint8)
+	// grass, flowers, cacti and deadBushes are the number of attempts made to
+	// place each of these plants.
+	grass, flowers, cacti, deadBushes int
+	// flowerTypes are the flowers that may generate in the biome.
+	flowerTypes []block.FlowerType
+}
+
+// trees returns the number of trees to attempt to place in a chunk.
+func (d decoration) trees(r *rand.Rand) int {
+	if d.tree == nil {
+		return 0
+	}
+	n := int(d.treeCount)
+	if r.Float64() < d.treeCount-float64(n) {
+		n++
+	}
+	return n
+}
+
+// flower returns a random flower that may grow in the biome.
+func (d decoration) flower(r *rand.Rand) block.FlowerType {
+	if len(d.flowerTypes) == 0 {
+		return block.Dandelion()
+	}
+	return d.flowerTypes[r.IntN(len(d.flowerTypes))]
+}
+
+var plainsFlowers = []block.FlowerType{block.Dandelion(), block.Poppy(), block.AzureBluet(), block.OxeyeDaisy(), block.Cornflower()}
+
+// decorationOf returns the decoration placed in chunks of a specific biome.
+func decorationOf(b world.Biome) decoration {
+	switch b.(type) {
+	case biome.Plains, biome.SunflowerPlains:
+		return decoration{treeCount: 0.1, tree: oakTree(block.OakWood()), grass: 40, flowers: 4, flowerTypes: plainsFlowers}
+	case biome.Meadow:
+		return decoration{treeCount: 0.05, tree: oakTree(block.BirchWood()), grass: 50, flowers: 12, flowerTypes: plainsFlowers}
+	case biome.Forest:
+		return decoration{treeCount: 8, tree: mixedTree(oakTree(block.OakWood()), oakTree(block.BirchWood())), grass: 10, flowers: 2, flowerTypes: plainsFlowers}
+	case biome.FlowerForest:
+		return decoration{treeCount: 5, tree: mixedTree(oakTree(block.OakWood()), oakTree(block.BirchWood())), grass: 6, flowers: 30, flowerTypes: []block.FlowerType{
+			block.Dandelion(), block.Poppy(), block.Allium(), block.AzureBluet(), block.RedTulip(), block.OrangeTulip(), block.WhiteTulip(), block.PinkTulip(), block.OxeyeDaisy(), block.Cornflower(), block.LilyOfTheValley(),
+		}}
+	case biome.BirchForest:
+		return decoration{treeCount: 8, tree: oakTree(block.BirchWood()), grass: 10, flowers: 2, flowerTypes: plainsFlowers}
+	case biome.DarkForest:
+		return decoration{treeCount: 10, tree: mixedTree(darkOakTree, oakTree(block.OakWood())), grass: 6}
+	case biome.CherryGrove:
+		return decoration{treeCount: 3, tree: oakTree(block.CherryWood()), grass: 30, flowers: 4, flowerTypes: plainsFlowers}
+	case biome.Taiga, biome.OldGrowthSpruceTaiga, biome.Grove:
+		return decoration{treeCount: 8, tree: spruceTree, grass: 10}
+	case biome.SnowyTaiga:
+		return decoration{treeCount: 5, tree: spruceTree}
+	case biome.SnowyPlains:
+		return decoration{treeCount: 0.1, tree: spruceTree}
+	case biome.WindsweptHills:
+		return decoration{treeCount: 1, tree: mixedTree(spruceTree, oakTree(block.OakWood())), grass: 6}
+	case biome.Savanna, biome.SavannaPlateau, biome.WindsweptSavanna:
+		return decoration{treeCount: 1, tree: acaciaTree, grass: 40}
+	case biome.Jungle:
+		return decoration{treeCount: 12, tree: jungleTree, grass: 30, flowers: 2, flowerTypes: plainsFlowers}
+	case biome.Swamp:
+		return decoration{treeCount: 2, tree: oakTree(block.OakWood()), grass: 10, flowers: 1, flowerTypes: []block.FlowerType{block.BlueOrchid()}}
+	case biome.MangroveSwamp:
+		return decoration{treeCount: 3, tree: oakTree(block.MangroveWood()), grass: 6}
+	case biome.Desert:
+		return decoration{cacti: 10, deadBushes: 3}
+	case biome.Badlands, biome.ErodedBadlands:
+		return decoration{cacti: 5, deadBushes: 10}
+	}
+	return decoration{}
+}
+
+// boolIndex returns 1 if b is true and 0 if it is false.
+func boolIndex(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
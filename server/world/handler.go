@@ -52,6 +52,11 @@ type Handler interface {
 	// Leaves decaying happens when there is no wood block neighbouring it.
 	// ctx.Cancel() may be called to prevent leaves from decaying.
 	HandleLeavesDecay(ctx *Context, pos cube.Pos)
+	// HandleMobSpawn handles a mob spawning naturally at a position in the
+	// World. The EntityHandle of the mob may be replaced to spawn a different
+	// entity, in which case the original EntityHandle is closed. ctx.Cancel()
+	// may be called to prevent the mob from spawning.
+	HandleMobSpawn(ctx *Context, pos mgl64.Vec3, handle **EntityHandle)
 	// HandleEntitySpawn handles an Entity being spawned into a World through a
 	// call to Tx.AddEntity.
 	HandleEntitySpawn(tx *Tx, e Entity)
@@ -81,6 +86,7 @@ func (NopHandler) HandleFireSpread(*Context, cube.Pos, cube.Pos)
 func (NopHandler) HandleBlockBurn(*Context, cube.Pos)                           {}
 func (NopHandler) HandleCropTrample(*Context, cube.Pos)                         {}
 func (NopHandler) HandleLeavesDecay(*Context, cube.Pos)                         {}
+func (NopHandler) HandleMobSpawn(*Context, mgl64.Vec3, **EntityHandle)          {}
 func (NopHandler) HandleEntitySpawn(*Tx, Entity)                                {}
 func (NopHandler) HandleEntityDespawn(*Tx, Entity)                              {}
 func (NopHandler) HandleClose(*Tx)                                              {}
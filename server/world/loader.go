@@ -4,6 +4,7 @@ import (
 	"github.com/go-gl/mathgl/mgl64"
 	"maps"
 	"math"
+	"slices"
 	"sync"
 )
 
@@ -86,30 +87,37 @@ func (l *Loader) Move(tx *Tx, pos mgl64.Vec3) {
 
 // Load loads n chunks around the centre of the chunk, starting with the middle and working outwards. For
 // every chunk loaded, the Viewer passed through construction in New has its ViewChunk method called.
+// Chunks that are not yet loaded in the World are loaded or generated in the background, so Load may load
+// fewer than n chunks if they are not ready yet. These chunks are loaded by a later call to Load.
 // Load does nothing for n <= 0.
 func (l *Loader) Load(tx *Tx, n int) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	if l.closed || l.w == nil {
+	if l.closed || l.w == nil || n <= 0 {
 		return
 	}
-	for i := 0; i < n; i++ {
-		if len(l.loadQueue) == 0 {
-			break
+	tx.w.insertLoadedChunks()
+
+	// Request more chunks than are loaded, so that the chunks loaded by the
+	// next calls are likely to be ready.
+	requested := 0
+	for i := 0; i < len(l.loadQueue) && n > 0 && requested < n*4; {
+		pos := l.loadQueue[i]
+		c, ok := tx.w.loadedChunk(pos)
+		if !ok {
+			requested++
+			i++
+			continue
 		}
-
-		pos := l.loadQueue[0]
-		c := tx.w.chunk(pos)
-
 		l.viewer.ViewChunk(pos, l.w.Dimension(), c.BlockEntities, c.Chunk)
 		l.w.addViewer(tx, c, l)
 
 		l.loaded[pos] = c
+		n--
 
-		// Shift the first element from the load queue off so that we can take a new one during the next
-		// iteration.
-		l.loadQueue = l.loadQueue[1:]
+		// Remove the chunk from the load queue so that it is not loaded again.
+		l.loadQueue = slices.Delete(l.loadQueue, i, i+1)
 	}
 }
 
@@ -0,0 +1,541 @@
+package world
+
+import (
+	"errors"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/go-gl/mathgl/mgl64"
+	"image"
+	"image/color"
+	"image/draw"
+	"maps"
+	"math"
+	"strconv"
+)
+
+// mapSize is the width and height of a Map in pixels.
+const mapSize = 128
+
+// MapData holds the data of a Map that is stored by a Provider.
+type MapData struct {
+	// Dimension is the Dimension of the World that the Map shows.
+	Dimension Dimension
+	// Centre is the position of the block shown in the centre of the Map. Its
+	// Y value is not used.
+	Centre cube.Pos
+	// Scale is the zoom level of the Map, ranging from 0 to 4. Every pixel of
+	// a Map shows an area of 2^Scale by 2^Scale blocks.
+	Scale int
+	// Locked specifies if the Map is locked, in which case changes in the
+	// World are no longer shown on the Map.
+	Locked bool
+	// Pixels holds the colours of the 128x128 pixels of the Map, row by row,
+	// with every pixel taking up 4 bytes for its red, green, blue and alpha
+	// values respectively.
+	Pixels []uint8
+	// Frames holds the rotation of the markers of item frames that hold the
+	// Map, indexed by the position of the frames. See MapMarker.Rotation for
+	// the values of the rotation.
+	Frames map[cube.Pos]uint8
+}
+
+// MapMarkerType is the type of a MapMarker. It determines the icon shown for
+// the MapMarker.
+type MapMarkerType uint8
+
+const (
+	// MapMarkerPlayer is the type of MapMarker shown for a player holding the
+	// Map. It points in the direction that the player is facing.
+	MapMarkerPlayer MapMarkerType = iota
+	// MapMarkerPlayerOffMap is the type of MapMarker shown for a player
+	// holding the Map while outside the area shown on it. It is shown at the
+	// edge of the Map closest to the player.
+	MapMarkerPlayerOffMap
+	// MapMarkerFrame is the type of MapMarker shown for an item frame holding
+	// the Map.
+	MapMarkerFrame
+)
+
+// MapMarker is a marker shown on a Map, such as the position of a player
+// holding the Map.
+type MapMarker struct {
+	// Type is the type of the MapMarker, which determines its icon.
+	Type MapMarkerType
+	// X and Y are the position of the MapMarker on the Map in half pixels,
+	// ranging from -128 at the left and top edges to 127 at the right and
+	// bottom edges of the Map.
+	X, Y int8
+	// Rotation is the rotation of the MapMarker in steps of 22.5 degrees
+	// clockwise, ranging from 0 to 15. A Rotation of 0 points to the bottom of
+	// the Map, which is south.
+	Rotation uint8
+}
+
+// Map is a map shown by filled map items and item frames holding them. A Map
+// shows a top-down view of part of a World, which is rendered around players
+// holding it, or an image drawn onto it using Map.Draw. Maps are identified
+// by an ID, which is stored in the items that show them.
+// Maps are obtained from a Tx using Tx.Map or Tx.NewMap and may only be used
+// in transactions of the World that they were obtained from.
+type Map struct {
+	w    *World
+	id   int64
+	data MapData
+	// img is an image.RGBA sharing the pixels of data.
+	img *image.RGBA
+
+	// dirty is the area of pixels changed since viewers of the World were
+	// last updated.
+	dirty image.Rectangle
+	// players holds the markers of entities that held the Map during the
+	// current tick. shown holds the markers of entities that held the Map
+	// during the last tick, which are shown on the Map.
+	players, shown map[*EntityHandle]MapMarker
+	// markersChanged is true if the markers of frames holding the Map changed
+	// since viewers of the World were last updated.
+	markersChanged bool
+	// modified is true if the Map changed since it was last saved.
+	modified bool
+	// step is increased every time the Map is rendered and is used to spread
+	// out rendering of the Map over multiple ticks.
+	step int
+}
+
+// newMap creates a Map with the ID and data passed.
+func newMap(w *World, id int64, data MapData) *Map {
+	if len(data.Pixels) != mapSize*mapSize*4 {
+		data.Pixels = make([]uint8, mapSize*mapSize*4)
+	}
+	if data.Frames == nil {
+		data.Frames = make(map[cube.Pos]uint8)
+	}
+	data.Scale = min(max(data.Scale, 0), 4)
+	return &Map{
+		w:       w,
+		id:      id,
+		data:    data,
+		img:     &image.RGBA{Pix: data.Pixels, Stride: mapSize * 4, Rect: image.Rect(0, 0, mapSize, mapSize)},
+		players: make(map[*EntityHandle]MapMarker),
+		shown:   make(map[*EntityHandle]MapMarker),
+	}
+}
+
+// ID returns the ID of the Map, which is stored in the items that show the
+// Map.
+func (m *Map) ID() int64 {
+	return m.id
+}
+
+// Dimension returns the Dimension of the World that the Map shows.
+func (m *Map) Dimension() Dimension {
+	return m.data.Dimension
+}
+
+// Centre returns the position of the block shown in the centre of the Map.
+// Its Y value is always 0.
+func (m *Map) Centre() cube.Pos {
+	return m.data.Centre
+}
+
+// Scale returns the zoom level of the Map, ranging from 0 to 4. Every pixel of
+// the Map shows an area of 2^Scale by 2^Scale blocks.
+func (m *Map) Scale() int {
+	return m.data.Scale
+}
+
+// Locked checks if the Map is locked, in which case changes in the World are
+// no longer shown on it.
+func (m *Map) Locked() bool {
+	return m.data.Locked
+}
+
+// SetLocked locks or unlocks the Map. Changes in the World are not shown on a
+// locked Map, so that its pixels only change when drawn onto using Map.Draw.
+func (m *Map) SetLocked(locked bool) {
+	if m.data.Locked != locked {
+		m.data.Locked, m.modified = locked, true
+		m.markersChanged = true
+	}
+}
+
+// Image returns a copy of the 128x128 pixels currently shown on the Map.
+func (m *Map) Image() *image.RGBA {
+	img := image.NewRGBA(m.img.Rect)
+	copy(img.Pix, m.img.Pix)
+	return img
+}
+
+// Pixels returns the colours of the pixels of the Map in the area passed, row
+// by row. The area is limited to the bounds of the Map.
+func (m *Map) Pixels(area image.Rectangle) []color.RGBA {
+	area = area.Intersect(m.img.Rect)
+	pixels := make([]color.RGBA, 0, area.Dx()*area.Dy())
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			pixels = append(pixels, m.img.RGBAAt(x, y))
+		}
+	}
+	return pixels
+}
+
+// Draw draws the image passed onto the Map. The top left corner of the bounds
+// of the image is drawn at the top left corner of the Map, so that an image
+// larger than a single Map may be split over multiple Maps using
+// SubImage. Transparent pixels of the image leave the pixels of the Map
+// unchanged. Draw is typically used on a locked Map, as the pixels of other
+// Maps are overwritten when the World is rendered onto them.
+func (m *Map) Draw(img image.Image) {
+	draw.Draw(m.img, m.img.Rect, img, img.Bounds().Min, draw.Over)
+	m.changed(m.img.Rect.Intersect(img.Bounds().Sub(img.Bounds().Min)))
+}
+
+// Markers returns the markers currently shown on the Map, which includes
+// markers of players holding the Map and of item frames holding it.
+func (m *Map) Markers() []MapMarker {
+	markers := make([]MapMarker, 0, len(m.shown)+len(m.data.Frames))
+	for _, marker := range m.shown {
+		markers = append(markers, marker)
+	}
+	for pos, rot := range m.data.Frames {
+		if x, y, ok := m.markerPos(pos.Vec3Centre()); ok {
+			markers = append(markers, MapMarker{Type: MapMarkerFrame, X: x, Y: y, Rotation: rot})
+		}
+	}
+	return markers
+}
+
+// AddFrame adds a marker to the Map for an item frame holding it at the
+// position passed, facing in the direction passed. No marker is added if the
+// Map shows a Dimension other than that of the World of the frame.
+func (m *Map) AddFrame(pos cube.Pos, facing cube.Face) {
+	if m.w.Dimension() != m.data.Dimension {
+		return
+	}
+	var rot uint8
+	switch facing {
+	case cube.FaceWest:
+		rot = 4
+	case cube.FaceNorth:
+		rot = 8
+	case cube.FaceEast:
+		rot = 12
+	}
+	if old, ok := m.data.Frames[pos]; !ok || old != rot {
+		m.data.Frames[pos] = rot
+		m.modified, m.markersChanged = true, true
+	}
+}
+
+// RemoveFrame removes the marker of an item frame at the position passed from
+// the Map, if present.
+func (m *Map) RemoveFrame(pos cube.Pos) {
+	if _, ok := m.data.Frames[pos]; ok {
+		delete(m.data.Frames, pos)
+		m.modified, m.markersChanged = true, true
+	}
+}
+
+// Update updates the Map for an Entity, usually a player, holding it. A marker
+// is shown on the Map for the Entity during the next tick. Additionally, the
+// area of the World around the Entity is rendered onto the Map, unless the
+// Map is locked or shows a Dimension other than that of the World. Update is
+// called every tick for every player holding a filled map.
+func (m *Map) Update(tx *Tx, e Entity) {
+	pos := e.Position()
+	if m.w.Dimension() == m.data.Dimension {
+		if marker, ok := m.playerMarker(pos, e.Rotation().Yaw()); ok {
+			m.players[e.H()] = marker
+		}
+	}
+	if !m.data.Locked && m.w.Dimension() == m.data.Dimension {
+		m.render(tx, pos)
+	}
+}
+
+// playerMarker returns the MapMarker of a player at the position passed with
+// the yaw passed. False is returned if the player is too far away from the
+// area shown on the Map to be shown.
+func (m *Map) playerMarker(pos mgl64.Vec3, yaw float64) (MapMarker, bool) {
+	if x, y, ok := m.markerPos(pos); ok {
+		return MapMarker{Type: MapMarkerPlayer, X: x, Y: y, Rotation: uint8(int(math.Round(yaw/22.5))+16) % 16}, true
+	}
+	scale := float64(int(1) << m.data.Scale)
+	dx, dz := (pos[0]-float64(m.data.Centre[0]))/scale, (pos[2]-float64(m.data.Centre[2]))/scale
+	if math.Abs(dx) >= 320 || math.Abs(dz) >= 320 {
+		return MapMarker{}, false
+	}
+	edge := func(v float64) int8 {
+		return int8(min(max(v*2, -128), 127))
+	}
+	return MapMarker{Type: MapMarkerPlayerOffMap, X: edge(dx), Y: edge(dz)}, true
+}
+
+// markerPos returns the position on the Map of a marker at the position in
+// the World passed. False is returned if the position is not shown on the
+// Map.
+func (m *Map) markerPos(pos mgl64.Vec3) (x, y int8, ok bool) {
+	scale := float64(int(1) << m.data.Scale)
+	dx, dz := (pos[0]-float64(m.data.Centre[0]))/scale, (pos[2]-float64(m.data.Centre[2]))/scale
+	if dx < -63 || dz < -63 || dx > 63 || dz > 63 {
+		return 0, 0, false
+	}
+	return int8(math.Floor(dx*2 + 0.5)), int8(math.Floor(dz*2 + 0.5)), true
+}
+
+// Map colour multipliers that are used to shade pixels of a Map to show
+// differences in height.
+const (
+	mapShadeLow    = 180
+	mapShadeNormal = 220
+	mapShadeHigh   = 255
+)
+
+var (
+	// mapColourDirt and mapColourStone are the colours used to render maps in
+	// dimensions with a ceiling, such as the nether.
+	mapColourDirt  = color.RGBA{R: 0x97, G: 0x6d, B: 0x4d, A: 0xff}
+	mapColourStone = color.RGBA{R: 0x70, G: 0x70, B: 0x70, A: 0xff}
+)
+
+// render renders the area of the World around the position passed onto the
+// Map, like vanilla does for players holding a map. Every call renders only
+// a part of the columns of pixels in the area, so that the complete area is
+// rendered over 16 calls. Chunks that are not loaded are not rendered.
+func (m *Map) render(tx *Tx, pos mgl64.Vec3) {
+	w := tx.World()
+	scale := 1 << m.data.Scale
+	cx, cz := m.data.Centre[0], m.data.Centre[2]
+	px := int(math.Floor(pos[0]-float64(cx)))/scale + mapSize/2
+	pz := int(math.Floor(pos[2]-float64(cz)))/scale + mapSize/2
+
+	ceiling := w.Dimension() == Nether
+	radius := mapSize / scale
+	if ceiling {
+		radius /= 2
+	}
+	m.step++
+	for x := max(px-radius+1, 0); x < min(px+radius, mapSize); x++ {
+		if x&15 != m.step&15 {
+			continue
+		}
+		prevHeight := 0.0
+		for z := max(pz-radius-1, -1); z < min(pz+radius, mapSize); z++ {
+			dx, dz := x-px, z-pz
+			edge := dx*dx+dz*dz > (radius-2)*(radius-2)
+			bx, bz := (cx/scale+x-mapSize/2)*scale, (cz/scale+z-mapSize/2)*scale
+
+			c, ok := w.chunks[chunkPosFromBlockPos(cube.Pos{bx, 0, bz})]
+			if !ok {
+				continue
+			}
+			col, height, depth := m.sample(w, c, bx, bz, scale, ceiling)
+
+			var shade float64
+			if depth >= 0 {
+				// Water is shaded by its depth rather than by the height
+				// difference with the pixel above it.
+				shade = depth*0.1 + float64((x+z)&1)*0.2
+				shade = -shade + 0.7
+			} else {
+				shade = (height-prevHeight)*4/float64(scale+4) + (float64((x+z)&1)-0.5)*0.4
+			}
+			prevHeight = height
+
+			if z < 0 || dx*dx+dz*dz >= radius*radius || (edge && (x+z)&1 == 0) {
+				continue
+			}
+			mul := mapShadeNormal
+			if shade > 0.6 {
+				mul = mapShadeHigh
+			} else if shade < -0.6 {
+				mul = mapShadeLow
+			}
+			if col.A != 0 {
+				col = color.RGBA{R: uint8(int(col.R) * mul / 255), G: uint8(int(col.G) * mul / 255), B: uint8(int(col.B) * mul / 255), A: 0xff}
+			}
+			if m.img.RGBAAt(x, z) != col {
+				m.img.SetRGBA(x, z, col)
+				m.changed(image.Rect(x, z, x+1, z+1))
+			}
+		}
+	}
+}
+
+// sample returns the most common map colour of the blocks in the area of
+// scale by scale blocks starting at bx and bz, along with the average height
+// of the area. If the most common colour is that of water, the average depth
+// of the water is returned. Otherwise, depth is -1.
+func (m *Map) sample(w *World, c *Column, bx, bz, scale int, ceiling bool) (col color.RGBA, height, depth float64) {
+	if ceiling {
+		// Vanilla does not render dimensions with a ceiling, but shows a noisy
+		// pattern of dirt and stone instead.
+		n := bx + bz*231871
+		n = n*n*31287121 + n*11
+		if (n>>20)&1 == 0 {
+			return mapColourDirt, 100, -1
+		}
+		return mapColourStone, 100, -1
+	}
+	counts := make(map[color.RGBA]int, 1)
+	var water color.RGBA
+	waterDepth := 0
+	for x := bx; x < bx+scale; x++ {
+		for z := bz; z < bz+scale; z++ {
+			y := int(c.HighestBlock(uint8(x), uint8(z)))
+			var (
+				b        Block
+				blockCol color.RGBA
+			)
+			for ; y > w.ra[0]; y-- {
+				b = w.blockInChunk(c, cube.Pos{x, y, z})
+				if blockCol = blockMapColour(b); blockCol.A != 0 {
+					break
+				}
+			}
+			if liq, ok := b.(Liquid); ok && liq.LiquidType() == "water" {
+				water = blockCol
+				for yy := y - 1; yy > w.ra[0]; yy-- {
+					if _, ok := w.blockInChunk(c, cube.Pos{x, yy, z}).(Liquid); !ok {
+						break
+					}
+					waterDepth++
+				}
+			}
+			height += float64(y) / float64(scale*scale)
+			counts[blockCol]++
+		}
+	}
+	best := -1
+	for candidate, n := range counts {
+		if n > best || (n == best && candidate.A != 0) {
+			col, best = candidate, n
+		}
+	}
+	if col == water && water.A != 0 {
+		return col, height, float64(waterDepth) / float64(scale*scale)
+	}
+	return col, height, -1
+}
+
+// mapColoured is a Block that is shown on maps. It is implemented by blocks in
+// the block package through block.MapColoured.
+type mapColoured interface {
+	MapColour() color.RGBA
+}
+
+// blockMapColour returns the map colour of the Block passed. A fully
+// transparent colour is returned if the Block is not shown on maps.
+func blockMapColour(b Block) color.RGBA {
+	if c, ok := b.(mapColoured); ok {
+		return c.MapColour()
+	}
+	if c, ok := b.(CustomBlock); ok {
+		if v, err := strconv.ParseUint(trimHash(c.Properties().MapColour), 16, 32); err == nil {
+			return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+		}
+	}
+	return color.RGBA{}
+}
+
+// trimHash trims a leading '#' from the hexadecimal colour passed.
+func trimHash(s string) string {
+	if len(s) > 0 && s[0] == '#' {
+		return s[1:]
+	}
+	return s
+}
+
+// changed marks the area of pixels passed as changed, so that viewers of the
+// World are updated at the end of the tick.
+func (m *Map) changed(area image.Rectangle) {
+	if area.Empty() {
+		return
+	}
+	m.dirty, m.modified = m.dirty.Union(area), true
+}
+
+// tick sends the changes made to the Map during the tick to the viewers of the
+// World passed.
+func (m *Map) tick(viewers []Viewer) {
+	changed := m.markersChanged || !maps.Equal(m.players, m.shown)
+	m.shown, m.players = m.players, m.shown
+	clear(m.players)
+
+	if !changed && m.dirty.Empty() {
+		return
+	}
+	for _, v := range viewers {
+		v.ViewMap(m, m.dirty)
+	}
+	m.dirty, m.markersChanged = image.Rectangle{}, false
+}
+
+// Map returns the Map with the ID passed. If the Map is not yet loaded, it is
+// loaded from the Provider of the World. False is returned if no Map with the
+// ID exists.
+func (tx *Tx) Map(id int64) (*Map, bool) {
+	return tx.World().loadMap(id)
+}
+
+// NewMap creates a new Map that shows the Dimension of the World, centred on
+// the position passed with the scale passed, ranging from 0 to 4. The Map is
+// assigned a new, unique ID. Pixels of the Map are rendered when it is held by
+// players. See Map.Update for more information.
+func (tx *Tx) NewMap(centre cube.Pos, scale int) *Map {
+	w := tx.World()
+	var id int64
+	for {
+		// Vanilla map IDs are random, so we do the same, making sure not to
+		// take the ID of a map that already exists.
+		if id = w.r.Int64(); id == 0 {
+			continue
+		}
+		if _, ok := w.loadMap(id); !ok {
+			break
+		}
+	}
+	m := newMap(w, id, MapData{Dimension: w.Dimension(), Centre: cube.Pos{centre[0], 0, centre[2]}, Scale: scale})
+	m.modified = true
+	w.maps[id] = m
+	return m
+}
+
+// loadMap returns the Map with the ID passed, loading it from the Provider if
+// it is not yet loaded.
+func (w *World) loadMap(id int64) (*Map, bool) {
+	if m, ok := w.maps[id]; ok {
+		return m, true
+	}
+	data, err := w.conf.Provider.LoadMap(id)
+	if err != nil {
+		if !errors.Is(err, leveldb.ErrNotFound) {
+			w.conf.Log.Error("load map: "+err.Error(), "ID", id)
+		}
+		return nil, false
+	}
+	m := newMap(w, id, *data)
+	w.maps[id] = m
+	return m, true
+}
+
+// tickMaps sends the changes made to loaded maps during the tick to the
+// viewers passed.
+func (w *World) tickMaps(viewers []Viewer) {
+	for _, m := range w.maps {
+		m.tick(viewers)
+	}
+}
+
+// saveMaps saves all maps that were changed since they were last saved to the
+// Provider of the World.
+func (w *World) saveMaps() {
+	for id, m := range w.maps {
+		if !m.modified {
+			continue
+		}
+		m.modified = false
+		if err := w.conf.Provider.StoreMap(id, &m.data); err != nil {
+			w.conf.Log.Error("save map: "+err.Error(), "ID", id)
+		}
+	}
+}
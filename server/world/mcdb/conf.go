@@ -35,7 +35,9 @@ func (conf Config) Open(dir string) (*DB, error) {
 	if conf.LDBOptions.BlockSize == 0 {
 		conf.LDBOptions.BlockSize = 16 * opt.KiB
 	}
-	_ = os.MkdirAll(filepath.Join(dir, "db"), 0777)
+	if !conf.LDBOptions.ReadOnly {
+		_ = os.MkdirAll(filepath.Join(dir, "db"), 0777)
+	}
 
 	db := &DB{conf: conf, dir: dir, ldat: &leveldat.Data{}}
 	if _, err := os.Stat(filepath.Join(dir, "level.dat")); os.IsNotExist(err) {
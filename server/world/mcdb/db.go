@@ -62,6 +62,10 @@ func (db *DB) LoadPlayerSpawnPosition(id uuid.UUID) (pos cube.Pos, exists bool,
 		return cube.Pos{}, exists, err
 	}
 	x, y, z := serverData["SpawnX"], serverData["SpawnY"], serverData["SpawnZ"]
+	if x == nil && y == nil && z == nil {
+		// The spawn position of the player was removed.
+		return cube.Pos{}, false, nil
+	}
 	if x == nil || y == nil || z == nil {
 		return cube.Pos{}, true, fmt.Errorf("error reading spawn fields from server data for player %v", id)
 	}
@@ -124,6 +128,27 @@ func (db *DB) SavePlayerSpawnPosition(id uuid.UUID, pos cube.Pos) error {
 	return nil
 }
 
+// RemovePlayerSpawnPosition removes the player spawn position stored in the
+// levelDB database for the UUID passed.
+func (db *DB) RemovePlayerSpawnPosition(id uuid.UUID) error {
+	d, k, exists, err := db.loadPlayerData(id)
+	if !exists || err != nil {
+		return err
+	}
+	delete(d, "SpawnX")
+	delete(d, "SpawnY")
+	delete(d, "SpawnZ")
+
+	data, err := nbt.MarshalEncoding(d, nbt.LittleEndian)
+	if err != nil {
+		panic(err)
+	}
+	if err = db.ldb.Put([]byte(k), data, nil); err != nil {
+		return fmt.Errorf("write server data for player %v: %w", id, err)
+	}
+	return nil
+}
+
 // LoadColumn reads a world.Column from the DB at a position and dimension in
 // the DB. If no column at that position exists, errors.Is(err,
 // leveldb.ErrNotFound) equals true.
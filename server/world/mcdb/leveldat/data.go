@@ -198,6 +198,7 @@ func (d *Data) FillDefault() {
 	d.NaturalRegeneration = true
 	d.NetherScale = 8
 	d.NetworkVersion = protocol.CurrentProtocol
+	d.PlayersSleepingPercentage = 100
 	d.PVP = true
 	d.Platform = 2
 	d.PlatformBroadcastIntent = 3
@@ -239,6 +240,8 @@ func (d *Data) Settings() *world.Settings {
 		DefaultGameMode: mode,
 		Difficulty:      difficulty,
 		TickRange:       d.ServerChunkTickRange,
+
+		PlayersSleepingPercentage: d.PlayersSleepingPercentage,
 	}
 }
 
@@ -260,6 +263,7 @@ func (d *Data) PutSettings(s *world.Settings) {
 	}
 	d.CurrentTick = s.CurrentTick
 	d.ServerChunkTickRange = s.TickRange
+	d.PlayersSleepingPercentage = s.PlayersSleepingPercentage
 	mode, _ := world.GameModeID(s.DefaultGameMode)
 	d.GameType = int32(mode)
 	difficulty, _ := world.DifficultyID(s.Difficulty)
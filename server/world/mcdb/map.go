@@ -0,0 +1,149 @@
+package mcdb
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"strconv"
+)
+
+// mapData is the NBT structure of a map as stored by vanilla under the
+// 'map_<id>' key.
+type mapData struct {
+	ID                int64               `nbt:"mapId"`
+	ParentID          int64               `nbt:"parentMapId"`
+	Dimension         byte                `nbt:"dimension"`
+	FullyExplored     byte                `nbt:"fullyExplored"`
+	Locked            byte                `nbt:"mapLocked"`
+	Scale             byte                `nbt:"scale"`
+	Height            int16               `nbt:"height"`
+	Width             int16               `nbt:"width"`
+	UnlimitedTracking byte                `nbt:"unlimitedTracking"`
+	XCentre           int32               `nbt:"xCenter"`
+	ZCentre           int32               `nbt:"zCenter"`
+	Colours           [128 * 128 * 4]byte `nbt:"colors"`
+	Decorations       []mapDecoration     `nbt:"decorations"`
+}
+
+// mapDecoration is the NBT structure of a decoration of a map, such as the
+// marker of an item frame holding the map.
+type mapDecoration struct {
+	Data struct {
+		Rotation int32 `nbt:"rot"`
+		Type     int32 `nbt:"type"`
+		X        int32 `nbt:"x"`
+		Y        int32 `nbt:"y"`
+	} `nbt:"data"`
+	Key struct {
+		X    int32 `nbt:"blockX"`
+		Y    int32 `nbt:"blockY"`
+		Z    int32 `nbt:"blockZ"`
+		Type int32 `nbt:"type"`
+	} `nbt:"key"`
+}
+
+// mapDecorationFrame is the type of decoration of an item frame holding a
+// map. It is used both for the type of the marker and the type of the key of
+// the decoration.
+const mapDecorationFrame = 1
+
+// mapKey returns the database key of the map with the ID passed.
+func mapKey(id int64) []byte {
+	return []byte("map_" + strconv.FormatInt(id, 10))
+}
+
+// LoadMap reads the data of the world.Map with the ID passed from the DB. If
+// no map with the ID exists, errors.Is(err, leveldb.ErrNotFound) equals true.
+func (db *DB) LoadMap(id int64) (*world.MapData, error) {
+	b, err := db.ldb.Get(mapKey(id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("load map %v: %w", id, err)
+	}
+	var m map[string]any
+	if err := nbt.UnmarshalEncoding(b, &m, nbt.LittleEndian); err != nil {
+		return nil, fmt.Errorf("decode map %v: %w", id, err)
+	}
+	dim, _ := world.DimensionByID(int(nbtInt(m["dimension"])))
+	if dim == nil {
+		dim = world.Overworld
+	}
+	data := &world.MapData{
+		Dimension: dim,
+		Centre:    cube.Pos{int(nbtInt(m["xCenter"])), 0, int(nbtInt(m["zCenter"]))},
+		Scale:     int(nbtInt(m["scale"])),
+		Locked:    nbtInt(m["mapLocked"]) != 0,
+		Frames:    make(map[cube.Pos]uint8),
+	}
+	switch colours := m["colors"].(type) {
+	case [128 * 128 * 4]byte:
+		data.Pixels = colours[:]
+	case []byte:
+		data.Pixels = colours
+	}
+	decorations, _ := m["decorations"].([]any)
+	for _, v := range decorations {
+		d, _ := v.(map[string]any)
+		key, _ := d["key"].(map[string]any)
+		marker, _ := d["data"].(map[string]any)
+		if key == nil || marker == nil || nbtInt(key["type"]) != mapDecorationFrame {
+			continue
+		}
+		pos := cube.Pos{int(nbtInt(key["blockX"])), int(nbtInt(key["blockY"])), int(nbtInt(key["blockZ"]))}
+		data.Frames[pos] = uint8(nbtInt(marker["rot"]))
+	}
+	return data, nil
+}
+
+// StoreMap stores the data of the world.Map with the ID passed in the DB.
+func (db *DB) StoreMap(id int64, data *world.MapData) error {
+	dim, _ := world.DimensionID(data.Dimension)
+	m := mapData{
+		ID:                id,
+		ParentID:          -1,
+		Dimension:         byte(dim),
+		FullyExplored:     1,
+		Scale:             byte(data.Scale),
+		Height:            128,
+		Width:             128,
+		UnlimitedTracking: 0,
+		XCentre:           int32(data.Centre[0]),
+		ZCentre:           int32(data.Centre[2]),
+		Decorations:       make([]mapDecoration, 0, len(data.Frames)),
+	}
+	if data.Locked {
+		m.Locked = 1
+	}
+	copy(m.Colours[:], data.Pixels)
+	for pos, rot := range data.Frames {
+		var d mapDecoration
+		d.Data.Rotation, d.Data.Type = int32(rot), mapDecorationFrame
+		d.Key.X, d.Key.Y, d.Key.Z, d.Key.Type = int32(pos[0]), int32(pos[1]), int32(pos[2]), mapDecorationFrame
+		m.Decorations = append(m.Decorations, d)
+	}
+	b, err := nbt.MarshalEncoding(m, nbt.LittleEndian)
+	if err != nil {
+		panic(err)
+	}
+	if err := db.ldb.Put(mapKey(id), b, nil); err != nil {
+		return fmt.Errorf("store map %v: %w", id, err)
+	}
+	return nil
+}
+
+// nbtInt returns the integer value of an NBT tag decoded into an any value,
+// regardless of the size of the integer. 0 is returned if v is not an
+// integer.
+func nbtInt(v any) int64 {
+	switch v := v.(type) {
+	case byte:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	}
+	return 0
+}
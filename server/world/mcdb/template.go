@@ -0,0 +1,216 @@
+package mcdb
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb/opt"
+	"github.com/google/uuid"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// Template is a world opened as a read-only template, from which any number of
+// world instances may be created, for example to play a minigame on copies of
+// the same map. The data of the template is never changed. Template is safe
+// for concurrent use.
+type Template struct {
+	db *DB
+}
+
+// OpenTemplate opens the world in the directory passed as a Template. Unlike
+// Open, OpenTemplate never creates or changes any files. An error is returned
+// if no world is present in the directory or if its data cannot be parsed.
+func (conf Config) OpenTemplate(dir string) (*Template, error) {
+	for _, path := range []string{filepath.Join(dir, "db"), filepath.Join(dir, "level.dat")} {
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("open template: %w", err)
+		}
+	}
+	o := opt.Options{}
+	if conf.LDBOptions != nil {
+		o = *conf.LDBOptions
+	}
+	o.ReadOnly, o.ErrorIfMissing = true, true
+	conf.LDBOptions = &o
+
+	db, err := conf.Open(dir)
+	if err != nil {
+		return nil, fmt.Errorf("open template: %w", err)
+	}
+	return &Template{db: db}, nil
+}
+
+// OpenTemplate opens the world in the directory passed as a Template using
+// default options.
+func OpenTemplate(dir string) (*Template, error) {
+	var conf Config
+	return conf.OpenTemplate(dir)
+}
+
+// Instance returns a new world.Provider that reads the chunks of the Template
+// and keeps all changes made to them in memory. The changes of an instance are
+// not visible to other instances and are discarded when the world using the
+// instance is closed. Instances only hold the chunks that were changed and
+// read all other chunks from the Template when needed.
+// The world.World created with the provider must not be ReadOnly: Changes
+// made to chunks would otherwise be lost when the chunks are unloaded.
+func (t *Template) Instance() world.Provider {
+	return &instance{
+		t:       t,
+		set:     t.db.Settings().Clone(),
+		columns: make(map[dbKey]storedColumn),
+		spawns:  make(map[uuid.UUID]*cube.Pos),
+		maps:    make(map[int64]*world.MapData),
+	}
+}
+
+// Close closes the Template. Worlds using instances of the Template must be
+// closed before calling Close.
+func (t *Template) Close() error {
+	return t.db.ldb.Close()
+}
+
+// instance is a world.Provider that overlays the changes made to the chunks of
+// a world on the chunks of a Template.
+type instance struct {
+	t   *Template
+	set *world.Settings
+
+	mu      sync.Mutex
+	columns map[dbKey]storedColumn
+	// spawns holds the player spawn positions changed in the instance. A nil
+	// position means that the spawn position of the player was removed.
+	spawns map[uuid.UUID]*cube.Pos
+	maps   map[int64]*world.MapData
+}
+
+// Settings returns a copy of the world.Settings of the Template.
+func (i *instance) Settings() *world.Settings {
+	return i.set
+}
+
+// SaveSettings does nothing: The world.Settings of an instance are held only in
+// memory.
+func (i *instance) SaveSettings(*world.Settings) {}
+
+// LoadPlayerSpawnPosition loads the spawn position of a player set in the
+// instance, or in the Template if it was not changed.
+func (i *instance) LoadPlayerSpawnPosition(id uuid.UUID) (cube.Pos, bool, error) {
+	i.mu.Lock()
+	pos, ok := i.spawns[id]
+	i.mu.Unlock()
+	if ok {
+		if pos == nil {
+			return cube.Pos{}, false, nil
+		}
+		return *pos, true, nil
+	}
+	return i.t.db.LoadPlayerSpawnPosition(id)
+}
+
+// SavePlayerSpawnPosition saves the spawn position of a player in memory.
+func (i *instance) SavePlayerSpawnPosition(id uuid.UUID, pos cube.Pos) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.spawns[id] = &pos
+	return nil
+}
+
+// RemovePlayerSpawnPosition removes the spawn position of a player in memory,
+// including a spawn position stored in the Template.
+func (i *instance) RemovePlayerSpawnPosition(id uuid.UUID) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.spawns[id] = nil
+	return nil
+}
+
+// LoadColumn returns a copy of the column last stored in the instance at the
+// position passed, or the column of the Template if none was stored.
+func (i *instance) LoadColumn(pos world.ChunkPos, dim world.Dimension) (*chunk.Column, error) {
+	i.mu.Lock()
+	stored, ok := i.columns[dbKey{pos: pos, dim: dim}]
+	i.mu.Unlock()
+	if !ok {
+		return i.t.db.LoadColumn(pos, dim)
+	}
+	c, err := chunk.DiskDecode(stored.data, dim.Range())
+	if err != nil {
+		return nil, fmt.Errorf("load column %v (%v): %w", pos, dim, err)
+	}
+	col := cloneColumn(stored.col)
+	col.Chunk = c
+	return &col, nil
+}
+
+// StoreColumn stores a copy of the column passed in memory, so that changes
+// made to the column after storing it do not affect the instance.
+func (i *instance) StoreColumn(pos world.ChunkPos, dim world.Dimension, col *chunk.Column) error {
+	stored := storedColumn{data: chunk.Encode(col.Chunk, chunk.DiskEncoding), col: cloneColumn(*col)}
+	stored.col.Chunk = nil
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.columns[dbKey{pos: pos, dim: dim}] = stored
+	return nil
+}
+
+// storedColumn is a chunk.Column stored in an instance. The chunk of the
+// column is held in its encoded form and decoded every time it is loaded.
+type storedColumn struct {
+	data chunk.SerialisedData
+	col  chunk.Column
+}
+
+// cloneColumn returns a copy of the entities, block entities and scheduled
+// block updates of a chunk.Column. The chunk of the column is not copied.
+func cloneColumn(col chunk.Column) chunk.Column {
+	col.Entities = slices.Clone(col.Entities)
+	for i, e := range col.Entities {
+		col.Entities[i].Data = maps.Clone(e.Data)
+	}
+	col.BlockEntities = slices.Clone(col.BlockEntities)
+	for i, e := range col.BlockEntities {
+		col.BlockEntities[i].Data = maps.Clone(e.Data)
+	}
+	col.ScheduledBlocks = slices.Clone(col.ScheduledBlocks)
+	return col
+}
+
+// LoadMap returns the map last stored in the instance with the ID passed, or
+// the map of the Template if none was stored.
+func (i *instance) LoadMap(id int64) (*world.MapData, error) {
+	i.mu.Lock()
+	data, ok := i.maps[id]
+	i.mu.Unlock()
+	if ok {
+		return data, nil
+	}
+	return i.t.db.LoadMap(id)
+}
+
+// StoreMap stores a copy of the map passed in memory.
+func (i *instance) StoreMap(id int64, data *world.MapData) error {
+	cp := *data
+	cp.Pixels, cp.Frames = slices.Clone(data.Pixels), maps.Clone(data.Frames)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.maps[id] = &cp
+	return nil
+}
+
+// Close discards all changes held by the instance.
+func (i *instance) Close() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	clear(i.columns)
+	clear(i.spawns)
+	clear(i.maps)
+	return nil
+}
@@ -0,0 +1,152 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+	"reflect"
+	"slices"
+)
+
+// movementStep is the distance in blocks that moving blocks travel every tick.
+const movementStep = 0.5
+
+// blockMovement is a group of blocks that are moving one block towards a face.
+type blockMovement struct {
+	face cube.Face
+	// positions holds the positions that the blocks are moving to. The blocks
+	// at the same index in blocks are moved to these positions, while the
+	// block at the same index in moving represents the block while it is in
+	// motion.
+	positions      []cube.Pos
+	blocks, moving []Block
+	// progress is the distance, from 0-1, that the blocks have moved so far.
+	progress float64
+}
+
+// moveBlocks starts moving the blocks at the positions passed one block
+// towards the face passed. The blocks are replaced by the blocks returned by
+// the moving function until the movement is finished.
+func (w *World) moveBlocks(positions []cube.Pos, face cube.Face, moving func(b Block) Block) {
+	if len(positions) == 0 {
+		return
+	}
+	m := blockMovement{
+		face:      face,
+		positions: make([]cube.Pos, len(positions)),
+		blocks:    make([]Block, len(positions)),
+		moving:    make([]Block, len(positions)),
+	}
+	destinations := make(map[cube.Pos]struct{}, len(positions))
+	for i, pos := range positions {
+		m.positions[i], m.blocks[i] = pos.Side(face), w.block(pos)
+		m.moving[i] = moving(m.blocks[i])
+		destinations[m.positions[i]] = struct{}{}
+	}
+	opts := &SetOpts{DisableBlockUpdates: true, DisableLiquidDisplacement: true}
+	for _, pos := range positions {
+		if _, ok := destinations[pos]; !ok {
+			// Only clear positions that no other block is moving into.
+			w.setBlock(pos, nil, opts)
+			w.doBlockUpdatesAround(pos)
+		}
+	}
+	for i, pos := range m.positions {
+		w.setBlock(pos, m.moving[i], opts)
+	}
+	w.movingBlocks = append(w.movingBlocks, m)
+}
+
+// tickMovingBlocks progresses all blocks that are currently in motion. Blocks
+// that have finished moving are placed at their new positions.
+func (w *World) tickMovingBlocks(tx *Tx) {
+	if len(w.movingBlocks) == 0 {
+		return
+	}
+	movements := w.movingBlocks[:0]
+	for _, m := range w.movingBlocks {
+		m.progress = min(m.progress+movementStep, 1)
+		m.moveEntities(tx)
+		if m.progress < 1 {
+			movements = append(movements, m)
+			continue
+		}
+		m.finish(w, nil)
+	}
+	clear(w.movingBlocks[len(movements):])
+	w.movingBlocks = movements
+}
+
+// finishMovements immediately finishes the movement of all blocks that are
+// moving into the chunk at the position passed, so that no blocks in motion
+// are saved to the chunk or lost when it is unloaded. The SetOpts passed are
+// used to place the moved blocks.
+func (w *World) finishMovements(chunk ChunkPos, opts *SetOpts) {
+	if len(w.movingBlocks) == 0 {
+		return
+	}
+	movements := w.movingBlocks[:0]
+	for _, m := range w.movingBlocks {
+		if !slices.ContainsFunc(m.positions, func(pos cube.Pos) bool { return chunkPosFromBlockPos(pos) == chunk }) {
+			movements = append(movements, m)
+			continue
+		}
+		m.finish(w, opts)
+	}
+	clear(w.movingBlocks[len(movements):])
+	w.movingBlocks = movements
+}
+
+// finish replaces the blocks in motion of the blockMovement with the blocks
+// that were moved, using the SetOpts passed.
+func (m blockMovement) finish(w *World, opts *SetOpts) {
+	for i, pos := range m.positions {
+		if _, ok := w.chunks[chunkPosFromBlockPos(pos)]; !ok {
+			continue
+		}
+		// The block in motion might have been replaced in the meantime, in
+		// which case the moved block is lost.
+		if sameBlock(w.block(pos), m.moving[i]) {
+			w.setBlock(pos, m.blocks[i], opts)
+		}
+	}
+}
+
+// sameBlock checks if the blocks passed are equal, including any block entity
+// data they hold. Blocks in motion all have the same hash, so their data must
+// be compared to tell them apart.
+func sameBlock(a, b Block) bool {
+	if BlockHash(a) != BlockHash(b) {
+		return false
+	}
+	an, ok := a.(NBTer)
+	if !ok {
+		return true
+	}
+	bn, ok := b.(NBTer)
+	return ok && reflect.DeepEqual(an.EncodeNBT(), bn.EncodeNBT())
+}
+
+// moveEntities moves all entities that collide with the blocks of the
+// blockMovement at its current progress along with the blocks.
+func (m blockMovement) moveEntities(tx *Tx) {
+	dir := cube.Pos{}.Side(m.face).Vec3()
+	offset, delta := dir.Mul(m.progress-1), dir.Mul(movementStep)
+
+	moved := make(map[*EntityHandle]struct{})
+	for i, pos := range m.positions {
+		for _, box := range m.blocks[i].Model().BBox(pos, tx) {
+			box = box.Translate(pos.Vec3().Add(offset))
+			for e := range tx.EntitiesWithin(box.Grow(2)) {
+				if _, ok := moved[e.H()]; ok || !e.H().Type().BBox(e).Translate(e.Position()).IntersectsWith(box) {
+					continue
+				}
+				if mover, ok := e.(interface {
+					Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64)
+				}); ok {
+					mover.Move(delta, 0, 0)
+					moved[e.H()] = struct{}{}
+				}
+			}
+		}
+	}
+}
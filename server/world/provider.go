@@ -9,7 +9,8 @@ import (
 )
 
 // Provider represents a value that may provide world data to a World value. It usually does the reading and
-// writing of the world data so that the World may use it.
+// writing of the world data so that the World may use it. LoadColumn may be called from multiple goroutines at
+// the same time, so implementations of Provider must be safe for concurrent use.
 type Provider interface {
 	io.Closer
 	// Settings loads the settings for a World and returns them.
@@ -22,6 +23,10 @@ type Provider interface {
 	// SavePlayerSpawnPosition saves the player spawn point. In vanilla, this can be done with beds in the overworld
 	// or respawn anchors in the nether.
 	SavePlayerSpawnPosition(uuid uuid.UUID, pos cube.Pos) error
+	// RemovePlayerSpawnPosition removes the player spawn point, so that the
+	// player spawns at the spawn of the world again. Nothing happens if the
+	// player has no spawn point.
+	RemovePlayerSpawnPosition(uuid uuid.UUID) error
 	// LoadColumn reads a world.Column from the DB at a position and dimension
 	// in the DB. If no column at that position exists, errors.Is(err,
 	// leveldb.ErrNotFound) equals true.
@@ -29,6 +34,12 @@ type Provider interface {
 	// StoreColumn stores a world.Column at a position and dimension in the DB.
 	// An error is returned if storing was unsuccessful.
 	StoreColumn(pos ChunkPos, dim Dimension, col *chunk.Column) error
+	// LoadMap reads the data of the Map with the ID passed from the DB. If no
+	// map with the ID exists, errors.Is(err, leveldb.ErrNotFound) equals true.
+	LoadMap(id int64) (*MapData, error)
+	// StoreMap stores the data of the Map with the ID passed in the DB. An
+	// error is returned if storing was unsuccessful.
+	StoreMap(id int64, data *MapData) error
 }
 
 // Compile time check to make sure NopProvider implements Provider.
@@ -53,8 +64,11 @@ func (NopProvider) LoadColumn(ChunkPos, Dimension) (*chunk.Column, error) {
 	return nil, leveldb.ErrNotFound
 }
 func (NopProvider) StoreColumn(ChunkPos, Dimension, *chunk.Column) error { return nil }
+func (NopProvider) LoadMap(int64) (*MapData, error)                      { return nil, leveldb.ErrNotFound }
+func (NopProvider) StoreMap(int64, *MapData) error                       { return nil }
 func (NopProvider) LoadPlayerSpawnPosition(uuid.UUID) (cube.Pos, bool, error) {
 	return cube.Pos{}, false, nil
 }
 func (NopProvider) SavePlayerSpawnPosition(uuid.UUID, cube.Pos) error { return nil }
+func (NopProvider) RemovePlayerSpawnPosition(uuid.UUID) error         { return nil }
 func (NopProvider) Close() error                                      { return nil }
@@ -0,0 +1,101 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// Conductor represents a block that emits redstone power to the blocks around
+// it. Examples are redstone dust, redstone torches and levers.
+//
+// Power is emitted in one of two ways: Weak power only powers the block
+// directly adjacent to the Conductor, while strong power also passes through a
+// solid block that receives it, powering the blocks around that solid block.
+type Conductor interface {
+	Block
+	// RedstoneSource checks if the Conductor is a source of redstone power,
+	// which, for example, redstone dust will visually connect to.
+	RedstoneSource() bool
+	// WeakPower returns the level of power, from 0-15, that the Conductor at
+	// the position passed emits through its face passed, towards the block at
+	// pos.Side(face). If accountForDust is false, power that is provided by
+	// redstone dust should not be returned.
+	WeakPower(pos cube.Pos, face cube.Face, tx *Tx, accountForDust bool) int
+	// StrongPower returns the level of power, from 0-15, that the Conductor at
+	// the position passed strongly provides to the block at pos.Side(face). If
+	// accountForDust is false, power that is provided by redstone dust should
+	// not be returned.
+	StrongPower(pos cube.Pos, face cube.Face, tx *Tx, accountForDust bool) int
+}
+
+// RedstonePower returns the level of redstone power, from 0-15, that the block
+// at the position passed emits through its face passed, towards the block at
+// pos.Side(face). If the block is a Conductor, its weak power is returned. If
+// it is instead a block that conducts redstone power, such as stone, the
+// highest strong power it receives from any of the Conductors around it is
+// returned. If accountForDust is false, power provided by redstone dust is
+// ignored.
+func (tx *Tx) RedstonePower(pos cube.Pos, face cube.Face, accountForDust bool) int {
+	if pos.OutOfBounds(tx.Range()) {
+		return 0
+	}
+	b := tx.Block(pos)
+	if c, ok := b.(Conductor); ok {
+		return c.WeakPower(pos, face, tx, accountForDust)
+	}
+	if !conductsRedstone(b, pos, tx) {
+		return 0
+	}
+	power := 0
+	for _, f := range cube.Faces() {
+		side := pos.Side(f)
+		if side.OutOfBounds(tx.Range()) {
+			continue
+		}
+		if c, ok := tx.Block(side).(Conductor); ok {
+			power = max(power, c.StrongPower(side, f.Opposite(), tx, accountForDust))
+		}
+	}
+	return power
+}
+
+// ReceivedRedstonePower returns the highest level of redstone power, from
+// 0-15, that the block at the position passed receives from any of the blocks
+// directly around it.
+func (tx *Tx) ReceivedRedstonePower(pos cube.Pos) int {
+	power := 0
+	for _, f := range cube.Faces() {
+		power = max(power, tx.RedstonePower(pos.Side(f), f.Opposite(), true))
+		if power == 15 {
+			break
+		}
+	}
+	return power
+}
+
+// ConductsRedstone checks if the block at the position passed conducts
+// redstone power that it receives from a Conductor next to it to the blocks
+// around it. This is the case for blocks that are not a Conductor themselves
+// and that are solid on all faces without letting light pass through, such as
+// stone.
+func (tx *Tx) ConductsRedstone(pos cube.Pos) bool {
+	b := tx.Block(pos)
+	if _, ok := b.(Conductor); ok {
+		return false
+	}
+	return conductsRedstone(b, pos, tx)
+}
+
+// conductsRedstone checks if a block placed at a specific position conducts
+// redstone power.
+func conductsRedstone(b Block, pos cube.Pos, tx *Tx) bool {
+	if d, ok := b.(lightDiffuser); ok && d.LightDiffusionLevel() < 15 {
+		return false
+	}
+	m := b.Model()
+	for _, f := range cube.Faces() {
+		if !m.FaceSolid(pos, f, tx) {
+			return false
+		}
+	}
+	return true
+}
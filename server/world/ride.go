@@ -0,0 +1,218 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/internal/sliceutil"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"slices"
+)
+
+// Seat is a seat of a Rideable entity that another entity may ride in.
+type Seat struct {
+	// Position is the position of the seat relative to the position of the
+	// vehicle. The X and Z values are rotated along with the vehicle: A
+	// positive Z points in the direction of the yaw of the vehicle and a
+	// positive X points 90 degrees to the left of it. Boats and minecarts
+	// have a yaw 90 degrees to the right of the direction they face, so that
+	// their front is at a positive X.
+	Position mgl64.Vec3
+	// Driver specifies if the rider in the seat controls the movement of the
+	// vehicle. A player riding in a driver seat moves the vehicle itself, like
+	// a player sitting in the front of a boat.
+	Driver bool
+}
+
+// Rideable represents an Entity that other entities may ride, such as a boat
+// or a minecart. Entities are made to ride a Rideable using Tx.Mount.
+type Rideable interface {
+	Entity
+	// Seats returns the seats of the Rideable. The number of seats returned
+	// is the maximum number of riders, which sit in the seats in the order
+	// that they mounted the Rideable. Seats may return different positions
+	// depending on the number of riders, which may be obtained using
+	// EntityHandle.Riders.
+	Seats() []Seat
+}
+
+// mover is an Entity that may be moved, used to move riders along with the
+// entity that they are riding.
+type mover interface {
+	Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64)
+}
+
+// Vehicle returns the EntityHandle of the entity that the entity is riding.
+// False is returned if the entity is not riding another entity. Vehicle must
+// only be called in a transaction of the World of the entity.
+func (e *EntityHandle) Vehicle() (*EntityHandle, bool) {
+	return e.vehicle, e.vehicle != nil
+}
+
+// Riders returns the EntityHandles of the entities riding the entity, in the
+// order of the seats that they are riding in. Riders must only be called in a
+// transaction of the World of the entity.
+func (e *EntityHandle) Riders() []*EntityHandle {
+	return slices.Clone(e.riders)
+}
+
+// Seat returns the Seat of the entity that the entity is riding in, along
+// with the index of the Seat. False is returned if the entity is not riding
+// another entity. Seat must only be called in a transaction of the World of
+// the entity.
+func (e *EntityHandle) Seat() (Seat, int, bool) {
+	if e.vehicle == nil {
+		return Seat{}, -1, false
+	}
+	return e.seat, slices.Index(e.vehicle.riders, e), true
+}
+
+// Mount makes the Entity passed ride the Rideable vehicle passed, taking the
+// first free seat of the vehicle. False is returned if the vehicle has no free
+// seats, if the rider is already riding an entity or if the rider is the
+// vehicle itself or one of the entities riding it. Both entities must be in
+// the World of the Tx.
+func (tx *Tx) Mount(rider Entity, vehicle Rideable) bool {
+	r, v := rider.H(), vehicle.H()
+	if r.w != tx.World() || v.w != tx.World() || r.vehicle != nil || len(v.riders) >= len(vehicle.Seats()) {
+		return false
+	}
+	for h := v; h != nil; h = h.vehicle {
+		if h == r {
+			// The rider is the vehicle or is (indirectly) ridden by the
+			// vehicle. Riding it would result in a loop.
+			return false
+		}
+	}
+	v.riders, r.vehicle = append(v.riders, r), v
+	tx.World().arrangeRiders(tx, vehicle)
+	for _, viewer := range tx.Viewers(vehicle.Position()) {
+		viewer.ViewEntityMount(rider, vehicle)
+	}
+	return true
+}
+
+// Dismount makes the Entity passed stop riding the entity it is currently
+// riding. Dismount does nothing if the Entity is not riding an entity. The
+// Entity is placed on top of the entity it was riding.
+func (tx *Tx) Dismount(rider Entity) {
+	v := rider.H().vehicle
+	if v == nil {
+		return
+	}
+	tx.World().dismount(tx, rider)
+	if vehicle, ok := v.Entity(tx); ok {
+		if rideable, ok := vehicle.(Rideable); ok {
+			tx.World().arrangeRiders(tx, rideable)
+		}
+	}
+}
+
+// dismount makes the Entity passed stop riding the entity it is currently
+// riding, without rearranging the remaining riders of the entity.
+func (w *World) dismount(tx *Tx, rider Entity) {
+	r := rider.H()
+	v := r.vehicle
+	v.riders, r.vehicle, r.seat = sliceutil.DeleteVal(v.riders, r), nil, Seat{}
+
+	vehicle, ok := v.Entity(tx)
+	if !ok {
+		// The vehicle is in a different world, which should never happen
+		// because entities are dismounted when they are removed from a world.
+		return
+	}
+	for _, viewer := range tx.Viewers(vehicle.Position()) {
+		viewer.ViewEntityDismount(rider, vehicle)
+	}
+	if m, ok := rider.(mover); ok {
+		top := vehicle.Position().Add(mgl64.Vec3{0, v.t.BBox(vehicle).Height()})
+		m.Move(top.Sub(rider.Position()), 0, 0)
+	}
+}
+
+// arrangeRiders updates the seats of the riders of the Rideable passed and
+// moves them to their seats. Viewers are updated if the seat of a rider
+// changed. Riders without a seat, which may be the case if the Rideable has
+// fewer seats than before, are dismounted.
+func (w *World) arrangeRiders(tx *Tx, vehicle Rideable) {
+	seats := vehicle.Seats()
+	for h := vehicle.H(); len(h.riders) > len(seats); {
+		r := h.riders[len(h.riders)-1]
+		if rider, ok := r.Entity(tx); ok {
+			w.dismount(tx, rider)
+		} else {
+			h.riders, r.vehicle, r.seat = h.riders[:len(h.riders)-1], nil, Seat{}
+		}
+	}
+	for i, r := range vehicle.H().riders {
+		if r.seat == seats[i] {
+			continue
+		}
+		r.seat = seats[i]
+		if rider, ok := r.Entity(tx); ok {
+			for _, viewer := range tx.Viewers(rider.Position()) {
+				viewer.ViewEntityState(rider)
+			}
+		}
+	}
+	w.moveRiders(tx, vehicle)
+}
+
+// moveRiders moves the riders of the vehicle passed to the positions of their
+// seats.
+func (w *World) moveRiders(tx *Tx, vehicle Entity) {
+	pos := vehicle.Position()
+	yaw := mgl64.DegToRad(vehicle.Rotation().Yaw())
+	sin, cos := math.Sin(yaw), math.Cos(yaw)
+	for _, r := range vehicle.H().riders {
+		rider, ok := r.Entity(tx)
+		if !ok {
+			continue
+		}
+		seat := r.seat.Position
+		target := pos.Add(mgl64.Vec3{seat[0]*cos - seat[2]*sin, seat[1], seat[0]*sin + seat[2]*cos})
+		if m, ok := rider.(mover); ok && !target.ApproxEqual(rider.Position()) {
+			m.Move(target.Sub(rider.Position()), 0, 0)
+		}
+	}
+}
+
+// tickRiders moves all riding entities in the World to the seats of the
+// entities they are riding.
+func (w *World) tickRiders(tx *Tx) {
+	for handle := range w.entities {
+		if len(handle.riders) == 0 || handle.vehicle != nil {
+			// Entities riding another entity are moved along with the
+			// entity at the bottom.
+			continue
+		}
+		w.moveRidersRecursive(tx, handle)
+	}
+}
+
+// moveRidersRecursive moves the riders of the entity passed, after which the
+// riders of those riders are moved.
+func (w *World) moveRidersRecursive(tx *Tx, handle *EntityHandle) {
+	vehicle, ok := handle.Entity(tx)
+	if !ok {
+		return
+	}
+	w.moveRiders(tx, vehicle)
+	for _, r := range handle.riders {
+		if len(r.riders) > 0 {
+			w.moveRidersRecursive(tx, r)
+		}
+	}
+}
+
+// unlinkEntity dismounts the Entity passed from the entity it is riding and
+// dismounts all entities riding it.
+func (w *World) unlinkEntity(tx *Tx, e Entity) {
+	tx.Dismount(e)
+	for _, r := range e.H().Riders() {
+		if rider, ok := r.Entity(tx); ok {
+			w.dismount(tx, rider)
+		} else {
+			r.vehicle, r.seat = nil, Seat{}
+		}
+	}
+	e.H().riders = nil
+}
@@ -41,6 +41,9 @@ type Settings struct {
 	// TickRange is the radius in chunks around a Viewer that has its blocks and entities ticked when the world is
 	// ticked. If set to 0, blocks and entities will never be ticked.
 	TickRange int32
+	// PlayersSleepingPercentage is the percentage of players in the World that must be sleeping in a bed for the night
+	// to be skipped. If set to 0, a single sleeping player is sufficient.
+	PlayersSleepingPercentage int32
 }
 
 // defaultSettings returns the default Settings for a new World.
@@ -52,5 +55,31 @@ func defaultSettings() *Settings {
 		TimeCycle:       true,
 		WeatherCycle:    true,
 		TickRange:       6,
+
+		PlayersSleepingPercentage: 100,
+	}
+}
+
+// Clone returns a copy of the Settings that is not used by any World yet. It
+// may be used to create multiple worlds starting with the same Settings, each
+// of which then changes its own copy.
+func (s *Settings) Clone() *Settings {
+	s.Lock()
+	defer s.Unlock()
+	return &Settings{
+		Name:                      s.Name,
+		Spawn:                     s.Spawn,
+		Time:                      s.Time,
+		TimeCycle:                 s.TimeCycle,
+		RainTime:                  s.RainTime,
+		Raining:                   s.Raining,
+		ThunderTime:               s.ThunderTime,
+		Thundering:                s.Thundering,
+		WeatherCycle:              s.WeatherCycle,
+		CurrentTick:               s.CurrentTick,
+		DefaultGameMode:           s.DefaultGameMode,
+		Difficulty:                s.Difficulty,
+		TickRange:                 s.TickRange,
+		PlayersSleepingPercentage: s.PlayersSleepingPercentage,
 	}
 }
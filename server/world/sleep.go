@@ -0,0 +1,68 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"math"
+)
+
+// Sleeper represents an entity that can sleep in a bed, such as a player.
+// Sleepers in a World count towards the players required to skip the night.
+type Sleeper interface {
+	Entity
+	// Sleep makes the Sleeper sleep in the bed at the position passed.
+	Sleep(pos cube.Pos)
+	// Wake wakes the Sleeper up if it is currently sleeping.
+	Wake()
+	// Sleeping returns the position of the bed that the Sleeper is sleeping
+	// in and true if it is currently sleeping.
+	Sleeping() (cube.Pos, bool)
+}
+
+// sleepDuration is the amount of ticks that enough players must be sleeping
+// for before the night is skipped.
+const sleepDuration = 100
+
+// tickSleeping skips the night and clears the weather once enough players in
+// the World have been sleeping for sleepDuration ticks. Sleeping players are
+// woken up once the night is skipped.
+func (w *World) tickSleeping(tx *Tx) {
+	var sleepers []Sleeper
+	total := 0
+	for e := range tx.Players() {
+		if g, ok := e.(interface{ GameMode() GameMode }); ok && !g.GameMode().Visible() && !g.GameMode().HasCollision() {
+			// Spectators never sleep and are not required to.
+			continue
+		}
+		total++
+		if s, ok := e.(Sleeper); ok {
+			if _, sleeping := s.Sleeping(); sleeping {
+				sleepers = append(sleepers, s)
+			}
+		}
+	}
+	if len(sleepers) == 0 || len(sleepers) < w.requiredSleepers(total) {
+		w.sleepTicks = 0
+		return
+	}
+	if w.sleepTicks++; w.sleepTicks < sleepDuration {
+		return
+	}
+	w.sleepTicks = 0
+
+	if w.Dimension().TimeCycle() {
+		t := w.Time()
+		w.SetTime(t + 24000 - t%24000)
+	}
+	if w.Dimension().WeatherCycle() {
+		w.StopRaining()
+	}
+	for _, s := range sleepers {
+		s.Wake()
+	}
+}
+
+// requiredSleepers returns the amount of players out of total that must be
+// sleeping for the night to be skipped.
+func (w *World) requiredSleepers(total int) int {
+	return max(1, int(math.Ceil(float64(total)*float64(w.PlayersSleepingPercentage())/100)))
+}
@@ -120,6 +120,24 @@ type DoorCrash struct{ sound }
 // Click is a clicking sound.
 type Click struct{ sound }
 
+// PowerOn is a sound played when a redstone component, such as a lever or a button, is switched on.
+type PowerOn struct{ sound }
+
+// PowerOff is a sound played when a redstone component, such as a lever or a button, is switched off.
+type PowerOff struct{ sound }
+
+// PistonExtend is a sound played when a piston extends.
+type PistonExtend struct{ sound }
+
+// PistonRetract is a sound played when a piston retracts.
+type PistonRetract struct{ sound }
+
+// EnderEyePlace is a sound played when an eye of ender is placed in an end portal frame.
+type EnderEyePlace struct{ sound }
+
+// EndPortalOpen is a sound played when an end portal is activated.
+type EndPortalOpen struct{ sound }
+
 // Ignite is a sound played when using a flint & steel.
 type Ignite struct{ sound }
 
@@ -0,0 +1,375 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// SpawnCategory is a category of entities that spawn naturally in a World.
+// Every category has its own conditions under which entities spawn and its own
+// maximum amount of entities that may exist at once.
+type SpawnCategory uint8
+
+const (
+	// SpawnCategoryMonster is the category of hostile mobs, such as zombies.
+	// Monsters spawn in the dark and do not spawn if the difficulty of the
+	// World is DifficultyPeaceful.
+	SpawnCategoryMonster SpawnCategory = iota
+	// SpawnCategoryCreature is the category of passive animals, such as cows.
+	// Creatures spawn on light, solid ground.
+	SpawnCategoryCreature
+	// SpawnCategoryAmbient is the category of ambient mobs, such as bats.
+	// Ambient mobs spawn in the dark.
+	SpawnCategoryAmbient
+	// SpawnCategoryWaterCreature is the category of mobs that live in water,
+	// such as squids.
+	SpawnCategoryWaterCreature
+	spawnCategoryCount
+)
+
+// Hostile checks if entities of the SpawnCategory are hostile, meaning they
+// do not spawn in worlds with the peaceful difficulty.
+func (c SpawnCategory) Hostile() bool {
+	return c == SpawnCategoryMonster
+}
+
+// despawns checks if entities of the SpawnCategory that spawned naturally
+// despawn when no player is near them.
+func (c SpawnCategory) despawns() bool {
+	return c == SpawnCategoryMonster || c == SpawnCategoryAmbient
+}
+
+// cap returns the maximum amount of entities of the SpawnCategory that may
+// exist for every 289 chunks (an area of 17x17 chunks) around viewers.
+func (c SpawnCategory) cap() int {
+	switch c {
+	case SpawnCategoryMonster:
+		return 70
+	case SpawnCategoryCreature:
+		return 10
+	case SpawnCategoryAmbient:
+		return 15
+	default:
+		return 5
+	}
+}
+
+// interval returns the interval in ticks at which entities of the
+// SpawnCategory attempt to spawn.
+func (c SpawnCategory) interval() int64 {
+	if c == SpawnCategoryCreature {
+		return 400
+	}
+	return 1
+}
+
+// SpawnEntry is an entry in the spawn list of a Biome. It describes an entity
+// that may spawn naturally in the Biome.
+type SpawnEntry struct {
+	// Type is the EntityType of the entity spawned. Entities of this type
+	// count towards the maximum amount of entities in the Category.
+	Type EntityType
+	// Category is the SpawnCategory of the entity. It decides the conditions
+	// under which the entity spawns.
+	Category SpawnCategory
+	// Weight is the chance of the entry being selected relative to the other
+	// entries of the same Category in the Biome.
+	Weight int
+	// MinGroup and MaxGroup are the minimum and maximum amount of entities
+	// spawned at once in a group.
+	MinGroup, MaxGroup int
+	// Condition is an additional condition that must be met for the entity to
+	// spawn at a position, for example requiring a specific block to be
+	// below it. Condition may be nil.
+	Condition func(tx *Tx, pos cube.Pos) bool
+	// New creates the entity that is spawned.
+	New func(opts EntitySpawnOpts) *EntityHandle
+}
+
+const (
+	// spawnMinPlayerDistance is the minimum distance to players that entities
+	// spawn at.
+	spawnMinPlayerDistance = 24
+	// despawnDistance is the distance to the nearest player at which hostile
+	// and ambient entities that spawned naturally are despawned immediately.
+	despawnDistance = 128
+	// despawnRandomDistance is the distance to the nearest player from which
+	// entities that spawn naturally have a chance to despawn every tick.
+	despawnRandomDistance = 32
+)
+
+// tickSpawning spawns entities naturally around the loaders passed and
+// despawns entities that spawn naturally if they are too far away from any
+// player.
+func (t ticker) tickSpawning(tx *Tx, loaders []*Loader, tick int64) {
+	w := tx.World()
+	r := int32(w.tickRange())
+	if w.conf.Entities.conf.Spawns == nil || !w.conf.MobSpawning || r == 0 {
+		return
+	}
+	var players []mgl64.Vec3
+	for p := range tx.Players() {
+		players = append(players, p.Position())
+	}
+	if len(players) == 0 {
+		return
+	}
+	categories := w.spawnCategories()
+	counts := t.despawnEntities(tx, categories, players)
+
+	loaded := make([]ChunkPos, 0, len(loaders))
+	for _, loader := range loaders {
+		loader.mu.RLock()
+		loaded = append(loaded, loader.pos)
+		loader.mu.RUnlock()
+	}
+	chunks := make([]ChunkPos, 0, len(w.chunks))
+	for pos := range w.chunks {
+		if t.anyWithinDistance(pos, loaded, r) {
+			chunks = append(chunks, pos)
+		}
+	}
+
+	w.set.Lock()
+	darkening := skyDarkening(w.set.Time, w.set.Raining, w.set.Thundering && w.set.Raining)
+	w.set.Unlock()
+	if !w.Dimension().TimeCycle() {
+		darkening = 0
+	}
+	peaceful := w.Difficulty() == DifficultyPeaceful
+
+	for c := range spawnCategoryCount {
+		if tick%c.interval() != 0 || (c.Hostile() && peaceful) {
+			continue
+		}
+		limit := c.cap() * len(chunks) / 289
+		for _, pos := range chunks {
+			if counts[c] >= limit {
+				break
+			}
+			counts[c] += t.spawnGroup(tx, pos, c, players, darkening)
+		}
+	}
+}
+
+// despawnEntities despawns entities that spawned naturally in a hostile or
+// ambient SpawnCategory if they are too far away from players. Entities that
+// did not spawn naturally, such as those spawned using spawn eggs, and
+// entities with a name tag never despawn. The amount of entities left in every
+// SpawnCategory is returned.
+func (t ticker) despawnEntities(tx *Tx, categories map[EntityType]SpawnCategory, players []mgl64.Vec3) (counts [spawnCategoryCount]int) {
+	w := tx.World()
+	var despawned []Entity
+	for handle := range w.entities {
+		c, ok := categories[handle.Type()]
+		if !ok {
+			continue
+		}
+		if handle.data.NaturallySpawned && handle.data.Name == "" && c.despawns() {
+			dist := nearestDistance(handle.data.Pos, players)
+			if dist > despawnDistance || (dist > despawnRandomDistance && w.r.IntN(800) == 0) {
+				despawned = append(despawned, handle.mustEntity(tx))
+				continue
+			}
+		}
+		counts[c]++
+	}
+	for _, e := range despawned {
+		_ = e.Close()
+	}
+	return counts
+}
+
+// spawnGroup attempts to spawn groups of entities of a SpawnCategory at a
+// random position in the chunk passed. The amount of entities spawned is
+// returned.
+func (t ticker) spawnGroup(tx *Tx, chunk ChunkPos, c SpawnCategory, players []mgl64.Vec3, darkening uint8) int {
+	w := tx.World()
+	x, z := int(chunk[0]<<4)+w.r.IntN(16), int(chunk[1]<<4)+w.r.IntN(16)
+	highest := tx.HighestBlock(x, z) + 1
+	if highest <= tx.Range()[0] {
+		return 0
+	}
+	start := cube.Pos{x, tx.Range()[0] + w.r.IntN(highest-tx.Range()[0]+1), z}
+	if len(tx.Block(start).Model().BBox(start, tx)) != 0 {
+		return 0
+	}
+
+	spawned := 0
+	for range 3 {
+		pos := start
+		var (
+			entry SpawnEntry
+			size  int
+		)
+		for range 4 {
+			pos = pos.Add(cube.Pos{w.r.IntN(6) - w.r.IntN(6), 0, w.r.IntN(6) - w.r.IntN(6)})
+			if pos.OutOfBounds(tx.Range()) {
+				continue
+			}
+			if _, ok := w.chunks[chunkPosFromBlockPos(pos)]; !ok {
+				// The group may wander into neighbouring chunks. Never spawn
+				// in chunks that are not loaded, so that they are not loaded
+				// or generated on the tick goroutine.
+				continue
+			}
+			vec := mgl64.Vec3{float64(pos[0]) + 0.5, float64(pos[1]), float64(pos[2]) + 0.5}
+			if dist := nearestDistance(vec, players); dist < spawnMinPlayerDistance || dist > despawnDistance {
+				continue
+			}
+			if size == 0 {
+				var ok bool
+				if entry, ok = w.randomSpawnEntry(tx.Biome(pos), c); !ok {
+					return spawned
+				}
+				size = entry.MinGroup + w.r.IntN(max(entry.MaxGroup-entry.MinGroup, 0)+1)
+			}
+			if !w.canSpawnAt(tx, pos, entry, darkening) {
+				continue
+			}
+			original := entry.New(EntitySpawnOpts{Position: vec, Rotation: cube.Rotation{w.r.Float64() * 360}})
+			handle, ctx := original, event.C(tx)
+			w.Handler().HandleMobSpawn(ctx, vec, &handle)
+			if handle != original {
+				// The handler replaced the entity, so the original will never
+				// be added to the World.
+				_ = original.Close()
+			}
+			if ctx.Cancelled() || handle == nil {
+				if handle != nil {
+					_ = handle.Close()
+				}
+				continue
+			}
+			handle.data.NaturallySpawned = true
+			tx.AddEntity(handle)
+			if spawned++; spawned >= size {
+				break
+			}
+		}
+	}
+	return spawned
+}
+
+// randomSpawnEntry selects a random SpawnEntry of a SpawnCategory from the
+// spawn list of the Biome passed, taking into account the weights of the
+// entries. False is returned if the Biome has no entries of the category.
+func (w *World) randomSpawnEntry(b Biome, c SpawnCategory) (SpawnEntry, bool) {
+	entries := w.conf.Entities.conf.Spawns(b)
+	total := 0
+	for _, e := range entries {
+		if e.Category == c {
+			total += max(e.Weight, 0)
+		}
+	}
+	if total == 0 {
+		return SpawnEntry{}, false
+	}
+	n := w.r.IntN(total)
+	for _, e := range entries {
+		if e.Category != c || e.Weight <= 0 {
+			continue
+		}
+		if n -= e.Weight; n < 0 {
+			return e, true
+		}
+	}
+	return SpawnEntry{}, false
+}
+
+// canSpawnAt checks if the entity of the SpawnEntry passed can spawn at a
+// position, based on the blocks around it and the light level.
+func (w *World) canSpawnAt(tx *Tx, pos cube.Pos, e SpawnEntry, darkening uint8) bool {
+	above, below := pos.Side(cube.FaceUp), pos.Side(cube.FaceDown)
+	if below.OutOfBounds(tx.Range()) || above.OutOfBounds(tx.Range()) {
+		return false
+	}
+	if e.Category == SpawnCategoryWaterCreature {
+		return w.water(tx, pos) && w.water(tx, above) && (e.Condition == nil || e.Condition(tx, pos))
+	}
+	for _, p := range [...]cube.Pos{pos, above} {
+		if _, ok := tx.Liquid(p); ok || len(tx.Block(p).Model().BBox(p, tx)) != 0 {
+			return false
+		}
+	}
+	if !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+		return false
+	}
+	switch e.Category {
+	case SpawnCategoryMonster, SpawnCategoryAmbient:
+		if sky := tx.SkyLight(pos); sky > uint8(w.r.IntN(32)) {
+			return false
+		}
+		if w.blockLight(pos) > 0 {
+			return false
+		}
+		if light := max(int(tx.SkyLight(pos))-int(darkening), 0); light > w.r.IntN(8) {
+			return false
+		}
+	case SpawnCategoryCreature:
+		if tx.Light(pos) <= 8 {
+			return false
+		}
+	}
+	return e.Condition == nil || e.Condition(tx, pos)
+}
+
+// water checks if the block at the position passed is water.
+func (w *World) water(tx *Tx, pos cube.Pos) bool {
+	l, ok := tx.Liquid(pos)
+	return ok && l.LiquidType() == "water"
+}
+
+// blockLight returns the light level emitted by blocks at a position, ignoring
+// skylight.
+func (w *World) blockLight(pos cube.Pos) uint8 {
+	if pos.OutOfBounds(w.ra) {
+		return 0
+	}
+	return w.chunk(chunkPosFromBlockPos(pos)).SubChunk(int16(pos[1])).BlockLight(uint8(pos[0]&0xf), uint8(pos[1]&0xf), uint8(pos[2]&0xf))
+}
+
+// spawnCategories returns the SpawnCategory of all EntityTypes that may spawn
+// naturally in any registered Biome.
+func (w *World) spawnCategories() map[EntityType]SpawnCategory {
+	if w.categories == nil {
+		w.categories = make(map[EntityType]SpawnCategory)
+		for _, b := range Biomes() {
+			for _, e := range w.conf.Entities.conf.Spawns(b) {
+				w.categories[e.Type] = e.Category
+			}
+		}
+	}
+	return w.categories
+}
+
+// nearestDistance returns the distance from pos to the nearest of the
+// positions passed.
+func nearestDistance(pos mgl64.Vec3, positions []mgl64.Vec3) float64 {
+	nearest := math.MaxFloat64
+	for _, p := range positions {
+		nearest = math.Min(nearest, p.Sub(pos).Len())
+	}
+	return nearest
+}
+
+// skyDarkening returns the amount by which the skylight is reduced at a
+// specific time of the day and depending on the weather.
+func skyDarkening(time int64, rain, thunder bool) uint8 {
+	d := float64(time%24000)/24000 - 0.25
+	if d < 0 {
+		d++
+	}
+	angle := d + (1-(math.Cos(d*math.Pi)+1)/2-d)/3
+
+	f := 1 - max(min(1-(math.Cos(angle*math.Pi*2)*2+0.5), 1), 0)
+	if rain {
+		f *= 1 - 5.0/16
+	}
+	if thunder {
+		f *= 1 - 5.0/16
+	}
+	return uint8((1 - f) * 11)
+}
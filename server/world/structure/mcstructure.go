@@ -0,0 +1,171 @@
+package structure
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// mcstructure is the NBT layout of a .mcstructure file, as written by
+// structure blocks in Bedrock Edition.
+type mcstructure struct {
+	FormatVersion int32   `nbt:"format_version"`
+	Size          []int32 `nbt:"size"`
+	Structure     struct {
+		// BlockIndices holds two layers of indices in the block palette, the
+		// second of which holds liquids such as the water of waterlogged
+		// blocks. An index of -1 means that no block is present.
+		BlockIndices [][]int32        `nbt:"block_indices"`
+		Entities     []map[string]any `nbt:"entities"`
+		Palette      struct {
+			Default struct {
+				BlockPalette      []map[string]any          `nbt:"block_palette"`
+				BlockPositionData map[string]map[string]any `nbt:"block_position_data"`
+			} `nbt:"default"`
+		} `nbt:"palette"`
+	} `nbt:"structure"`
+	Origin []int32 `nbt:"structure_world_origin"`
+}
+
+// ReadMCStructure reads a Bedrock Edition .mcstructure file from the
+// io.Reader passed. Blocks with NBT data, such as chests and signs, are read
+// along with their data. Entities stored in the file are not read. An error is
+// returned if the data could not be decoded or if the file holds a block that
+// does not exist.
+func ReadMCStructure(r io.Reader) (*Structure, error) {
+	// The NBT decoder does not handle short reads, so we first read all data.
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read mcstructure: %w", err)
+	}
+	var m mcstructure
+	if err := nbt.UnmarshalEncoding(data, &m, nbt.LittleEndian); err != nil {
+		return nil, fmt.Errorf("read mcstructure: decode nbt: %w", err)
+	}
+	if len(m.Size) != 3 {
+		return nil, fmt.Errorf("read mcstructure: invalid size %v", m.Size)
+	}
+	size := [3]int{int(m.Size[0]), int(m.Size[1]), int(m.Size[2])}
+	n, err := volume(size)
+	if err != nil {
+		return nil, fmt.Errorf("read mcstructure: %w", err)
+	}
+	var blocks, liquids []int32
+	if layers := m.Structure.BlockIndices; len(layers) > 0 {
+		blocks = layers[0]
+		if len(layers) > 1 {
+			liquids = layers[1]
+		}
+	}
+	if len(blocks) != n || (len(liquids) != 0 && len(liquids) != n) {
+		return nil, fmt.Errorf("read mcstructure: block indices do not match size %v", m.Size)
+	}
+	s := New(size)
+	if len(m.Origin) == 3 {
+		s.origin = cube.Pos{int(m.Origin[0]), int(m.Origin[1]), int(m.Origin[2])}
+	}
+
+	p := m.Structure.Palette.Default
+	palette := make([]world.Block, len(p.BlockPalette))
+	for i, entry := range p.BlockPalette {
+		rid, err := chunk.BlockPaletteEncoding.DecodeBlockState(entry)
+		if err != nil {
+			return nil, fmt.Errorf("read mcstructure: palette: %w", err)
+		}
+		palette[i], _ = world.BlockByRuntimeID(rid)
+	}
+	paletteBlock := func(layer []int32, i int) (world.Block, error) {
+		if i >= len(layer) || layer[i] == -1 {
+			return nil, nil
+		}
+		if layer[i] < 0 || int(layer[i]) >= len(palette) {
+			return nil, fmt.Errorf("read mcstructure: palette index %v out of range", layer[i])
+		}
+		return palette[layer[i]], nil
+	}
+
+	for i := range s.blocks {
+		b, err := paletteBlock(blocks, i)
+		if err != nil {
+			return nil, err
+		}
+		l, err := paletteBlock(liquids, i)
+		if err != nil {
+			return nil, err
+		}
+		if nb, ok := b.(world.NBTer); ok {
+			data, _ := p.BlockPositionData[strconv.Itoa(i)]["block_entity_data"].(map[string]any)
+			if data == nil {
+				data = map[string]any{}
+			}
+			b = nb.DecodeNBT(data).(world.Block)
+		}
+		liq, _ := l.(world.Liquid)
+		x, y, z := s.pos(i)
+		s.Set(x, y, z, b, liq)
+	}
+	return s, nil
+}
+
+// ReadMCStructureFile reads a Bedrock Edition .mcstructure file at the path
+// passed. See ReadMCStructure for more information.
+func ReadMCStructureFile(path string) (*Structure, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read mcstructure: %w", err)
+	}
+	defer f.Close()
+	return ReadMCStructure(f)
+}
+
+// WriteMCStructure writes the Structure to the io.Writer passed in the format
+// of Bedrock Edition .mcstructure files, so that it may be loaded using
+// structure blocks. Positions in the Structure that were left empty are
+// written as structure void. The origin of the Structure is written as the
+// world origin of the structure.
+func (s *Structure) WriteMCStructure(w io.Writer) error {
+	var m mcstructure
+	m.FormatVersion = 1
+	m.Size = []int32{int32(s.size[0]), int32(s.size[1]), int32(s.size[2])}
+	m.Origin = []int32{int32(s.origin[0]), int32(s.origin[1]), int32(s.origin[2])}
+	m.Structure.BlockIndices = [][]int32{s.blocks, s.liquids}
+	m.Structure.Entities = []map[string]any{}
+
+	p := &m.Structure.Palette.Default
+	p.BlockPalette = make([]map[string]any, len(s.palette))
+	for i, b := range s.palette {
+		e := chunk.BlockPaletteEncoding.EncodeBlockState(world.BlockRuntimeID(b))
+		p.BlockPalette[i] = map[string]any{"name": e.Name, "states": e.State, "version": e.Version}
+	}
+	p.BlockPositionData = make(map[string]map[string]any, len(s.nbt))
+	for i, b := range s.nbt {
+		data := b.(world.NBTer).EncodeNBT()
+		x, y, z := s.pos(i)
+		data["x"], data["y"], data["z"] = int32(s.origin[0]+x), int32(s.origin[1]+y), int32(s.origin[2]+z)
+		p.BlockPositionData[strconv.Itoa(i)] = map[string]any{"block_entity_data": data}
+	}
+	if err := nbt.NewEncoderWithEncoding(w, nbt.LittleEndian).Encode(m); err != nil {
+		return fmt.Errorf("write mcstructure: %w", err)
+	}
+	return nil
+}
+
+// WriteMCStructureFile writes the Structure to a .mcstructure file at the path
+// passed, creating or truncating it. See Structure.WriteMCStructure for more
+// information.
+func (s *Structure) WriteMCStructureFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("write mcstructure: %w", err)
+	}
+	if err := s.WriteMCStructure(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,196 @@
+package structure
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"io"
+	"maps"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ReadSchematic reads a Sponge schematic (.schem) file, as written by
+// WorldEdit, from the io.Reader passed. Versions 1, 2 and 3 of the format are
+// supported.
+//
+// Sponge schematics hold Java Edition block states, which are converted to
+// Bedrock Edition block states on a best-effort basis: Blocks are looked up by
+// the same name, with properties that exist in Bedrock Edition under the same
+// name (or pillar_axis for axis) carried over. If a state is not found, the
+// default state of the block is used instead, and blocks that do not exist in
+// Bedrock Edition at all are replaced with air. Waterlogged blocks have water
+// placed in the same position. Block entities and entities stored in the file
+// are not read.
+func ReadSchematic(r io.Reader) (*Structure, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("read schematic: %w", err)
+	}
+	// The NBT decoder does not handle short reads, so we first read all data.
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("read schematic: %w", err)
+	}
+	var m map[string]any
+	if err := nbt.UnmarshalEncoding(data, &m, nbt.BigEndian); err != nil {
+		return nil, fmt.Errorf("read schematic: decode nbt: %w", err)
+	}
+
+	palette, _ := m["Palette"].(map[string]any)
+	indices := byteArray(m["BlockData"])
+	if v3, ok := m["Schematic"].(map[string]any); ok {
+		// Version 3 nests all data in a Schematic compound and moves the
+		// palette and block data into a Blocks compound.
+		m = v3
+		blocks, _ := m["Blocks"].(map[string]any)
+		palette, _ = blocks["Palette"].(map[string]any)
+		indices = byteArray(blocks["Data"])
+	}
+	width, _ := m["Width"].(int16)
+	height, _ := m["Height"].(int16)
+	length, _ := m["Length"].(int16)
+
+	// Dimensions are unsigned shorts, which the NBT format does not have.
+	size := [3]int{int(uint16(width)), int(uint16(height)), int(uint16(length))}
+	n, err := volume(size)
+	if err != nil {
+		return nil, fmt.Errorf("read schematic: %w", err)
+	}
+	// Every block is encoded as a varint of at least one byte.
+	if len(indices) < n {
+		return nil, fmt.Errorf("read schematic: block data too short for size %v", size)
+	}
+	s := New(size)
+	if offset, ok := m["Offset"].([3]int32); ok {
+		s.origin = cube.Pos{int(offset[0]), int(offset[1]), int(offset[2])}
+	}
+
+	type state struct {
+		b   world.Block
+		liq world.Liquid
+	}
+	states := make(map[int32]state, len(palette))
+	for key, v := range palette {
+		i, ok := v.(int32)
+		if !ok {
+			return nil, fmt.Errorf("read schematic: invalid palette index for %v", key)
+		}
+		b, liq := javaBlockState(key)
+		states[i] = state{b: b, liq: liq}
+	}
+
+	buf := bytes.NewReader(indices)
+	for y := range s.size[1] {
+		for z := range s.size[2] {
+			for x := range s.size[0] {
+				v, err := binary.ReadUvarint(buf)
+				if err != nil {
+					return nil, fmt.Errorf("read schematic: block data: %w", err)
+				}
+				st, ok := states[int32(v)]
+				if !ok {
+					return nil, fmt.Errorf("read schematic: palette index %v out of range", v)
+				}
+				s.Set(x, y, z, st.b, st.liq)
+			}
+		}
+	}
+	return s, nil
+}
+
+// ReadSchematicFile reads a Sponge schematic (.schem) file at the path passed.
+// See ReadSchematic for more information.
+func ReadSchematicFile(path string) (*Structure, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read schematic: %w", err)
+	}
+	defer f.Close()
+	return ReadSchematic(f)
+}
+
+// javaBlockState converts a Java Edition block state, such as
+// "minecraft:oak_log[axis=y]", to a Bedrock Edition block and the liquid in
+// the same position, if the block is waterlogged.
+func javaBlockState(key string) (world.Block, world.Liquid) {
+	name, props, _ := strings.Cut(key, "[")
+	if !strings.Contains(name, ":") {
+		name = "minecraft:" + name
+	}
+	defaults, ok := world.BlockProperties(name)
+	if !ok {
+		return block.Air{}, nil
+	}
+
+	var liq world.Liquid
+	properties := maps.Clone(defaults)
+	for _, prop := range strings.Split(strings.TrimSuffix(props, "]"), ",") {
+		k, v, ok := strings.Cut(prop, "=")
+		if !ok {
+			continue
+		}
+		if k == "waterlogged" {
+			if v == "true" {
+				liq = block.Water{Still: true, Depth: 8}
+			}
+			continue
+		}
+		if k == "axis" {
+			if _, ok := defaults[k]; !ok {
+				k = "pillar_axis"
+			}
+		}
+		if def, ok := defaults[k]; ok {
+			if val, ok := parseProperty(def, v); ok {
+				properties[k] = val
+			}
+		}
+	}
+	b, ok := world.BlockByName(name, properties)
+	if !ok {
+		b, _ = world.BlockByName(name, defaults)
+	}
+	return b, liq
+}
+
+// parseProperty parses the value of a Java Edition block state property to a
+// value of the same type as the Bedrock Edition property def.
+func parseProperty(def any, v string) (any, bool) {
+	switch def.(type) {
+	case string:
+		return v, true
+	case int32:
+		n, err := strconv.ParseInt(v, 10, 32)
+		return int32(n), err == nil
+	case uint8:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, false
+		}
+		if b {
+			return uint8(1), true
+		}
+		return uint8(0), true
+	}
+	return nil, false
+}
+
+// byteArray returns the contents of an NBT byte array decoded into v, which
+// is decoded as an array of a fixed size.
+func byteArray(v any) []byte {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Array || rv.Type().Elem().Kind() != reflect.Uint8 {
+		return nil
+	}
+	b := make([]byte, rv.Len())
+	reflect.Copy(reflect.ValueOf(b), rv)
+	return b
+}
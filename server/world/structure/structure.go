@@ -0,0 +1,171 @@
+// Package structure implements a world.Structure that may be read from and
+// written to structure files, such as Bedrock Edition .mcstructure files and
+// Sponge .schem schematics, or captured from a region of a world.World.
+// Structures may be placed in a world using world.Tx.BuildStructure.
+package structure
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Structure is a world.Structure holding a cuboid of blocks. Every position in
+// a Structure holds a block and, optionally, a liquid in the same position,
+// such as the water in a waterlogged block. Positions may also be left empty,
+// in which case the blocks already in the world remain when the Structure is
+// placed. A Structure may be created using New, Capture or by reading a file
+// using ReadMCStructure or ReadSchematic.
+type Structure struct {
+	size [3]int
+	// origin is the position in the world that the Structure was captured at
+	// or that was stored in the file it was read from.
+	origin cube.Pos
+
+	palette []world.Block
+	ids     map[uint32]int32
+	// blocks and liquids hold an index in the palette for every position in
+	// the Structure. An index of -1 means that there is no block or liquid at
+	// the position.
+	blocks, liquids []int32
+	// nbt holds blocks with NBT data, such as chests and signs, by their index
+	// in blocks.
+	nbt map[int]world.Block
+}
+
+// maxVolume is the maximum number of blocks that a Structure read from a file
+// may hold. It prevents files with very large dimensions from allocating more
+// memory than is reasonable.
+const maxVolume = 1 << 24
+
+// New returns an empty Structure with the dimensions passed: The width, height
+// and length respectively. No blocks are placed by the Structure until they
+// are set using Structure.Set. New panics if any of the dimensions is
+// negative.
+func New(dimensions [3]int) *Structure {
+	s := &Structure{
+		size:    dimensions,
+		ids:     make(map[uint32]int32),
+		blocks:  make([]int32, dimensions[0]*dimensions[1]*dimensions[2]),
+		liquids: make([]int32, dimensions[0]*dimensions[1]*dimensions[2]),
+		nbt:     make(map[int]world.Block),
+	}
+	for i := range s.blocks {
+		s.blocks[i], s.liquids[i] = -1, -1
+	}
+	return s
+}
+
+// volume returns the number of blocks in a Structure with the dimensions
+// passed. An error is returned if any of the dimensions is negative or if the
+// volume exceeds maxVolume.
+func volume(dimensions [3]int) (int, error) {
+	v := 1
+	for _, d := range dimensions {
+		if d < 0 || d > maxVolume {
+			return 0, fmt.Errorf("invalid dimensions %v", dimensions)
+		}
+		if v *= d; v > maxVolume {
+			return 0, fmt.Errorf("dimensions %v exceed the maximum of %v blocks", dimensions, maxVolume)
+		}
+	}
+	return v, nil
+}
+
+// Capture captures the blocks and liquids in the cuboid region between the
+// positions a and b, both inclusive, into a new Structure. Blocks with NBT
+// data, such as chests, are captured along with their data. The origin of the
+// Structure is set to the lowest corner of the region.
+func Capture(tx *world.Tx, a, b cube.Pos) *Structure {
+	low := cube.Pos{min(a[0], b[0]), min(a[1], b[1]), min(a[2], b[2])}
+	high := cube.Pos{max(a[0], b[0]), max(a[1], b[1]), max(a[2], b[2])}
+
+	s := New([3]int{high[0] - low[0] + 1, high[1] - low[1] + 1, high[2] - low[2] + 1})
+	s.origin = low
+	for x := range s.size[0] {
+		for y := range s.size[1] {
+			for z := range s.size[2] {
+				pos := low.Add(cube.Pos{x, y, z})
+				bl := tx.Block(pos)
+				var liq world.Liquid
+				if _, ok := bl.(world.Liquid); !ok {
+					// Only liquids in the second layer, such as the water in a
+					// waterlogged block, are captured separately.
+					liq, _ = tx.Liquid(pos)
+				}
+				s.Set(x, y, z, bl, liq)
+			}
+		}
+	}
+	return s
+}
+
+// Dimensions returns the width, height and length of the Structure.
+func (s *Structure) Dimensions() [3]int {
+	return s.size
+}
+
+// Origin returns the position in the world that the Structure was captured at
+// or that was stored in the file that it was read from.
+func (s *Structure) Origin() cube.Pos {
+	return s.origin
+}
+
+// At returns the block and liquid at a position in the Structure. Nil is
+// returned for positions that were left empty.
+func (s *Structure) At(x, y, z int, _ func(x, y, z int) world.Block) (world.Block, world.Liquid) {
+	i := s.index(x, y, z)
+	var (
+		b   world.Block
+		liq world.Liquid
+	)
+	if nb, ok := s.nbt[i]; ok {
+		b = nb
+	} else if s.blocks[i] != -1 {
+		b = s.palette[s.blocks[i]]
+	}
+	if s.liquids[i] != -1 {
+		liq, _ = s.palette[s.liquids[i]].(world.Liquid)
+	}
+	return b, liq
+}
+
+// Set sets the block and liquid at a position in the Structure. Either of them
+// may be nil to leave the block or liquid at the position empty.
+func (s *Structure) Set(x, y, z int, b world.Block, liq world.Liquid) {
+	i := s.index(x, y, z)
+	s.blocks[i], s.liquids[i] = s.paletteIndex(b), s.paletteIndex(liq)
+	delete(s.nbt, i)
+	if _, ok := b.(world.NBTer); ok {
+		s.nbt[i] = b
+	}
+}
+
+// paletteIndex returns the index of the block passed in the palette of the
+// Structure, adding it to the palette if it is not yet present. -1 is
+// returned if b is nil.
+func (s *Structure) paletteIndex(b world.Block) int32 {
+	if b == nil {
+		return -1
+	}
+	rid := world.BlockRuntimeID(b)
+	if i, ok := s.ids[rid]; ok {
+		return i
+	}
+	i := int32(len(s.palette))
+	s.ids[rid] = i
+	s.palette = append(s.palette, b)
+	return i
+}
+
+// index returns the index of a position in the Structure in blocks and
+// liquids. Positions are ordered by x, y and z, with z changing the fastest,
+// like in .mcstructure files.
+func (s *Structure) index(x, y, z int) int {
+	return (x*s.size[1]+y)*s.size[2] + z
+}
+
+// pos returns the position in the Structure of an index in blocks and liquids.
+func (s *Structure) pos(i int) (x, y, z int) {
+	return i / (s.size[1] * s.size[2]), i / s.size[2] % s.size[1], i % s.size[2]
+}
@@ -20,13 +20,15 @@ type ticker struct {
 func (t ticker) tickLoop(w *World) {
 	tc := time.NewTicker(t.interval)
 	defer tc.Stop()
+	// World is being closed once the loop returns: Get rid of a task.
+	defer w.running.Done()
 	for {
 		select {
 		case <-tc.C:
-			<-w.Exec(t.tick)
+			if !w.execUntilClosed(t.tick) {
+				return
+			}
 		case <-w.closing:
-			// World is being closed: Stop ticking and get rid of a task.
-			w.running.Done()
 			return
 		}
 	}
@@ -37,6 +39,7 @@ func (t ticker) tickLoop(w *World) {
 func (t ticker) tick(tx *Tx) {
 	viewers, loaders := tx.World().allViewers()
 	w := tx.World()
+	w.insertLoadedChunks()
 
 	w.set.Lock()
 	if s := w.set.Spawn; s[1] > tx.Range()[1] {
@@ -77,9 +80,14 @@ func (t ticker) tick(tx *Tx) {
 	}
 
 	t.tickEntities(tx, tick)
+	w.tickRiders(tx)
+	w.tickSleeping(tx)
 	w.scheduledUpdates.tick(tx, tick)
+	w.tickMovingBlocks(tx)
+	t.tickSpawning(tx, loaders, tick)
 	t.tickBlocksRandomly(tx, loaders, tick)
 	t.performNeighbourUpdates(tx)
+	w.tickMaps(viewers)
 }
 
 // performNeighbourUpdates performs all block updates that came as a result of a neighbouring block being changed.
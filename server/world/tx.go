@@ -86,6 +86,28 @@ func (tx *Tx) ScheduleBlockUpdate(pos cube.Pos, b Block, delay time.Duration) {
 	tx.World().scheduleBlockUpdate(pos, b, delay)
 }
 
+// UpdateBlocksAround performs block updates directly around and on the
+// position passed, as if the block at that position was changed. Blocks
+// implementing NeighbourUpdateTicker have their NeighbourUpdateTick method
+// called at the end of the tick. UpdateBlocksAround may be used to notify
+// blocks of a change that does not involve changing a block, such as a change
+// in the redstone power passing through a solid block.
+func (tx *Tx) UpdateBlocksAround(pos cube.Pos) {
+	tx.World().doBlockUpdatesAround(pos)
+}
+
+// MoveBlocks moves the blocks at the positions passed one block towards the
+// face passed, for example because they are pushed or pulled by a piston.
+// Unlike SetBlock, MoveBlocks does not move the blocks instantly. Instead, each
+// block is replaced by the block returned by the moving function at the
+// position it moves to, which should represent the block while it is in
+// motion. Positions that the blocks moved away from are set to air. Over the
+// next two ticks, entities in the way of the blocks are moved along with them,
+// after which the blocks in motion are replaced by the blocks that were moved.
+func (tx *Tx) MoveBlocks(positions []cube.Pos, face cube.Face, moving func(b Block) Block) {
+	tx.World().moveBlocks(positions, face, moving)
+}
+
 // HighestLightBlocker gets the Y value of the highest fully light blocking
 // block at the x and z values passed in the World.
 func (tx *Tx) HighestLightBlocker(x, z int) int {
@@ -200,6 +222,16 @@ func (tx *Tx) EntitiesWithin(box cube.BBox) iter.Seq[Entity] {
 	return tx.World().entitiesWithin(tx, box)
 }
 
+// BlocksWithin returns an iterator that yields the positions of all blocks
+// between the positions a and b, both inclusive, for which f returns true. f
+// is called once for every kind of block in the area, without any block
+// entity data. Sub chunks without any block for which f returns true are
+// skipped as a whole, which makes BlocksWithin considerably faster than
+// calling Block for every position when searching for rare blocks.
+func (tx *Tx) BlocksWithin(a, b cube.Pos, f func(b Block) bool) iter.Seq[cube.Pos] {
+	return tx.World().blocksWithin(a, b, f)
+}
+
 // Entities returns an iterator that yields all entities in the World.
 func (tx *Tx) Entities() iter.Seq[Entity] {
 	return tx.World().allEntities(tx)
@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
+	"image"
 	"time"
 )
 
@@ -43,6 +44,11 @@ type Viewer interface {
 	ViewEntityItems(e Entity)
 	// ViewEntityArmour views the items currently equipped as armour by the Entity.
 	ViewEntityArmour(e Entity)
+	// ViewEntityMount views an Entity starting to ride a Rideable vehicle. The seat of the rider may be
+	// obtained using EntityHandle.Seat.
+	ViewEntityMount(rider Entity, vehicle Rideable)
+	// ViewEntityDismount views an Entity no longer riding the vehicle passed.
+	ViewEntityDismount(rider, vehicle Entity)
 	// ViewEntityAction views an action performed by an Entity. Available actions may be found in the `action`
 	// package, and include things such as swinging an arm.
 	ViewEntityAction(e Entity, a EntityAction)
@@ -70,6 +76,9 @@ type Viewer interface {
 	ViewWorldSpawn(pos cube.Pos)
 	// ViewWeather views the weather of the world, including rain and thunder.
 	ViewWeather(raining, thunder bool)
+	// ViewMap views the changes made to a Map. area is the area of pixels of the Map that changed, which is
+	// empty if only the markers of the Map changed.
+	ViewMap(m *Map, area image.Rectangle)
 }
 
 // NopViewer is a Viewer implementation that does not implement any behaviour. It may be embedded by other structs to
@@ -90,6 +99,8 @@ func (NopViewer) ViewTime(int)
 func (NopViewer) ViewEntityItems(Entity)                                                     {}
 func (NopViewer) ViewEntityArmour(Entity)                                                    {}
 func (NopViewer) ViewEntityAction(Entity, EntityAction)                                      {}
+func (NopViewer) ViewEntityMount(Entity, Rideable)                                           {}
+func (NopViewer) ViewEntityDismount(Entity, Entity)                                          {}
 func (NopViewer) ViewEntityState(Entity)                                                     {}
 func (NopViewer) ViewEntityAnimation(Entity, EntityAnimation)                                {}
 func (NopViewer) ViewParticle(mgl64.Vec3, Particle)                                          {}
@@ -100,6 +111,7 @@ func (NopViewer) ViewEmote(Entity, uuid.UUID)
 func (NopViewer) ViewSkin(Entity)                                                            {}
 func (NopViewer) ViewWorldSpawn(cube.Pos)                                                    {}
 func (NopViewer) ViewWeather(bool, bool)                                                     {}
+func (NopViewer) ViewMap(*Map, image.Rectangle)                                              {}
 func (NopViewer) ViewBrewingUpdate(time.Duration, time.Duration, int32, int32, int32, int32) {}
 func (NopViewer) ViewFurnaceUpdate(time.Duration, time.Duration, time.Duration, time.Duration, time.Duration, time.Duration) {
 }
@@ -64,6 +64,18 @@ func (w weather) thunderingAt(pos cube.Pos) bool {
 	return a && w.w.highestObstructingBlock(pos[0], pos[2]) < pos[1]
 }
 
+// Thundering checks if it is currently thundering in the World. Unlike
+// Tx.ThunderingAt, Thundering does not take the position or biome into
+// account.
+func (w weather) Thundering() bool {
+	if w.w == nil || !w.w.Dimension().WeatherCycle() {
+		return false
+	}
+	w.w.set.Lock()
+	defer w.w.set.Unlock()
+	return w.w.set.Thundering && w.w.set.Raining
+}
+
 // StartRaining makes it rain in the World. The time.Duration passed will
 // determine how long it will rain.
 func (w weather) StartRaining(dur time.Duration) {
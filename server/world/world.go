@@ -2,18 +2,17 @@ package world
 
 import (
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/internal/sliceutil"
 	"github.com/df-mc/dragonfly/server/world/chunk"
-	"github.com/df-mc/goleveldb/leveldb"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
 	"iter"
 	"maps"
 	"math/rand/v2"
+	"runtime"
 	"slices"
 	"sync"
 	"sync/atomic"
@@ -48,12 +47,20 @@ type World struct {
 	// chunks holds a cache of chunks currently loaded. These chunks are cleared
 	// from this map after some time of not being used.
 	chunks map[ChunkPos]*Column
+	// chunkWorkers loads chunks requested by loaders off the transaction
+	// goroutine.
+	chunkWorkers *chunkWorkers
 
 	// entities holds a map of entities currently loaded and the last ChunkPos
 	// that the Entity was in. These are tracked so that a call to RemoveEntity
 	// can find the correct Entity.
 	entities map[*EntityHandle]ChunkPos
 
+	// maps holds the maps that are currently loaded, indexed by their ID.
+	// Maps are loaded from the Provider when first requested and remain
+	// loaded until the World is closed.
+	maps map[int64]*Map
+
 	r *rand.Rand
 
 	// scheduledUpdates is a map of tick time values indexed by the block
@@ -62,6 +69,15 @@ type World struct {
 	// be removed from the map.
 	scheduledUpdates *scheduledTickQueue
 	neighbourUpdates []neighbourUpdate
+	// movingBlocks holds groups of blocks that are currently in motion, for
+	// example because they are pushed by a piston.
+	movingBlocks []blockMovement
+	// categories holds the SpawnCategory of every EntityType that may spawn
+	// naturally. It is lazily filled when mobs first attempt to spawn.
+	categories map[EntityType]SpawnCategory
+	// sleepTicks is the amount of ticks that enough players have been sleeping
+	// for to skip the night.
+	sleepTicks int
 
 	viewerMu sync.Mutex
 	viewers  map[*Loader]Viewer
@@ -116,6 +132,80 @@ func (w *World) Exec(f ExecFunc) <-chan struct{} {
 	return c
 }
 
+// ExecLoaded performs a synchronised transaction f on a World like Exec, but
+// first loads or generates the chunks at the positions passed in the
+// background, so that f may use them without stalling other transactions
+// while they are loaded. ExecLoaded returns a channel that is closed once the
+// transaction is complete, or once the World is closed if that happens before
+// f could be run, in which case f is never run.
+func (w *World) ExecLoaded(chunks []ChunkPos, f ExecFunc) <-chan struct{} {
+	c := make(chan struct{})
+	w.running.Add(1)
+	go func() {
+		defer w.running.Done()
+		defer close(c)
+
+		var requested []ChunkPos
+		if !w.execUntilClosed(func(tx *Tx) {
+			for _, pos := range chunks {
+				if _, ok := w.chunks[pos]; ok {
+					continue
+				}
+				if _, ok := w.chunkWorkers.pending[pos]; ok {
+					continue
+				}
+				// Mark the chunk as pending like the chunk workers do, so
+				// that it is discarded if it is loaded in the meantime.
+				w.chunkWorkers.pending[pos] = false
+				requested = append(requested, pos)
+			}
+		}) {
+			return
+		}
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+		for _, pos := range requested {
+			wg.Add(1)
+			w.running.Add(1)
+			go func() {
+				defer w.running.Done()
+				defer wg.Done()
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-w.closing:
+					// The Provider may be closed once the World is closed,
+					// so the chunk must no longer be read.
+					return
+				}
+				l := w.readColumn(pos)
+				w.chunkWorkers.mu.Lock()
+				w.chunkWorkers.loaded = append(w.chunkWorkers.loaded, l)
+				w.chunkWorkers.mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		w.execUntilClosed(func(tx *Tx) {
+			w.insertLoadedChunks()
+			f(tx)
+		})
+	}()
+	return c
+}
+
+// execUntilClosed performs a synchronised transaction f on a World like Exec
+// and waits for it to complete. Unlike Exec, it stops waiting if the World is
+// closed before f is run, in which case false is returned and f may never
+// run. It is used by goroutines of the World that must not outlive it.
+func (w *World) execUntilClosed(f ExecFunc) bool {
+	select {
+	case <-w.Exec(f):
+		return true
+	case <-w.closing:
+		return false
+	}
+}
+
 func (w *World) weakExec(invalid *atomic.Bool, cond *sync.Cond, f ExecFunc) <-chan bool {
 	c := make(chan bool, 1)
 	w.queue <- weakTransaction{c: c, f: f, invalid: invalid, cond: cond}
@@ -149,6 +239,54 @@ func (w *World) block(pos cube.Pos) Block {
 	return w.blockInChunk(w.chunk(chunkPosFromBlockPos(pos)), pos)
 }
 
+// blocksWithin returns an iterator that yields the positions of all blocks
+// between a and b for which f returns true. See Tx.BlocksWithin.
+func (w *World) blocksWithin(a, b cube.Pos, f func(Block) bool) iter.Seq[cube.Pos] {
+	return func(yield func(cube.Pos) bool) {
+		low := cube.Pos{min(a[0], b[0]), max(min(a[1], b[1]), w.ra[0]), min(a[2], b[2])}
+		high := cube.Pos{max(a[0], b[0]), min(max(a[1], b[1]), w.ra[1]), max(a[2], b[2])}
+
+		matches := make(map[uint32]bool)
+		match := func(rid uint32) bool {
+			m, ok := matches[rid]
+			if !ok {
+				m = f(blockByRuntimeIDOrAir(rid))
+				matches[rid] = m
+			}
+			return m
+		}
+		for cx := low[0] >> 4; cx <= high[0]>>4; cx++ {
+			for cz := low[2] >> 4; cz <= high[2]>>4; cz++ {
+				c := w.chunk(ChunkPos{int32(cx), int32(cz)})
+				for sy := low[1] >> 4; sy <= high[1]>>4; sy++ {
+					layers := c.SubChunk(int16(sy << 4)).Layers()
+					if len(layers) == 0 {
+						continue
+					}
+					storage, found := layers[0], false
+					for i := range storage.Palette().Len() {
+						if found = match(storage.Palette().Value(uint16(i))); found {
+							break
+						}
+					}
+					if !found {
+						continue
+					}
+					for x := max(low[0], cx<<4); x <= min(high[0], cx<<4+15); x++ {
+						for z := max(low[2], cz<<4); z <= min(high[2], cz<<4+15); z++ {
+							for y := max(low[1], sy<<4); y <= min(high[1], sy<<4+15); y++ {
+								if match(storage.At(uint8(x&0xf), uint8(y&0xf), uint8(z&0xf))) && !yield(cube.Pos{x, y, z}) {
+									return
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
 // blockInChunk reads a block from a chunk at the position passed. The block
 // is assumed to be within the chunk passed.
 func (w *World) blockInChunk(c *Column, pos cube.Pos) Block {
@@ -693,6 +831,7 @@ func (w *World) removeEntity(e Entity, tx *Tx) *EntityHandle {
 		return nil
 	}
 	w.Handler().HandleEntityDespawn(tx, e)
+	w.unlinkEntity(tx, e)
 
 	c := w.chunk(pos)
 	c.Entities, c.modified = sliceutil.DeleteVal(c.Entities, handle), true
@@ -810,6 +949,18 @@ func (w *World) SetPlayerSpawn(id uuid.UUID, pos cube.Pos) {
 	}
 }
 
+// RemovePlayerSpawn removes the spawn position of a player with a UUID in this
+// World, so that PlayerSpawn returns the spawn of the World for the player
+// again, even if the spawn of the World changes later.
+func (w *World) RemovePlayerSpawn(id uuid.UUID) {
+	if w == nil {
+		return
+	}
+	if err := w.conf.Provider.RemovePlayerSpawnPosition(id); err != nil {
+		w.conf.Log.Error("remove player spawn: "+err.Error(), "ID", id)
+	}
+}
+
 // DefaultGameMode returns the default game mode of the world. When players
 // join, they are given this game mode. The default game mode may be changed
 // using SetDefaultGameMode().
@@ -872,6 +1023,29 @@ func (w *World) SetDifficulty(d Difficulty) {
 	w.set.Difficulty = d
 }
 
+// PlayersSleepingPercentage returns the percentage of players in the World
+// that must be sleeping for the night to be skipped.
+func (w *World) PlayersSleepingPercentage() int {
+	if w == nil {
+		return 100
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return int(w.set.PlayersSleepingPercentage)
+}
+
+// SetPlayersSleepingPercentage changes the percentage of players in the World
+// that must be sleeping for the night to be skipped. The percentage is clamped
+// between 0 and 100. If 0, a single sleeping player skips the night.
+func (w *World) SetPlayersSleepingPercentage(percentage int) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.PlayersSleepingPercentage = int32(min(max(percentage, 0), 100))
+}
+
 // scheduleBlockUpdate schedules a block update at the position passed for the
 // block type passed after a specific delay. If the block at that position does
 // not handle block updates, nothing will happen.
@@ -963,13 +1137,16 @@ func (w *World) save(f func(*Tx, ChunkPos, *Column)) ExecFunc {
 		for pos, c := range w.chunks {
 			f(tx, pos, c)
 		}
+		w.saveMaps()
 		w.conf.Log.Debug("Updating level.dat values...")
 		w.conf.Provider.SaveSettings(w.set)
 	}
 }
 
 // saveChunk saves a chunk and its entities to disk after compacting the chunk.
+// Blocks that are moving into the chunk are placed first.
 func (w *World) saveChunk(_ *Tx, pos ChunkPos, c *Column) {
+	w.finishMovements(pos, nil)
 	if !w.conf.ReadOnly && c.modified {
 		c.Compact()
 		if err := w.conf.Provider.StoreColumn(pos, w.conf.Dim, w.columnTo(c, pos)); err != nil {
@@ -982,6 +1159,9 @@ func (w *World) saveChunk(_ *Tx, pos ChunkPos, c *Column) {
 // Afterwards, scheduled updates from that chunk are removed and all entities
 // in it are closed.
 func (w *World) closeChunk(tx *Tx, pos ChunkPos, c *Column) {
+	// Blocks moving into the chunk are placed without updating their
+	// neighbours, which could otherwise load the chunk again.
+	w.finishMovements(pos, &SetOpts{DisableBlockUpdates: true})
 	w.saveChunk(tx, pos, c)
 	w.scheduledUpdates.removeChunk(pos)
 	// Note: We close c.Entities here because some entities may remove
@@ -1111,39 +1291,16 @@ func (w *World) chunk(pos ChunkPos) *Column {
 	if ok {
 		return c
 	}
-	c, err := w.loadChunk(pos)
-	chunk.LightArea([]*chunk.Chunk{c.Chunk}, int(pos[0]), int(pos[1])).Fill()
-	if err != nil {
-		w.conf.Log.Error("load chunk: "+err.Error(), "X", pos[0], "Z", pos[1])
-		return c
-	}
-	w.calculateLight(pos)
-	return c
-}
-
-// loadChunk attempts to load a chunk from the provider, or generates a chunk
-// if one doesn't currently exist.
-func (w *World) loadChunk(pos ChunkPos) (*Column, error) {
-	column, err := w.conf.Provider.LoadColumn(pos, w.conf.Dim)
-	switch {
-	case err == nil:
-		col := w.columnFrom(column, pos)
-		w.chunks[pos] = col
-		for _, e := range col.Entities {
-			w.entities[e] = pos
-			e.w = w
-		}
-		return col, nil
-	case errors.Is(err, leveldb.ErrNotFound):
-		// The provider doesn't have a chunk saved at this position, so we generate a new one.
-		col := newColumn(chunk.New(airRID, w.Range()))
-		w.chunks[pos] = col
-
-		w.conf.Generator.GenerateChunk(pos, col.Chunk)
-		return col, nil
-	default:
-		return newColumn(chunk.New(airRID, w.Range())), err
+	// The chunk is needed right away, so load it synchronously. If a chunk
+	// worker is also loading it, its result is discarded once it is done.
+	w.chunkWorkers.discard(pos)
+	l := w.readColumn(pos)
+	if l.err != nil {
+		w.conf.Log.Error("load chunk: "+l.err.Error(), "X", pos[0], "Z", pos[1])
+		return l.col
 	}
+	w.insertColumn(l)
+	return l.col
 }
 
 // calculateLight calculates the light in the chunk passed and spreads the
@@ -1193,14 +1350,18 @@ func (w *World) autoSave() {
 	closeUnused := time.NewTicker(time.Minute * 2)
 	defer closeUnused.Stop()
 
+	defer w.running.Done()
 	for {
 		select {
 		case <-closeUnused.C:
-			<-w.Exec(w.closeUnusedChunks)
+			if !w.execUntilClosed(w.closeUnusedChunks) {
+				return
+			}
 		case <-save.C:
-			w.Save()
+			if !w.execUntilClosed(w.save(w.saveChunk)) {
+				return
+			}
 		case <-w.closing:
-			w.running.Done()
 			return
 		}
 	}
@@ -1294,11 +1455,5 @@ func (w *World) columnFrom(c *chunk.Column, _ ChunkPos) *Column {
 		}
 		col.BlockEntities[be.Pos] = nb.DecodeNBT(be.Data).(Block)
 	}
-	scheduled, savedTick := make([]scheduledTick, 0, len(c.ScheduledBlocks)), c.Tick
-	for _, t := range c.ScheduledBlocks {
-		bl := blockByRuntimeIDOrAir(t.Block)
-		scheduled = append(scheduled, scheduledTick{pos: t.Pos, b: bl, bhash: BlockHash(bl), t: w.scheduledUpdates.currentTick + (t.Tick - savedTick)})
-	}
-	w.scheduledUpdates.add(scheduled)
 	return col
 }
@@ -0,0 +1,191 @@
+package server
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Names of the default worlds of a Server, under which they may be found
+// using Server.WorldByName.
+const (
+	OverworldName = "overworld"
+	NetherName    = "nether"
+	EndName       = "end"
+)
+
+// WorldConfig holds the settings of a world loaded using Server.LoadWorld.
+type WorldConfig struct {
+	// Dim is the world.Dimension of the world. If nil, world.Overworld is
+	// used.
+	Dim world.Dimension
+	// Folder is the folder that the data of the world is stored in using the
+	// default LevelDB provider. The folder is created if it does not yet
+	// exist. If empty and Provider is nil, the data of the world is not
+	// saved.
+	Folder string
+	// Provider is the world.Provider used to read and write the data of the
+	// world. If set, Folder is ignored.
+	Provider world.Provider
+	// ReadOnly specifies if the world should be read only. If set to true,
+	// no data is written to the Provider.
+	ReadOnly bool
+	// Generator is the world.Generator used to generate new areas of the
+	// world. If nil, the generator of the Config of the Server for Dim is
+	// used.
+	Generator world.Generator
+	// Nether and End are the names of the worlds that nether and end portals
+	// in the world lead to, such as NetherName and EndName. For a world in
+	// the nether, Nether should be the name of the overworld that its nether
+	// portals lead back to. If empty, or if no world with the name is loaded,
+	// portals of that type do not function in the world.
+	Nether, End string
+}
+
+// LoadWorld loads a world with the name passed and adds it to the Server, so
+// that it may be found using WorldByName. If the world has a Folder that
+// already holds world data, the world is loaded from it. Otherwise, a new world
+// is created. An error is returned if a world with the same name is already
+// loaded or if the world.Provider could not be opened.
+// Note that players that quit while in a world loaded with LoadWorld are saved
+// at their spawn in the overworld of the Server, as player data does not hold
+// the world that a player was in.
+func (srv *Server) LoadWorld(name string, conf WorldConfig) (*world.World, error) {
+	key := strings.ToLower(name)
+	if _, ok := srv.WorldByName(key); ok {
+		return nil, fmt.Errorf("load world %v: a world with this name is already loaded", name)
+	}
+	if conf.Dim == nil {
+		conf.Dim = world.Overworld
+	}
+	if conf.Generator == nil {
+		conf.Generator = srv.conf.Generator(conf.Dim)
+	}
+	log := srv.conf.Log.With("world", name)
+	if conf.Provider == nil && conf.Folder != "" {
+		db, err := mcdb.Config{Log: log}.Open(conf.Folder)
+		if err != nil {
+			return nil, fmt.Errorf("load world %v: %w", name, err)
+		}
+		conf.Provider = db
+	}
+	w := world.Config{
+		Log:             log,
+		Dim:             conf.Dim,
+		Provider:        conf.Provider,
+		Generator:       conf.Generator,
+		RandomTickSpeed: srv.conf.RandomTickSpeed,
+		MobSpawning:     srv.conf.MobSpawning,
+		ReadOnly:        conf.ReadOnly,
+		Entities:        srv.conf.Entities,
+		PortalDestination: func(dim world.Dimension) *world.World {
+			var dest string
+			switch dim {
+			case world.Nether:
+				dest = conf.Nether
+			case world.End:
+				dest = conf.End
+			}
+			w, _ := srv.WorldByName(dest)
+			return w
+		},
+	}.New()
+	srv.applyWorldSettings(w)
+
+	srv.wmu.Lock()
+	defer srv.wmu.Unlock()
+	if srv.worlds == nil {
+		_ = w.Close()
+		return nil, fmt.Errorf("load world %v: server closed", name)
+	}
+	if _, ok := srv.worlds[key]; ok {
+		_ = w.Close()
+		return nil, fmt.Errorf("load world %v: a world with this name is already loaded", name)
+	}
+	srv.worlds[key] = w
+	log.Info("Loaded world.", "dimension", strings.ToLower(fmt.Sprint(conf.Dim)))
+	return w, nil
+}
+
+// UnloadWorld closes the world with the name passed and removes it from the
+// Server. Players in the world are moved to the spawn of the overworld of the
+// Server first. Other entities are saved along with the world. The default
+// worlds of the Server cannot be unloaded. An error is returned if no world
+// with the name is loaded.
+func (srv *Server) UnloadWorld(name string) error {
+	key := strings.ToLower(name)
+	switch key {
+	case OverworldName, NetherName, EndName:
+		return fmt.Errorf("unload world %v: default worlds cannot be unloaded", name)
+	}
+	srv.wmu.Lock()
+	w, ok := srv.worlds[key]
+	delete(srv.worlds, key)
+	srv.wmu.Unlock()
+	if !ok {
+		return fmt.Errorf("unload world %v: no world with this name is loaded", name)
+	}
+
+	var handles []*world.EntityHandle
+	<-w.Exec(func(tx *world.Tx) {
+		for e := range tx.Players() {
+			handles = append(handles, tx.RemoveEntity(e))
+		}
+	})
+	// The players must be in the overworld before the world is closed, as
+	// they would otherwise not be in any world until they are added.
+	<-srv.world.Exec(func(tx *world.Tx) {
+		for _, handle := range handles {
+			p := tx.AddEntity(handle).(*player.Player)
+			p.Teleport(tx.World().PlayerSpawn(p.UUID()).Vec3Middle())
+		}
+	})
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("unload world %v: %w", name, err)
+	}
+	srv.conf.Log.Info("Unloaded world.", "world", name)
+	return nil
+}
+
+// WorldByName looks up a world loaded by the Server by its name. The default
+// worlds of the Server are found using OverworldName, NetherName and EndName.
+// Worlds loaded using LoadWorld are found using the name passed to it. Names
+// are case-insensitive.
+func (srv *Server) WorldByName(name string) (*world.World, bool) {
+	srv.wmu.RLock()
+	defer srv.wmu.RUnlock()
+	w, ok := srv.worlds[strings.ToLower(name)]
+	return w, ok
+}
+
+// Worlds returns an iterator over the names and worlds of all worlds loaded by
+// the Server, including its default worlds, sorted by name.
+func (srv *Server) Worlds() iter.Seq2[string, *world.World] {
+	srv.wmu.RLock()
+	worlds := maps.Clone(srv.worlds)
+	srv.wmu.RUnlock()
+
+	return func(yield func(string, *world.World) bool) {
+		for _, name := range slices.Sorted(maps.Keys(worlds)) {
+			if !yield(name, worlds[name]) {
+				return
+			}
+		}
+	}
+}
+
+// applyWorldSettings applies the difficulty and tick range set in the Config
+// of the Server to the world passed.
+func (srv *Server) applyWorldSettings(w *world.World) {
+	if srv.conf.Difficulty != nil {
+		w.SetDifficulty(srv.conf.Difficulty)
+	}
+	if srv.conf.TickRange != 0 {
+		w.SetTickRange(srv.conf.TickRange)
+	}
+}